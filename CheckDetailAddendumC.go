@@ -7,13 +7,50 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
 
 // Errors specific to a CheckDetailAddendumC Record
 
+// EndorsingBankType identifies the role of the bank whose routing number is in an endorsement record, as
+// conveyed by the EndorsingBankIdentifier of CheckDetailAddendumC and ReturnDetailAddendumD.
+type EndorsingBankType int
+
+const (
+	// EndorsingBankDepository is the Depository Bank (BOFD), or the Return Processing Bank in lieu of BOFD.
+	EndorsingBankDepository EndorsingBankType = 0
+	// EndorsingBankOtherCollecting is another Collecting Bank
+	EndorsingBankOtherCollecting EndorsingBankType = 1
+	// EndorsingBankOtherReturning is another Returning Bank
+	EndorsingBankOtherReturning EndorsingBankType = 2
+	// EndorsingBankPayor is the Payor Bank
+	EndorsingBankPayor EndorsingBankType = 3
+)
+
+func (t EndorsingBankType) String() string {
+	switch t {
+	case EndorsingBankDepository:
+		return "Depository Bank"
+	case EndorsingBankOtherCollecting:
+		return "Other Collecting Bank"
+	case EndorsingBankOtherReturning:
+		return "Other Returning Bank"
+	case EndorsingBankPayor:
+		return "Payor Bank"
+	}
+	return fmt.Sprintf("EndorsingBankType(%d)", int(t))
+}
+
+// endorsingBankType returns identifier as an EndorsingBankType or an error if it's not a defined value
+func endorsingBankType(identifier int) (EndorsingBankType, error) {
+	var v validator
+	if err := v.isEndorsingBankIdentifier(identifier); err != nil {
+		return 0, &FieldError{FieldName: "EndorsingBankIdentifier", Value: strconv.Itoa(identifier), Msg: err.Error()}
+	}
+	return EndorsingBankType(identifier), nil
+}
+
 // CheckDetailAddendumC Record
 type CheckDetailAddendumC struct {
 	// ID is a client defined string used as a reference to this record.
@@ -83,6 +120,8 @@ type CheckDetailAddendumC struct {
 	EndorsingBankIdentifier int `json:"endorsingBankIdentifier"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -100,7 +139,12 @@ func (cdAddendumC *CheckDetailAddendumC) setRecordType() {
 	if cdAddendumC == nil {
 		return
 	}
-	cdAddendumC.recordType = "28"
+	cdAddendumC.recordType = RecordTypeCheckDetailAddendumC
+}
+
+// RecordType returns the record type of the CheckDetailAddendumC, which is RecordTypeCheckDetailAddendumC once set by NewCheckDetailAddendumC or Parse
+func (cdAddendumC *CheckDetailAddendumC) RecordType() string {
+	return cdAddendumC.recordType
 }
 
 // Parse takes the input record string and parses the CheckDetailAddendumC values
@@ -132,26 +176,29 @@ func (cdAddendumC *CheckDetailAddendumC) Parse(record string) {
 	// 60-60
 	cdAddendumC.EndorsingBankIdentifier = cdAddendumC.parseNumField(record[59:60])
 	// 61-80
-	cdAddendumC.reserved = "                    "
+	cdAddendumC.reserved = cdAddendumC.parseReservedField(record, 60, 80)
 }
 
 // String writes the CheckDetailAddendumC struct to a string.
 func (cdAddendumC *CheckDetailAddendumC) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(cdAddendumC.recordType)
-	buf.WriteString(cdAddendumC.RecordNumberField())
-	buf.WriteString(cdAddendumC.EndorsingBankRoutingNumberField())
-	buf.WriteString(cdAddendumC.BOFDEndorsementBusinessDateField())
-	buf.WriteString(cdAddendumC.EndorsingBankItemSequenceNumberField())
-	buf.WriteString(cdAddendumC.TruncationIndicatorField())
-	buf.WriteString(cdAddendumC.EndorsingBankConversionIndicatorField())
-	buf.WriteString(cdAddendumC.EndorsingBankCorrectionIndicatorField())
-	buf.WriteString(cdAddendumC.ReturnReasonField())
-	buf.WriteString(cdAddendumC.UserFieldField())
-	buf.WriteString(cdAddendumC.EndorsingBankIdentifierField())
-	buf.WriteString(cdAddendumC.reservedField())
-	return buf.String()
+	return string(cdAddendumC.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the CheckDetailAddendumC record, as String returns it, to b and returns the extended buffer.
+func (cdAddendumC *CheckDetailAddendumC) AppendTo(b []byte) []byte {
+	b = append(b, cdAddendumC.recordType...)
+	b = cdAddendumC.appendNumericField(b, cdAddendumC.RecordNumber, 2)
+	b = cdAddendumC.appendStringField(b, cdAddendumC.EndorsingBankRoutingNumber, 9)
+	b = cdAddendumC.appendYYYYMMDDDate(b, cdAddendumC.BOFDEndorsementBusinessDate)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.EndorsingBankItemSequenceNumber, 15)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.TruncationIndicator, 1)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.EndorsingBankConversionIndicator, 1)
+	b = cdAddendumC.appendNumericField(b, cdAddendumC.EndorsingBankCorrectionIndicator, 1)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.ReturnReason, 1)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.UserField, 19)
+	b = cdAddendumC.appendNumericField(b, cdAddendumC.EndorsingBankIdentifier, 1)
+	b = cdAddendumC.appendAlphaField(b, cdAddendumC.reserved, 20)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -160,9 +207,9 @@ func (cdAddendumC *CheckDetailAddendumC) Validate() error {
 	if err := cdAddendumC.fieldInclusion(); err != nil {
 		return err
 	}
-	if cdAddendumC.recordType != "28" {
+	if cdAddendumC.recordType != RecordTypeCheckDetailAddendumC {
 		msg := fmt.Sprintf(msgRecordType, 28)
-		return &FieldError{FieldName: "recordType", Value: cdAddendumC.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: cdAddendumC.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := cdAddendumC.isNumeric(cdAddendumC.EndorsingBankRoutingNumber); err != nil {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
@@ -187,9 +234,12 @@ func (cdAddendumC *CheckDetailAddendumC) Validate() error {
 				Value: cdAddendumC.EndorsingBankCorrectionIndicatorField(), Msg: err.Error()}
 		}
 	}
-	if err := cdAddendumC.isAlphanumeric(cdAddendumC.ReturnReason); err != nil {
-		return &FieldError{FieldName: "ReturnReason",
-			Value: cdAddendumC.ReturnReason, Msg: err.Error()}
+	// Conditional
+	if cdAddendumC.ReturnReason != "" {
+		if err := cdAddendumC.isReturnReason(cdAddendumC.ReturnReason); err != nil {
+			return &FieldError{FieldName: "ReturnReason",
+				Value: cdAddendumC.ReturnReason, Msg: err.Error()}
+		}
 	}
 	if err := cdAddendumC.isAlphanumericSpecial(cdAddendumC.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: cdAddendumC.UserField, Msg: err.Error()}
@@ -198,6 +248,9 @@ func (cdAddendumC *CheckDetailAddendumC) Validate() error {
 		return &FieldError{FieldName: "EndorsingBankIdentifier",
 			Value: cdAddendumC.EndorsingBankIdentifierField(), Msg: err.Error()}
 	}
+	if err := cdAddendumC.isDateInRange(cdAddendumC.BOFDEndorsementBusinessDate); err != nil {
+		return &FieldError{FieldName: "BOFDEndorsementBusinessDate", Value: cdAddendumC.BOFDEndorsementBusinessDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -207,37 +260,37 @@ func (cdAddendumC *CheckDetailAddendumC) fieldInclusion() error {
 	if cdAddendumC.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: cdAddendumC.recordType,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumC.RecordNumber == 0 {
 		return &FieldError{FieldName: "RecordNumber",
 			Value: cdAddendumC.RecordNumberField(),
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumC.EndorsingBankRoutingNumber == "" {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
 			Value: cdAddendumC.EndorsingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
-	if cdAddendumC.EndorsingBankRoutingNumberField() == "000000000" {
+	if cdAddendumC.isZeroRoutingNumber(cdAddendumC.EndorsingBankRoutingNumber) {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
 			Value: cdAddendumC.EndorsingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumC.BOFDEndorsementBusinessDate.IsZero() {
 		return &FieldError{FieldName: "BOFDEndorsementBusinessDate",
 			Value: cdAddendumC.BOFDEndorsementBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumC.EndorsingBankItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "EndorsingBankItemSequenceNumber",
 			Value: cdAddendumC.EndorsingBankItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumC.TruncationIndicator == "" {
 		return &FieldError{FieldName: "TruncationIndicator",
 			Value: cdAddendumC.TruncationIndicator,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -292,6 +345,12 @@ func (cdAddendumC *CheckDetailAddendumC) EndorsingBankIdentifierField() string {
 	return cdAddendumC.numericField(cdAddendumC.EndorsingBankIdentifier, 1)
 }
 
+// EndorsingBankType returns the EndorsingBankIdentifier as an EndorsingBankType. An error is returned
+// if EndorsingBankIdentifier is not a defined value.
+func (cdAddendumC *CheckDetailAddendumC) EndorsingBankType() (EndorsingBankType, error) {
+	return endorsingBankType(cdAddendumC.EndorsingBankIdentifier)
+}
+
 // reservedField gets reserved - blank space
 func (cdAddendumC *CheckDetailAddendumC) reservedField() string {
 	return cdAddendumC.alphaField(cdAddendumC.reserved, 20)
@@ -0,0 +1,255 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"time"
+)
+
+// Errors specific to building a File with a FileBuilder
+var (
+	msgBuilderCashLetter    = "AddCashLetter must be called first"
+	msgBuilderRoutingNumber = "must be 9 digits including the check digit"
+)
+
+// CheckItemSpec describes a check added to a File by FileBuilder.AddCheckItem.
+type CheckItemSpec struct {
+	// PayorBankRoutingNumber is the 9 digit routing number of the payor bank, including the check digit
+	PayorBankRoutingNumber string
+	// OnUs is the On-Us field of the MICR line, usually the payor's account number
+	OnUs string
+	// AuxiliaryOnUs is the Auxiliary On-Us field of the MICR line, used on commercial checks
+	AuxiliaryOnUs string
+	// ItemAmount is the amount of the check in cents
+	ItemAmount int64
+	// PayeeName is the name of the payee written in the CheckDetailAddendumA
+	PayeeName string
+	// BOFDAccountNumber is the account at the BOFD the check is deposited to
+	BOFDAccountNumber string
+	// FrontImage and BackImage are the TIFF images of each side of the check. An ImageViewDetail and
+	// ImageViewData are added for each image which is not empty.
+	FrontImage []byte
+	BackImage  []byte
+}
+
+// FileBuilder assembles a File from CashLetterHeaders and CheckItemSpecs, creating the Bundles,
+// addenda, image views and control records. Each record is validated as it is added and the first
+// error stops the build, which is returned by Build.
+//
+//	file, err := NewFileBuilder(fh).
+//		AddCashLetter(clh).
+//		AddCheckItem(CheckItemSpec{PayorBankRoutingNumber: "031300012", OnUs: "5558881", ItemAmount: 100000}).
+//		Build()
+type FileBuilder struct {
+	file *File
+	// cashLetter is the CashLetter items are added to, which is added to file by the next AddCashLetter or Build
+	cashLetter *CashLetter
+	// bundle is the Bundle of cashLetter items are added to
+	bundle *Bundle
+	// bundleSequence is the BundleSequenceNumber of bundle
+	bundleSequence int
+	err            error
+}
+
+// NewFileBuilder returns a new FileBuilder for a File with the FileHeader fh
+func NewFileBuilder(fh FileHeader) *FileBuilder {
+	fb := &FileBuilder{file: NewFile()}
+	fb.file.SetHeader(fh)
+	fb.err = fh.Validate()
+	return fb
+}
+
+// AddCashLetter starts a new CashLetter with the CashLetterHeader clh. Items added following
+// AddCashLetter are placed in the new CashLetter. The CashLetter is given a copy of clh, which isn't changed.
+func (fb *FileBuilder) AddCashLetter(clh *CashLetterHeader) *FileBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	if clh == nil {
+		fb.err = &FileError{FieldName: "CashLetterHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+		return fb
+	}
+	if fb.err = clh.Validate(); fb.err != nil {
+		return fb
+	}
+	fb.finishCashLetter()
+	header := *clh
+	cl := NewCashLetter(&header)
+	fb.cashLetter = &cl
+	fb.bundle = nil
+	fb.bundleSequence = 0
+	return fb
+}
+
+// AddBundle starts a new Bundle in the current CashLetter with the BundleHeader bh. Bundles are created
+// from the CashLetterHeader when items are added without one, so AddBundle is only needed to split
+// items across Bundles or to set BundleHeader values which differ from the CashLetterHeader. The Bundle is
+// given a copy of bh, which isn't changed.
+func (fb *FileBuilder) AddBundle(bh *BundleHeader) *FileBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	if fb.cashLetter == nil {
+		fb.err = &FileError{FieldName: "BundleHeader", Msg: msgBuilderCashLetter}
+		return fb
+	}
+	if bh == nil {
+		fb.err = &FileError{FieldName: "BundleHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+		return fb
+	}
+	// the Bundle is given a copy, so numbering it and building the File leave the caller's BundleHeader unchanged
+	header := *bh
+	fb.bundleSequence++
+	if header.BundleSequenceNumber == "" {
+		header.BundleSequenceNumber = fmt.Sprint(fb.bundleSequence)
+	}
+	if fb.err = header.Validate(); fb.err != nil {
+		return fb
+	}
+	fb.bundle = NewBundle(&header)
+	fb.cashLetter.AddBundle(fb.bundle)
+	return fb
+}
+
+// AddCheckItem adds a CheckDetail described by spec to the current Bundle along with a CheckDetailAddendumA
+// for the BOFD endorsement and an ImageViewDetail and ImageViewData for each image. The item is given the
+// next EceInstitutionItemSequenceNumber of the Bundle, a MICRValidIndicator of 1 (good read) and a BOFDIndicator
+// of Y (the ECE institution is the BOFD).
+func (fb *FileBuilder) AddCheckItem(spec CheckItemSpec) *FileBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	if fb.cashLetter == nil {
+		fb.err = &FileError{FieldName: "CheckDetail", Msg: msgBuilderCashLetter}
+		return fb
+	}
+	if len(spec.PayorBankRoutingNumber) != 9 {
+		fb.err = &FieldError{FieldName: "PayorBankRoutingNumber", Value: spec.PayorBankRoutingNumber, Msg: msgBuilderRoutingNumber}
+		return fb
+	}
+	if fb.bundle == nil {
+		if fb.AddBundle(fb.bundleHeader()); fb.err != nil {
+			return fb
+		}
+	}
+	clh := fb.cashLetter.CashLetterHeader
+	bh := fb.bundle.BundleHeader
+
+	cd := NewCheckDetail()
+	cd.AuxiliaryOnUs = spec.AuxiliaryOnUs
+	cd.PayorBankRoutingNumber = spec.PayorBankRoutingNumber[:8]
+	cd.PayorBankCheckDigit = spec.PayorBankRoutingNumber[8:]
+	cd.OnUs = spec.OnUs
+	if fb.err = cd.SetItemAmount(spec.ItemAmount); fb.err != nil {
+		return fb
+	}
+	cd.SetEceInstitutionItemSequenceNumber(len(fb.bundle.Checks) + 1)
+	cd.MICRValidIndicator = 1
+	cd.BOFDIndicator = "Y"
+
+	images := [][]byte{spec.FrontImage, spec.BackImage}
+	truncated := len(spec.FrontImage) > 0 || len(spec.BackImage) > 0
+
+	cdAddendumA := NewCheckDetailAddendumA()
+	cdAddendumA.RecordNumber = 1
+	cdAddendumA.ReturnLocationRoutingNumber = clh.ECEInstitutionRoutingNumber
+	cdAddendumA.BOFDEndorsementDate = bh.BundleBusinessDate
+	cdAddendumA.BOFDItemSequenceNumber = cd.EceInstitutionItemSequenceNumber
+	cdAddendumA.BOFDAccountNumber = spec.BOFDAccountNumber
+	cdAddendumA.PayeeName = spec.PayeeName
+	cdAddendumA.TruncationIndicator = "N"
+	if truncated {
+		cdAddendumA.TruncationIndicator = "Y"
+	}
+	if fb.err = cd.AddCheckDetailAddendumA(cdAddendumA); fb.err != nil {
+		return fb
+	}
+	cd.AddendumCount = len(cd.CheckDetailAddendumA)
+
+	for side, image := range images {
+		if len(image) == 0 {
+			continue
+		}
+		cd.AddImageViewDetail(fb.imageViewDetail(side, bh.BundleBusinessDate))
+		cd.AddImageViewData(fb.imageViewData(cd, image))
+	}
+
+	if fb.err = cd.Validate(); fb.err != nil {
+		return fb
+	}
+	if fb.err = fb.bundle.ValidateForwardItems(cd); fb.err != nil {
+		return fb
+	}
+	fb.bundle.AddCheckDetail(cd)
+	return fb
+}
+
+// bundleHeader returns a BundleHeader for the current CashLetter from its CashLetterHeader
+func (fb *FileBuilder) bundleHeader() *BundleHeader {
+	return newBundleHeader(fb.cashLetter.CashLetterHeader)
+}
+
+// imageViewDetail returns an ImageViewDetail for a TIFF image with Group 4 compression of the front
+// (side 0) or back (side 1) of a check
+func (fb *FileBuilder) imageViewDetail(side int, imageCreatorDate time.Time) ImageViewDetail {
+	ivDetail := NewImageViewDetail()
+	ivDetail.ImageIndicator = 1
+	ivDetail.ImageCreatorRoutingNumber = fb.cashLetter.CashLetterHeader.ECEInstitutionRoutingNumber
+	ivDetail.ImageCreatorDate = imageCreatorDate
+	ivDetail.ImageViewFormatIndicator = "00"
+	ivDetail.ImageViewCompressionAlgorithm = "00"
+	ivDetail.ViewSideIndicator = side
+	ivDetail.ViewDescriptor = "00"
+	ivDetail.DigitalSignatureIndicator = 0
+	ivDetail.OverrideIndicator = "0"
+	return ivDetail
+}
+
+// imageViewData returns an ImageViewData holding image for the CheckDetail cd
+func (fb *FileBuilder) imageViewData(cd *CheckDetail, image []byte) ImageViewData {
+	bh := fb.bundle.BundleHeader
+	ivData := NewImageViewData()
+	ivData.EceInstitutionRoutingNumber = bh.ECEInstitutionRoutingNumber
+	ivData.BundleBusinessDate = bh.BundleBusinessDate
+	ivData.CycleNumber = bh.CycleNumber
+	ivData.EceInstitutionItemSequenceNumber = cd.EceInstitutionItemSequenceNumber
+	ivData.LengthImageReferenceKey = "0000"
+	ivData.LengthDigitalSignature = "0"
+	ivData.LengthImageData = fmt.Sprintf("%07d", len(image))
+	ivData.ImageData = image
+	return ivData
+}
+
+// finishCashLetter adds the current CashLetter to the File
+func (fb *FileBuilder) finishCashLetter() {
+	if fb.cashLetter == nil {
+		return
+	}
+	fb.file.AddCashLetter(*fb.cashLetter)
+	fb.cashLetter = nil
+	fb.bundle = nil
+}
+
+// Build creates the BundleControl, CashLetterControl and FileControl records and returns the File once
+// every record has been validated. The first error from building or validating the File is returned.
+func (fb *FileBuilder) Build() (*File, error) {
+	if fb.err != nil {
+		return nil, fb.err
+	}
+	fb.finishCashLetter()
+	if err := fb.file.Recalculate(); err != nil {
+		return nil, err
+	}
+	if err := fb.file.Validate(); err != nil {
+		return nil, err
+	}
+	for i := range fb.file.CashLetters {
+		if err := fb.file.CashLetters[i].validateRecords(); err != nil {
+			return nil, err
+		}
+	}
+	return fb.file, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestFileBuilder validates a File with one check is built, validates and can be written and read back
+func TestFileBuilder(t *testing.T) {
+	file, err := NewFileBuilder(mockFileHeader()).
+		AddCashLetter(mockCashLetterHeader()).
+		AddCheckItem(CheckItemSpec{
+			PayorBankRoutingNumber: "031300012",
+			OnUs:                   "5558881",
+			ItemAmount:             100000,
+			PayeeName:              "Test Payee",
+			FrontImage:             []byte("front image"),
+			BackImage:              []byte("back image"),
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0), WithStrict()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if file.Control.FileTotalAmount != 100000 || file.Control.TotalItemCount != 6 {
+		t.Errorf("unexpected FileControl: %s", file.Control.String())
+	}
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	if cd.PayorBankRoutingNumber != "03130001" || cd.PayorBankCheckDigit != "2" || cd.AddendumCount != 1 {
+		t.Errorf("unexpected CheckDetail: %s", cd.String())
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	written := buf.String()
+	read, err := NewReader(&buf).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	buf.Reset()
+	if err := NewWriter(&buf).Write(&read); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if buf.String() != written {
+		t.Error("File read back does not match the File written")
+	}
+	ivData := read.CashLetters[0].Bundles[0].Checks[0].ImageViewData
+	if len(ivData) != 2 || string(ivData[1].ImageData) != "back image" {
+		t.Errorf("unexpected ImageViewData: %v", ivData)
+	}
+}
+
+// TestFileBuilder__Bundles validates items are numbered within each Bundle
+func TestFileBuilder__Bundles(t *testing.T) {
+	spec := CheckItemSpec{PayorBankRoutingNumber: "031300012", OnUs: "5558881", ItemAmount: 2500}
+	clh := mockCashLetterHeader()
+	clh.DocumentationTypeIndicator = "K"
+	file, err := NewFileBuilder(mockFileHeader()).
+		AddCashLetter(clh).
+		AddCheckItem(spec).
+		AddBundle(mockBundleHeader()).
+		AddCheckItem(spec).
+		AddCheckItem(spec).
+		Build()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	bundles := file.CashLetters[0].Bundles
+	if len(bundles) != 2 || len(bundles[0].Checks) != 1 || len(bundles[1].Checks) != 2 {
+		t.Fatalf("unexpected bundles: %d", len(bundles))
+	}
+	if seq := bundles[1].Checks[1].EceInstitutionItemSequenceNumber; seq != "2" {
+		t.Errorf("unexpected EceInstitutionItemSequenceNumber %q", seq)
+	}
+	if total := file.CashLetters[0].CashLetterControl.CashLetterTotalAmount; total != 7500 {
+		t.Errorf("unexpected CashLetterTotalAmount %d", total)
+	}
+}
+
+// TestFileBuilder__BundleHeaderCopied validates the BundleHeader passed to AddBundle isn't numbered or shared,
+// so it can be reused for several Bundles
+func TestFileBuilder__BundleHeaderCopied(t *testing.T) {
+	spec := CheckItemSpec{PayorBankRoutingNumber: "031300012", OnUs: "5558881", ItemAmount: 2500}
+	clh := mockCashLetterHeader()
+	clh.DocumentationTypeIndicator = "K"
+	bh := mockBundleHeader()
+	bh.BundleSequenceNumber = ""
+	file, err := NewFileBuilder(mockFileHeader()).
+		AddCashLetter(clh).
+		AddBundle(bh).
+		AddCheckItem(spec).
+		AddBundle(bh).
+		AddCheckItem(spec).
+		Build()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if bh.BundleSequenceNumber != "" {
+		t.Errorf("BundleSequenceNumber of the caller's BundleHeader set to %q", bh.BundleSequenceNumber)
+	}
+	bundles := file.CashLetters[0].Bundles
+	if len(bundles) != 2 || bundles[0].BundleHeader == bh || bundles[1].BundleHeader == bh ||
+		bundles[0].BundleHeader == bundles[1].BundleHeader {
+		t.Fatal("expected each Bundle to have its own BundleHeader")
+	}
+	for i, b := range bundles {
+		if want := fmt.Sprint(i + 1); b.BundleHeader.BundleSequenceNumber != want {
+			t.Errorf("BundleSequenceNumber %q, expected %q", b.BundleHeader.BundleSequenceNumber, want)
+		}
+	}
+}
+
+// TestFileBuilder__CashLetterHeaderCopied validates the CashLetterHeader passed to AddCashLetter isn't shared,
+// so it can be reused for several CashLetters
+func TestFileBuilder__CashLetterHeaderCopied(t *testing.T) {
+	spec := CheckItemSpec{PayorBankRoutingNumber: "031300012", OnUs: "5558881", ItemAmount: 2500}
+	clh := mockCashLetterHeader()
+	fb := NewFileBuilder(mockFileHeader()).AddCashLetter(clh).AddCheckItem(spec)
+	clh.CashLetterID = "A2"
+	file, err := fb.AddCashLetter(clh).AddCheckItem(spec).Build()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	cashLetters := file.CashLetters
+	if len(cashLetters) != 2 || cashLetters[0].CashLetterHeader == clh || cashLetters[1].CashLetterHeader == clh {
+		t.Fatal("expected each CashLetter to have its own CashLetterHeader")
+	}
+	if id := cashLetters[0].CashLetterHeader.CashLetterID; id == "A2" {
+		t.Errorf("CashLetterID of the first CashLetter changed to %q", id)
+	}
+}
+
+// TestFileBuilder__Errors validates the first error adding a record is returned by Build
+func TestFileBuilder__Errors(t *testing.T) {
+	spec := CheckItemSpec{PayorBankRoutingNumber: "031300012", OnUs: "5558881", ItemAmount: 2500}
+	_, err := NewFileBuilder(mockFileHeader()).AddCheckItem(spec).AddCashLetter(mockCashLetterHeader()).Build()
+	if e, ok := err.(*FileError); !ok || e.Msg != msgBuilderCashLetter {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	spec.PayorBankRoutingNumber = "03130001"
+	_, err = NewFileBuilder(mockFileHeader()).AddCashLetter(mockCashLetterHeader()).AddCheckItem(spec).Build()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "PayorBankRoutingNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	_, err = NewFileBuilder(mockFileHeader()).AddCashLetter(mockCashLetterHeader()).Build()
+	if err == nil {
+		t.Error("expected an error for a CashLetter without items")
+	}
+}
@@ -13,12 +13,19 @@ type BundleError struct {
 	BundleSequenceNumber string
 	FieldName            string
 	Msg                  string
+	// Err is the sentinel error, such as ErrFieldInclusion, the error was built from
+	Err error
 }
 
 func (e *BundleError) Error() string {
 	return fmt.Sprintf("BundleNumber %s %s %s", e.BundleSequenceNumber, e.FieldName, e.Msg)
 }
 
+// Unwrap returns the sentinel error the error was built from, if any, for errors.Is
+func (e *BundleError) Unwrap() error {
+	return e.Err
+}
+
 // Addendum Counts
 const (
 	CheckDetailAddendumACount  = 9
@@ -35,6 +42,8 @@ var (
 	msgBundleEntries       = "must have Check Detail or Return Detail to be built"
 	msgBundleAddendum      = "%v found is greater than maximum of %v"
 	msgBundleAddendumCount = "%v does not match Addenda Records"
+	msgBundleMixedItems    = "must not have both Check Detail and Return Detail"
+	msgBundleAddendumOrder = "RecordNumber %d does not follow the preceding %d"
 )
 
 // Bundle contains forward items (checks)
@@ -47,6 +56,11 @@ type Bundle struct {
 	Checks []*CheckDetail `json:"checks,omitempty"`
 	// Returns are Return Items: Return Detail Records, Return Detail Addendum Records, and Image Views
 	Returns []*ReturnDetail `json:"returns,omitempty"`
+	// CreditItems and Credits are the credit records following the Bundle Header Record. They are
+	// counted in the CashLetterControl with the CashLetter's CreditItems and Credits, but not in the
+	// BundleControl.
+	CreditItems []*CreditItem `json:"creditItems,omitempty"`
+	Credits     []*Credit     `json:"credits,omitempty"`
 	// BundleControl is a Bundle Control Record
 	BundleControl *BundleControl `json:"bundleControl,omitempty"`
 }
@@ -70,6 +84,12 @@ func (b *Bundle) setRecordType() {
 	for i := range b.Returns {
 		b.Returns[i].setRecordType()
 	}
+	for i := range b.CreditItems {
+		b.CreditItems[i].setRecordType()
+	}
+	for i := range b.Credits {
+		b.Credits[i].setRecordType()
+	}
 	b.BundleControl.setRecordType()
 }
 
@@ -78,6 +98,9 @@ func (b *Bundle) Validate() error {
 	if (len(b.Checks) <= 0) && (len(b.Returns) <= 0) {
 		return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "entries", Msg: msgBundleEntries}
 	}
+	if (len(b.Checks) > 0) && (len(b.Returns) > 0) {
+		return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "entries", Msg: msgBundleMixedItems}
+	}
 
 	if len(b.Checks) > 0 {
 		if err := b.checkDetailAddendumCount(); err != nil {
@@ -88,6 +111,59 @@ func (b *Bundle) Validate() error {
 			return err
 		}
 	}
+	for _, cd := range b.Checks {
+		if err := cd.validateImageViewCounts(); err != nil {
+			return err
+		}
+	}
+	for _, rd := range b.Returns {
+		if err := rd.validateImageViewCounts(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRecords validates the Bundle and every record it contains, returning the first error found.
+func (b *Bundle) validateRecords() error {
+	if b.BundleHeader == nil {
+		return &BundleError{FieldName: "BundleHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+	}
+	if err := b.BundleHeader.Validate(); err != nil {
+		return err
+	}
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	for _, ci := range b.CreditItems {
+		if err := ci.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, cr := range b.Credits {
+		if err := cr.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, cd := range b.Checks {
+		if err := cd.Validate(); err != nil {
+			return err
+		}
+		if err := b.ValidateForwardItems(cd); err != nil {
+			return err
+		}
+	}
+	for _, rd := range b.Returns {
+		if err := rd.Validate(); err != nil {
+			return err
+		}
+		if err := b.ValidateReturnItems(rd); err != nil {
+			return err
+		}
+	}
+	if b.BundleControl != nil {
+		return b.BundleControl.Validate()
+	}
 	return nil
 }
 
@@ -153,6 +229,55 @@ func (b *Bundle) build() error {
 	return nil
 }
 
+// BuildControl recalculates the counts and amounts of the BundleControl from the Bundle's items, creating
+// the BundleControl if there is none. Unlike CashLetter.Create the items are not validated or renumbered
+// and the BundleControl UserField is kept, so BuildControl can follow an edit of a single Bundle. The
+// CashLetterControl and FileControl are not changed; call CashLetter.BuildControl and File.Create to
+// recalculate them.
+func (b *Bundle) BuildControl() error {
+	if b.BundleHeader == nil {
+		return &BundleError{FieldName: "BundleHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+	}
+	if (len(b.Checks) <= 0) && (len(b.Returns) <= 0) {
+		return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "entries", Msg: msgBundleEntries}
+	}
+	bc := NewBundleControl()
+	if b.BundleControl != nil {
+		*bc = *b.BundleControl
+	}
+	micrValidTotalAmount := 0
+	for _, cd := range b.Checks {
+		if cd.MICRValidIndicator == 1 {
+			micrValidTotalAmount = micrValidTotalAmount + cd.ItemAmount
+		}
+	}
+	bc.BundleItemsCount = b.itemsCount()
+	bc.BundleTotalAmount = int(b.TotalAmount())
+	bc.MICRValidTotalAmount = micrValidTotalAmount
+	bc.BundleImagesCount = b.imagesCount()
+	if err := bc.Validate(); err != nil {
+		return err
+	}
+	b.BundleControl = bc
+	return nil
+}
+
+// TotalAmount returns the sum in cents of the ItemAmount of every CheckDetail and ReturnDetail in the
+// Bundle, which is the BundleTotalAmount calculated by build.
+func (b *Bundle) TotalAmount() int64 {
+	if b == nil {
+		return 0
+	}
+	var total int64
+	for _, cd := range b.Checks {
+		total = total + int64(cd.ItemAmount)
+	}
+	for _, rd := range b.Returns {
+		total = total + int64(rd.ItemAmount)
+	}
+	return total
+}
+
 // SetHeader appends an BundleHeader to the Bundle
 func (b *Bundle) SetHeader(bundleHeader *BundleHeader) {
 	b.BundleHeader = bundleHeader
@@ -173,6 +298,16 @@ func (b *Bundle) GetControl() *BundleControl {
 	return b.BundleControl
 }
 
+// AddCreditItem appends a CreditItem to the Bundle
+func (b *Bundle) AddCreditItem(ci *CreditItem) {
+	b.CreditItems = append(b.CreditItems, ci)
+}
+
+// AddCredit appends a Credit to the Bundle
+func (b *Bundle) AddCredit(cr *Credit) {
+	b.Credits = append(b.Credits, cr)
+}
+
 // AddCheckDetail appends a CheckDetail to the Bundle
 func (b *Bundle) AddCheckDetail(cd *CheckDetail) {
 	b.Checks = append(b.Checks, cd)
@@ -296,7 +431,19 @@ func (b *Bundle) checkDetailAddendumCount() error {
 			msg := fmt.Sprintf(msgBundleAddendum, len(cd.CheckDetailAddendumC), CheckDetailAddendumCCount)
 			return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "CheckDetailAddendumC", Msg: msg}
 		}
-
+		// Addendum A and C records are in the order of their RecordNumbers
+		for i := 1; i < len(cd.CheckDetailAddendumA); i++ {
+			if n, prev := cd.CheckDetailAddendumA[i].RecordNumber, cd.CheckDetailAddendumA[i-1].RecordNumber; n <= prev {
+				msg := fmt.Sprintf(msgBundleAddendumOrder, n, prev)
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "CheckDetailAddendumA", Msg: msg}
+			}
+		}
+		for i := 1; i < len(cd.CheckDetailAddendumC); i++ {
+			if n, prev := cd.CheckDetailAddendumC[i].RecordNumber, cd.CheckDetailAddendumC[i-1].RecordNumber; n <= prev {
+				msg := fmt.Sprintf(msgBundleAddendumOrder, n, prev)
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "CheckDetailAddendumC", Msg: msg}
+			}
+		}
 	}
 	return nil
 }
@@ -324,6 +471,73 @@ func (b *Bundle) returnDetailAddendumCount() error {
 			msg := fmt.Sprintf(msgBundleAddendum, len(rd.ReturnDetailAddendumD), ReturnDetailAddendumDCount)
 			return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "ReturnDetailAddendumD", Msg: msg}
 		}
+		// Addendum A and D records are in the order of their RecordNumbers
+		for i := 1; i < len(rd.ReturnDetailAddendumA); i++ {
+			if n, prev := rd.ReturnDetailAddendumA[i].RecordNumber, rd.ReturnDetailAddendumA[i-1].RecordNumber; n <= prev {
+				msg := fmt.Sprintf(msgBundleAddendumOrder, n, prev)
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "ReturnDetailAddendumA", Msg: msg}
+			}
+		}
+		for i := 1; i < len(rd.ReturnDetailAddendumD); i++ {
+			if n, prev := rd.ReturnDetailAddendumD[i].RecordNumber, rd.ReturnDetailAddendumD[i-1].RecordNumber; n <= prev {
+				msg := fmt.Sprintf(msgBundleAddendumOrder, n, prev)
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "ReturnDetailAddendumD", Msg: msg}
+			}
+		}
 	}
 	return nil
 }
+
+// copy returns a copy of the Bundle which shares no records with it
+func (b *Bundle) copy() *Bundle {
+	c := &Bundle{ID: b.ID}
+	if b.BundleHeader != nil {
+		bh := *b.BundleHeader
+		c.BundleHeader = &bh
+	}
+	if b.BundleControl != nil {
+		bc := *b.BundleControl
+		c.BundleControl = &bc
+	}
+	for _, cd := range b.Checks {
+		if cd != nil {
+			cd = cd.copy()
+		}
+		c.Checks = append(c.Checks, cd)
+	}
+	for _, rd := range b.Returns {
+		if rd != nil {
+			rd = rd.copy()
+		}
+		c.Returns = append(c.Returns, rd)
+	}
+	c.CreditItems = copyCreditItems(b.CreditItems)
+	c.Credits = copyCredits(b.Credits)
+	return c
+}
+
+// copyCreditItems returns a copy of items which shares no records with it
+func copyCreditItems(items []*CreditItem) []*CreditItem {
+	var c []*CreditItem
+	for _, ci := range items {
+		if ci != nil {
+			item := *ci
+			ci = &item
+		}
+		c = append(c, ci)
+	}
+	return c
+}
+
+// copyCredits returns a copy of credits which shares no records with it
+func copyCredits(credits []*Credit) []*Credit {
+	var c []*Credit
+	for _, cr := range credits {
+		if cr != nil {
+			credit := *cr
+			cr = &credit
+		}
+		c = append(c, cr)
+	}
+	return c
+}
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -38,6 +37,8 @@ type BundleControl struct {
 	CreditTotalIndicator int `json:"creditTotalIndicator"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -55,10 +56,15 @@ func (bc *BundleControl) setRecordType() {
 	if bc == nil {
 		return
 	}
-	bc.recordType = "70"
+	bc.recordType = RecordTypeBundleControl
 	bc.reserved = "                        "
 }
 
+// RecordType returns the record type of the BundleControl, which is RecordTypeBundleControl once set by NewBundleControl or Parse
+func (bc *BundleControl) RecordType() string {
+	return bc.recordType
+}
+
 // Parse takes the input record string and parses the BundleControl values
 func (bc *BundleControl) Parse(record string) {
 	if utf8.RuneCountInString(record) < 56 {
@@ -70,9 +76,11 @@ func (bc *BundleControl) Parse(record string) {
 	// 03-06
 	bc.BundleItemsCount = bc.parseNumField(record[2:6])
 	// 07-18
-	bc.BundleTotalAmount = bc.parseNumField(record[6:18])
+	bundleTotalAmount, _ := bc.parseAmount(record[6:18])
+	bc.BundleTotalAmount = int(bundleTotalAmount)
 	// 19-30
-	bc.MICRValidTotalAmount = bc.parseNumField(record[18:30])
+	mICRValidTotalAmount, _ := bc.parseAmount(record[18:30])
+	bc.MICRValidTotalAmount = int(mICRValidTotalAmount)
 	// 31-35
 	bc.BundleImagesCount = bc.parseNumField(record[30:35])
 	// 36-55
@@ -80,23 +88,26 @@ func (bc *BundleControl) Parse(record string) {
 	// 56-56
 	bc.CreditTotalIndicator = bc.parseNumField(record[55:56])
 	// 57-80
-	bc.reserved = "                        "
+	bc.reserved = bc.parseReservedField(record, 56, 80)
 
 }
 
 // String writes the BundleControl struct to a string.
 func (bc *BundleControl) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(bc.recordType)
-	buf.WriteString(bc.BundleItemsCountField())
-	buf.WriteString(bc.BundleTotalAmountField())
-	buf.WriteString(bc.MICRValidTotalAmountField())
-	buf.WriteString(bc.BundleImagesCountField())
-	buf.WriteString(bc.UserFieldField())
-	buf.WriteString(bc.CreditTotalIndicatorField())
-	buf.WriteString(bc.reservedField())
-	return buf.String()
+	return string(bc.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the BundleControl record, as String returns it, to b and returns the extended buffer.
+func (bc *BundleControl) AppendTo(b []byte) []byte {
+	b = append(b, bc.recordType...)
+	b = bc.appendNumericField(b, bc.BundleItemsCount, 4)
+	b = bc.appendAmount(b, int64(bc.BundleTotalAmount), 12)
+	b = bc.appendAmount(b, int64(bc.MICRValidTotalAmount), 12)
+	b = bc.appendNumericField(b, bc.BundleImagesCount, 5)
+	b = bc.appendAlphaField(b, bc.UserField, 20)
+	b = bc.appendNumericField(b, bc.CreditTotalIndicator, 1)
+	b = bc.appendAlphaField(b, bc.reserved, 24)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -105,9 +116,9 @@ func (bc *BundleControl) Validate() error {
 	if err := bc.fieldInclusion(); err != nil {
 		return err
 	}
-	if bc.recordType != "70" {
+	if bc.recordType != RecordTypeBundleControl {
 		msg := fmt.Sprintf(msgRecordType, 70)
-		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := bc.isAlphanumericSpecial(bc.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: bc.UserField, Msg: err.Error()}
@@ -126,17 +137,17 @@ func (bc *BundleControl) fieldInclusion() error {
 	if bc.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: bc.recordType,
-			Msg:   msgFieldInclusion + ", did you use BundleControl()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleControl()?", Err: ErrFieldInclusion}
 	}
 	if bc.BundleItemsCount == 0 {
 		return &FieldError{FieldName: "BundleItemsCount",
 			Value: bc.BundleItemsCountField(),
-			Msg:   msgFieldInclusion + ", did you use BundleControl()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleControl()?", Err: ErrFieldInclusion}
 	}
 	if bc.BundleTotalAmount == 0 {
 		return &FieldError{FieldName: "BundleTotalAmount",
 			Value: bc.BundleTotalAmountField(),
-			Msg:   msgFieldInclusion + ", did you use BundleControl()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleControl()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -148,12 +159,44 @@ func (bc *BundleControl) BundleItemsCountField() string {
 
 // BundleTotalAmountField gets a string of the BundleTotalAmount zero padded
 func (bc *BundleControl) BundleTotalAmountField() string {
-	return bc.numericField(bc.BundleTotalAmount, 12)
+	return bc.formatAmount(int64(bc.BundleTotalAmount), 12)
+}
+
+// SetBundleTotalAmount sets BundleTotalAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 12 digit field.
+func (bc *BundleControl) SetBundleTotalAmount(cents int64) error {
+	amount, err := bc.amountFromCents("BundleTotalAmount", cents, 12)
+	if err != nil {
+		return err
+	}
+	bc.BundleTotalAmount = amount
+	return nil
+}
+
+// BundleTotalAmountCents returns BundleTotalAmount in cents
+func (bc *BundleControl) BundleTotalAmountCents() int64 {
+	return int64(bc.BundleTotalAmount)
 }
 
 // MICRValidTotalAmountField gets a string of the MICRValidTotalAmount zero padded
 func (bc *BundleControl) MICRValidTotalAmountField() string {
-	return bc.numericField(bc.MICRValidTotalAmount, 12)
+	return bc.formatAmount(int64(bc.MICRValidTotalAmount), 12)
+}
+
+// SetMICRValidTotalAmount sets MICRValidTotalAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 12 digit field.
+func (bc *BundleControl) SetMICRValidTotalAmount(cents int64) error {
+	amount, err := bc.amountFromCents("MICRValidTotalAmount", cents, 12)
+	if err != nil {
+		return err
+	}
+	bc.MICRValidTotalAmount = amount
+	return nil
+}
+
+// MICRValidTotalAmountCents returns MICRValidTotalAmount in cents
+func (bc *BundleControl) MICRValidTotalAmountCents() int64 {
+	return int64(bc.MICRValidTotalAmount)
 }
 
 // BundleImagesCountField gets a string of the BundleImagesCount zero padded
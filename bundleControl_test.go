@@ -240,3 +240,24 @@ func TestBundleControlRuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString")
 	}
 }
+
+// TestBundleControlSetAmounts validates setting BundleTotalAmount and MICRValidTotalAmount from cents
+func TestBundleControlSetAmounts(t *testing.T) {
+	bc := mockBundleControl()
+	if err := bc.SetBundleTotalAmount(12345); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := bc.SetMICRValidTotalAmount(12345); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if v := bc.BundleTotalAmountField(); v != "000000012345" {
+		t.Errorf("BundleTotalAmount Expected '000000012345' got: %v", v)
+	}
+	if v := bc.MICRValidTotalAmountCents(); v != 12345 {
+		t.Errorf("MICRValidTotalAmountCents Expected 12345 got: %v", v)
+	}
+	err := bc.SetBundleTotalAmount(1000000000000)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "BundleTotalAmount" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
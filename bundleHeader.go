@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -85,6 +84,8 @@ type BundleHeader struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -102,7 +103,12 @@ func (bh *BundleHeader) setRecordType() {
 	if bh == nil {
 		return
 	}
-	bh.recordType = "20"
+	bh.recordType = RecordTypeBundleHeader
+}
+
+// RecordType returns the record type of the BundleHeader, which is RecordTypeBundleHeader once set by NewBundleHeader or Parse
+func (bh *BundleHeader) RecordType() string {
+	return bh.recordType
 }
 
 // Parse takes the input record string and parses the BundleHeader values
@@ -134,26 +140,29 @@ func (bh *BundleHeader) Parse(record string) {
 	// 64-68
 	bh.UserField = bh.parseStringField(record[63:68])
 	// 69-80
-	bh.reserved = "            "
+	bh.reserved = bh.parseReservedField(record, 68, 80)
 }
 
 // String writes the BundleHeader struct to a string.
 func (bh *BundleHeader) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(bh.recordType)
-	buf.WriteString(bh.CollectionTypeIndicatorField())
-	buf.WriteString(bh.DestinationRoutingNumberField())
-	buf.WriteString(bh.ECEInstitutionRoutingNumberField())
-	buf.WriteString(bh.BundleBusinessDateField())
-	buf.WriteString(bh.BundleCreationDateField())
-	buf.WriteString(bh.BundleIDField())
-	buf.WriteString(bh.BundleSequenceNumberField())
-	buf.WriteString(bh.CycleNumberField())
-	buf.WriteString(bh.ReturnLocationRoutingNumberField())
-	buf.WriteString(bh.UserFieldField())
-	buf.WriteString(bh.reservedField())
-	return buf.String()
+	return string(bh.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the BundleHeader record, as String returns it, to b and returns the extended buffer.
+func (bh *BundleHeader) AppendTo(b []byte) []byte {
+	b = append(b, bh.recordType...)
+	b = bh.appendAlphaField(b, bh.CollectionTypeIndicator, 2)
+	b = bh.appendStringField(b, bh.DestinationRoutingNumber, 9)
+	b = bh.appendStringField(b, bh.ECEInstitutionRoutingNumber, 9)
+	b = bh.appendYYYYMMDDDate(b, bh.BundleBusinessDate)
+	b = bh.appendYYYYMMDDDate(b, bh.BundleCreationDate)
+	b = bh.appendAlphaField(b, bh.BundleID, 10)
+	b = bh.appendAlphaField(b, bh.BundleSequenceNumber, 4)
+	b = bh.appendAlphaField(b, bh.CycleNumber, 2)
+	b = bh.appendAlphaField(b, bh.ReturnLocationRoutingNumber, 9)
+	b = bh.appendAlphaField(b, bh.UserField, 5)
+	b = bh.appendAlphaField(b, bh.reserved, 12)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -162,9 +171,9 @@ func (bh *BundleHeader) Validate() error {
 	if err := bh.fieldInclusion(); err != nil {
 		return err
 	}
-	if bh.recordType != "20" {
+	if bh.recordType != RecordTypeBundleHeader {
 		msg := fmt.Sprintf(msgRecordType, 20)
-		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	// Mandatory
 	if err := bh.isCollectionTypeIndicator(bh.CollectionTypeIndicator); err != nil {
@@ -180,6 +189,16 @@ func (bh *BundleHeader) Validate() error {
 	if err := bh.isAlphanumericSpecial(bh.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: bh.UserField, Msg: err.Error()}
 	}
+	if bh.isZeroRoutingNumber(bh.ReturnLocationRoutingNumber) {
+		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
+			Value: bh.ReturnLocationRoutingNumber, Msg: msgRoutingNumberZero}
+	}
+	if err := bh.isDateInRange(bh.BundleBusinessDate); err != nil {
+		return &FieldError{FieldName: "BundleBusinessDate", Value: bh.BundleBusinessDateField(), Msg: err.Error()}
+	}
+	if err := bh.isDateInRange(bh.BundleCreationDate); err != nil {
+		return &FieldError{FieldName: "BundleCreationDate", Value: bh.BundleCreationDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -189,46 +208,46 @@ func (bh *BundleHeader) fieldInclusion() error {
 	if bh.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: bh.recordType,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	if bh.CollectionTypeIndicator == "" {
 		return &FieldError{FieldName: "CollectionTypeIndicator",
 			Value: bh.CollectionTypeIndicator,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	if bh.DestinationRoutingNumber == "" {
 		return &FieldError{FieldName: "DestinationRoutingNumber",
 			Value: bh.DestinationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
-	if bh.DestinationRoutingNumberField() == "000000000" {
+	if bh.isZeroRoutingNumber(bh.DestinationRoutingNumber) {
 		return &FieldError{FieldName: "DestinationRoutingNumber",
-			Value: bh.DestinationRoutingNumber, Msg: msgFieldInclusion}
+			Value: bh.DestinationRoutingNumber, Msg: msgFieldInclusion, Err: ErrFieldInclusion}
 	}
 	if bh.ECEInstitutionRoutingNumber == "" {
 		return &FieldError{FieldName: "ECEInstitutionRoutingNumber",
 			Value: bh.ECEInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
-	if bh.ECEInstitutionRoutingNumberField() == "000000000" {
+	if bh.isZeroRoutingNumber(bh.ECEInstitutionRoutingNumber) {
 		return &FieldError{FieldName: "ECEInstitutionRoutingNumber",
 			Value: bh.ECEInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	if bh.BundleBusinessDate.IsZero() {
 		return &FieldError{FieldName: "BundleBusinessDate",
 			Value: bh.BundleBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	if bh.BundleCreationDate.IsZero() {
 		return &FieldError{FieldName: "BundleCreationDate",
 			Value: bh.BundleCreationDate.String(),
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	if bh.BundleSequenceNumberField() == "    " {
 		return &FieldError{FieldName: "BundleSequenceNumber",
 			Value: bh.BundleSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use BundleHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use BundleHeader()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
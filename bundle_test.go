@@ -4,7 +4,10 @@
 
 package imagecashletter
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 // mockBundleChecks
 func mockBundleChecks() *Bundle {
@@ -102,7 +105,9 @@ func TestCheckDetailAddendumACount(t *testing.T) {
 	cd := mockCheckDetail()
 	cd.AddendumCount = 12
 	for i := 0; i < 10; i++ {
-		cd.AddCheckDetailAddendumA(mockCheckDetailAddendumA())
+		addendumA := mockCheckDetailAddendumA()
+		addendumA.RecordNumber = i + 1
+		cd.AddCheckDetailAddendumA(addendumA)
 	}
 	cd.AddCheckDetailAddendumB(mockCheckDetailAddendumB())
 	cd.AddCheckDetailAddendumC(mockCheckDetailAddendumC())
@@ -121,6 +126,48 @@ func TestCheckDetailAddendumACount(t *testing.T) {
 	}
 }
 
+// TestCheckDetailAddendumOrder validates CheckDetailAddendumA and CheckDetailAddendumC records are in
+// the order of their RecordNumbers and within their maximum counts
+func TestCheckDetailAddendumOrder(t *testing.T) {
+	newBundle := func(addendaA, addendaC []int) *Bundle {
+		cd := mockCheckDetail()
+		for _, n := range addendaA {
+			addendumA := mockCheckDetailAddendumA()
+			addendumA.RecordNumber = n
+			// appended directly, as AddCheckDetailAddendumA rejects a duplicate RecordNumber
+			cd.CheckDetailAddendumA = append(cd.CheckDetailAddendumA, addendumA)
+		}
+		for _, n := range addendaC {
+			addendumC := mockCheckDetailAddendumC()
+			addendumC.RecordNumber = n
+			cd.CheckDetailAddendumC = append(cd.CheckDetailAddendumC, addendumC)
+		}
+		cd.AddendumCount = len(addendaA) + len(addendaC)
+		bundle := NewBundle(mockBundleHeader())
+		bundle.AddCheckDetail(cd)
+		return bundle
+	}
+
+	if err := newBundle([]int{1, 2, 3}, []int{1, 2}).Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	cases := []struct {
+		addendaA, addendaC []int
+		field, msg         string
+	}{
+		{[]int{1, 3, 2}, nil, "CheckDetailAddendumA", fmt.Sprintf(msgBundleAddendumOrder, 2, 3)},
+		{[]int{1, 1}, nil, "CheckDetailAddendumA", fmt.Sprintf(msgBundleAddendumOrder, 1, 1)},
+		{[]int{1}, []int{2, 1}, "CheckDetailAddendumC", fmt.Sprintf(msgBundleAddendumOrder, 1, 2)},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, nil, "CheckDetailAddendumA", fmt.Sprintf(msgBundleAddendum, 10, CheckDetailAddendumACount)},
+	}
+	for _, c := range cases {
+		err := newBundle(c.addendaA, c.addendaC).Validate()
+		if e, ok := err.(*BundleError); !ok || e.FieldName != c.field || e.Msg != c.msg {
+			t.Errorf("%v %v: %T: %s", c.addendaA, c.addendaC, err, err)
+		}
+	}
+}
+
 // TestCheckDetailAddendumBCount validates CheckDetailAddendumB AddendaCount
 func TestCheckDetailAddendumBCount(t *testing.T) {
 	cd := mockCheckDetail()
@@ -151,7 +198,9 @@ func TestCheckDetailAddendumCCount(t *testing.T) {
 	cd.AddCheckDetailAddendumA(mockCheckDetailAddendumA())
 	cd.AddCheckDetailAddendumB(mockCheckDetailAddendumB())
 	for i := 0; i < 100; i++ {
-		cd.AddCheckDetailAddendumC(mockCheckDetailAddendumC())
+		addendumC := mockCheckDetailAddendumC()
+		addendumC.RecordNumber = i + 1
+		cd.AddCheckDetailAddendumC(addendumC)
 	}
 	cd.AddImageViewDetail(mockImageViewDetail())
 	cd.AddImageViewData(mockImageViewData())
@@ -197,7 +246,9 @@ func TestReturnDetailAddendumACount(t *testing.T) {
 	rd := mockReturnDetail()
 	rd.AddendumCount = 13
 	for i := 0; i < 10; i++ {
-		rd.AddReturnDetailAddendumA(mockReturnDetailAddendumA())
+		addendumA := mockReturnDetailAddendumA()
+		addendumA.RecordNumber = i + 1
+		rd.AddReturnDetailAddendumA(addendumA)
 	}
 	rd.AddReturnDetailAddendumB(mockReturnDetailAddendumB())
 	rd.AddReturnDetailAddendumC(mockReturnDetailAddendumC())
@@ -273,7 +324,9 @@ func TestReturnDetailAddendumDCount(t *testing.T) {
 	rd.AddReturnDetailAddendumB(mockReturnDetailAddendumB())
 	rd.AddReturnDetailAddendumC(mockReturnDetailAddendumC())
 	for i := 0; i < 100; i++ {
-		rd.AddReturnDetailAddendumD(mockReturnDetailAddendumD())
+		addendumD := mockReturnDetailAddendumD()
+		addendumD.RecordNumber = i + 1
+		rd.AddReturnDetailAddendumD(addendumD)
 	}
 	rd.AddImageViewDetail(mockImageViewDetail())
 	rd.AddImageViewData(mockImageViewData())
@@ -288,3 +341,162 @@ func TestReturnDetailAddendumDCount(t *testing.T) {
 		}
 	}
 }
+
+// TestReturnDetailAddendumOrder validates ReturnDetailAddendumA and ReturnDetailAddendumD records are in
+// the order of their RecordNumbers and within their maximum counts
+func TestReturnDetailAddendumOrder(t *testing.T) {
+	newBundle := func(addendaA, addendaD []int) *Bundle {
+		rd := mockReturnDetail()
+		for _, n := range addendaA {
+			addendumA := mockReturnDetailAddendumA()
+			addendumA.RecordNumber = n
+			// appended directly, as AddReturnDetailAddendumA rejects a duplicate RecordNumber
+			rd.ReturnDetailAddendumA = append(rd.ReturnDetailAddendumA, addendumA)
+		}
+		for _, n := range addendaD {
+			addendumD := mockReturnDetailAddendumD()
+			addendumD.RecordNumber = n
+			rd.ReturnDetailAddendumD = append(rd.ReturnDetailAddendumD, addendumD)
+		}
+		rd.AddendumCount = len(addendaA) + len(addendaD)
+		bundle := NewBundle(mockBundleHeader())
+		bundle.AddReturnDetail(rd)
+		return bundle
+	}
+
+	if err := newBundle([]int{1}, []int{1, 2, 3}).Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	over := make([]int, ReturnDetailAddendumDCount+1)
+	for i := range over {
+		over[i] = i + 1
+	}
+	cases := []struct {
+		addendaA, addendaD []int
+		field, msg         string
+	}{
+		{[]int{1}, []int{1, 3, 2}, "ReturnDetailAddendumD", fmt.Sprintf(msgBundleAddendumOrder, 2, 3)},
+		{[]int{1}, []int{2, 2}, "ReturnDetailAddendumD", fmt.Sprintf(msgBundleAddendumOrder, 2, 2)},
+		{[]int{2, 1}, []int{1}, "ReturnDetailAddendumA", fmt.Sprintf(msgBundleAddendumOrder, 1, 2)},
+		{[]int{1}, over, "ReturnDetailAddendumD", fmt.Sprintf(msgBundleAddendum, len(over), ReturnDetailAddendumDCount)},
+	}
+	for _, c := range cases {
+		err := newBundle(c.addendaA, c.addendaD).Validate()
+		if e, ok := err.(*BundleError); !ok || e.FieldName != c.field || e.Msg != c.msg {
+			t.Errorf("%v %v: %T: %s", c.addendaA, c.addendaD, err, err)
+		}
+	}
+
+	// the AddendumCount counts every addendum D
+	bundle := newBundle([]int{1}, []int{1, 2, 3})
+	bundle.Returns[0].AddendumCount = 3
+	err := bundle.Validate()
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "AddendumCount" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestCheckDetailImageViewDataCount validates a CheckDetail missing an ImageViewData record
+func TestCheckDetailImageViewDataCount(t *testing.T) {
+	bundle := mockBundleChecks()
+	cd := bundle.Checks[0]
+	cd.AddImageViewDetail(mockImageViewDetail())
+	cd.AddImageViewAnalysis(mockImageViewAnalysis())
+
+	err := bundle.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ImageViewData" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	cd.AddImageViewData(mockImageViewData())
+	if err := bundle.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestCheckDetailImageViewAnalysisCount validates a CheckDetail with more ImageViewAnalysis than ImageViewDetail records
+func TestCheckDetailImageViewAnalysisCount(t *testing.T) {
+	bundle := mockBundleChecks()
+	bundle.Checks[0].AddImageViewAnalysis(mockImageViewAnalysis())
+
+	err := bundle.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ImageViewAnalysis" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReturnDetailImageViewDataCount validates a ReturnDetail missing an ImageViewData record
+func TestReturnDetailImageViewDataCount(t *testing.T) {
+	bundle := mockBundleReturns()
+	rd := bundle.Returns[0]
+	rd.ImageViewData = nil
+
+	err := bundle.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ImageViewData" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestBundleBuildControl recalculates the BundleControl after items are added and removed
+func TestBundleBuildControl(t *testing.T) {
+	b := mockBundleChecks()
+	b.BundleControl.UserField = "USER"
+	expected := *b.BundleControl
+
+	cd := mockCheckDetail()
+	cd.ItemAmount = 2500
+	cd.MICRValidIndicator = 2
+	cd.AddImageViewDetail(mockImageViewDetail())
+	cd.AddImageViewData(mockImageViewData())
+	b.AddCheckDetail(cd)
+	if err := b.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	bc := b.GetControl()
+	if bc.BundleItemsCount != expected.BundleItemsCount+3 {
+		t.Errorf("BundleItemsCount=%d", bc.BundleItemsCount)
+	}
+	if bc.BundleTotalAmount != expected.BundleTotalAmount+2500 {
+		t.Errorf("BundleTotalAmount=%d", bc.BundleTotalAmount)
+	}
+	if bc.MICRValidTotalAmount != expected.MICRValidTotalAmount {
+		t.Errorf("MICRValidTotalAmount=%d", bc.MICRValidTotalAmount)
+	}
+	if bc.BundleImagesCount != expected.BundleImagesCount+1 {
+		t.Errorf("BundleImagesCount=%d", bc.BundleImagesCount)
+	}
+	if bc.UserField != "USER" {
+		t.Errorf("UserField=%q", bc.UserField)
+	}
+
+	// removing the item restores the original control
+	b.Checks = b.Checks[:1]
+	if err := b.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if *b.GetControl() != expected {
+		t.Errorf("BundleControl %#v != %#v", *b.GetControl(), expected)
+	}
+
+	b.Checks = nil
+	if err := b.BuildControl(); err != nil {
+		if e, ok := err.(*BundleError); !ok || e.FieldName != "entries" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+	if *b.GetControl() != expected {
+		t.Error("BundleControl changed by an error")
+	}
+}
+
+// TestBundleMixedItems validates a Bundle doesn't hold both checks and returns
+func TestBundleMixedItems(t *testing.T) {
+	bundle := mockBundleChecks()
+	bundle.AddReturnDetail(mockReturnDetail())
+	err := bundle.Validate()
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "entries" || e.Msg != msgBundleMixedItems {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -11,12 +11,19 @@ type CashLetterError struct {
 	CashLetterID string
 	FieldName    string
 	Msg          string
+	// Err is the sentinel error, such as ErrFieldInclusion, the error was built from
+	Err error
 }
 
 func (e *CashLetterError) Error() string {
 	return fmt.Sprintf("CashLetterNumber %s %s %s", e.CashLetterID, e.FieldName, e.Msg)
 }
 
+// Unwrap returns the sentinel error the error was built from, if any, for errors.Is
+func (e *CashLetterError) Unwrap() error {
+	return e.Err
+}
+
 // Errors specific to parsing a CashLetter
 var (
 	msgCashLetterBundleEntries = "%v cannot have bundle entries"
@@ -33,6 +40,8 @@ type CashLetter struct {
 	Bundles []*Bundle `json:"bundles,omitempty"`
 	// CreditItems is an array of CreditItem
 	CreditItems []*CreditItem `json:"creditItem,omitempty"`
+	// Credits is an array of Credit
+	Credits []*Credit `json:"credits,omitempty"`
 	// RoutingNumberSummary is an array of RoutingNumberSummary
 	RoutingNumberSummary []*RoutingNumberSummary `json:"routingNumberSummary,omitempty"`
 	// currentBundle is the currentBundle being parsed
@@ -63,6 +72,9 @@ func (cl *CashLetter) setRecordType() {
 	for i := range cl.CreditItems {
 		cl.CreditItems[i].setRecordType()
 	}
+	for i := range cl.Credits {
+		cl.Credits[i].setRecordType()
+	}
 	for i := range cl.RoutingNumberSummary {
 		cl.RoutingNumberSummary[i].setRecordType()
 	}
@@ -92,6 +104,43 @@ func (cl *CashLetter) Validate() error {
 	return nil
 }
 
+// validateRecords validates the CashLetter and every record it contains, returning the first error found.
+func (cl *CashLetter) validateRecords() error {
+	if cl.CashLetterHeader == nil {
+		return &CashLetterError{FieldName: "CashLetterHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+	}
+	if err := cl.CashLetterHeader.Validate(); err != nil {
+		return err
+	}
+	if err := cl.Validate(); err != nil {
+		return err
+	}
+	for _, ci := range cl.CreditItems {
+		if err := ci.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, cr := range cl.Credits {
+		if err := cr.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, b := range cl.Bundles {
+		if err := b.validateRecords(); err != nil {
+			return err
+		}
+	}
+	for _, rns := range cl.RoutingNumberSummary {
+		if err := rns.Validate(); err != nil {
+			return err
+		}
+	}
+	if cl.CashLetterControl != nil {
+		return cl.CashLetterControl.Validate()
+	}
+	return nil
+}
+
 // build a valid CashLetter by building a CashLetterControl. An error is returned if
 // the CashLetter being built has invalid records.
 func (cl *CashLetter) build() error {
@@ -112,8 +161,15 @@ func (cl *CashLetter) build() error {
 	//creditIndicator
 	creditIndicator := 0
 
-	if len(cl.GetCreditItems()) > 0 {
-		cashLetterItemsCount = cashLetterItemsCount + len(cl.GetCreditItems())
+	// Credit Items
+	for _, ci := range cl.allCreditItems() {
+		cashLetterItemsCount = cashLetterItemsCount + 1
+		cashLetterTotalAmount = cashLetterTotalAmount + ci.ItemAmount
+		creditIndicator = 1
+	}
+	for _, cr := range cl.allCredits() {
+		cashLetterItemsCount = cashLetterItemsCount + 1
+		cashLetterTotalAmount = cashLetterTotalAmount + cr.ItemAmount
 		creditIndicator = 1
 	}
 	// Bundles
@@ -145,7 +201,7 @@ func (cl *CashLetter) build() error {
 			}
 			for x := range cd.CheckDetailAddendumC {
 				cd.CheckDetailAddendumC[x].SetEndorsingBankItemSequenceNumber(cdSequenceNumber)
-				cd.CheckDetailAddendumC[x].RecordNumber = cdAddendumARecordNumber
+				cd.CheckDetailAddendumC[x].RecordNumber = cdAddendumCRecordNumber
 				cdAddendumCRecordNumber++
 				if cdAddendumCRecordNumber > 99 {
 					cdAddendumCRecordNumber = 1
@@ -184,7 +240,7 @@ func (cl *CashLetter) build() error {
 
 			for x := range rd.ReturnDetailAddendumD {
 				rd.ReturnDetailAddendumD[x].SetEndorsingBankItemSequenceNumber(rdSequenceNumber)
-				rd.ReturnDetailAddendumA[x].RecordNumber = rdAddendumDRecordNumber
+				rd.ReturnDetailAddendumD[x].RecordNumber = rdAddendumDRecordNumber
 				rdAddendumDRecordNumber++
 				if rdAddendumDRecordNumber > 99 {
 					rdAddendumDRecordNumber = 1
@@ -230,6 +286,56 @@ func (cl *CashLetter) Create() error {
 	return cl.Validate()
 }
 
+// BuildControl recalculates the counts and amounts of the CashLetterControl from the CashLetter's Bundles
+// and the CreditItems and Credits of the CashLetter and its Bundles, creating the CashLetterControl if there
+// is none. Unlike Create the Bundles are not validated or renumbered, their BundleControls are not rebuilt
+// and the other CashLetterControl fields are kept. The FileControl is not changed; call File.Create to recalculate it.
+func (cl *CashLetter) BuildControl() error {
+	if cl.CashLetterHeader == nil {
+		return &CashLetterError{FieldName: "CashLetterHeader", Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+	}
+	clc := NewCashLetterControl()
+	if cl.CashLetterControl != nil {
+		*clc = *cl.CashLetterControl
+	} else {
+		clc.ECEInstitutionName = cl.CashLetterHeader.ECEInstitutionRoutingNumber
+	}
+	credits := len(cl.allCreditItems()) + len(cl.allCredits())
+	clc.CashLetterBundleCount = len(cl.Bundles)
+	clc.CashLetterItemsCount = cl.itemsCount() + credits
+	clc.CashLetterTotalAmount = int(cl.TotalAmount())
+	clc.CashLetterImagesCount = cl.imagesCount()
+	clc.CreditTotalIndicator = 0
+	if credits > 0 {
+		clc.CreditTotalIndicator = 1
+	}
+	if err := clc.Validate(); err != nil {
+		return err
+	}
+	cl.CashLetterControl = clc
+	return nil
+}
+
+// TotalAmount returns the sum in cents of the ItemAmount of every item in the CashLetter's Bundles along
+// with the CreditItems and Credits of the CashLetter and its Bundles, which is the CashLetterTotalAmount
+// calculated by build.
+func (cl *CashLetter) TotalAmount() int64 {
+	if cl == nil {
+		return 0
+	}
+	var total int64
+	for _, b := range cl.Bundles {
+		total = total + b.TotalAmount()
+	}
+	for _, ci := range cl.allCreditItems() {
+		total = total + int64(ci.ItemAmount)
+	}
+	for _, cr := range cl.allCredits() {
+		total = total + int64(cr.ItemAmount)
+	}
+	return total
+}
+
 // SetHeader appends a CashLetterHeader to the CashLetter
 func (cl *CashLetter) SetHeader(cashLetterHeader *CashLetterHeader) {
 	cl.CashLetterHeader = cashLetterHeader
@@ -291,3 +397,68 @@ func (cl *CashLetter) GetCreditItems() []*CreditItem {
 	}
 	return cl.CreditItems
 }
+
+// AddCredit appends a Credit to the CashLetter
+func (cl *CashLetter) AddCredit(cr *Credit) []*Credit {
+	cl.Credits = append(cl.Credits, cr)
+	return cl.Credits
+}
+
+// GetCredits returns a slice of Credit for the CashLetter
+func (cl *CashLetter) GetCredits() []*Credit {
+	if cl == nil {
+		return nil
+	}
+	return cl.Credits
+}
+
+// allCreditItems returns the CreditItems of the CashLetter followed by the CreditItems of each Bundle
+func (cl *CashLetter) allCreditItems() []*CreditItem {
+	items := append([]*CreditItem(nil), cl.CreditItems...)
+	for _, b := range cl.Bundles {
+		if b != nil {
+			items = append(items, b.CreditItems...)
+		}
+	}
+	return items
+}
+
+// allCredits returns the Credits of the CashLetter followed by the Credits of each Bundle
+func (cl *CashLetter) allCredits() []*Credit {
+	credits := append([]*Credit(nil), cl.Credits...)
+	for _, b := range cl.Bundles {
+		if b != nil {
+			credits = append(credits, b.Credits...)
+		}
+	}
+	return credits
+}
+
+// copy returns a copy of the CashLetter which shares no records with it
+func (cl *CashLetter) copy() CashLetter {
+	c := CashLetter{ID: cl.ID}
+	if cl.CashLetterHeader != nil {
+		clh := *cl.CashLetterHeader
+		c.CashLetterHeader = &clh
+	}
+	if cl.CashLetterControl != nil {
+		clc := *cl.CashLetterControl
+		c.CashLetterControl = &clc
+	}
+	for _, b := range cl.Bundles {
+		if b != nil {
+			b = b.copy()
+		}
+		c.Bundles = append(c.Bundles, b)
+	}
+	c.CreditItems = copyCreditItems(cl.CreditItems)
+	c.Credits = copyCredits(cl.Credits)
+	for _, rns := range cl.RoutingNumberSummary {
+		if rns != nil {
+			r := *rns
+			rns = &r
+		}
+		c.RoutingNumberSummary = append(c.RoutingNumberSummary, rns)
+	}
+	return c
+}
@@ -6,14 +6,14 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
 
 // Errors specific to a CashLetterControl Record
 
-//CashLetterControl Record
+// CashLetterControl Record. It repeats no field of the CashLetterHeader: ECEInstitutionName is a name rather
+// than the ECE institution routing number, so only the totals are validated against the CashLetter.
 type CashLetterControl struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
@@ -44,6 +44,8 @@ type CashLetterControl struct {
 	CreditTotalIndicator int `json:"creditTotalIndicator"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -62,11 +64,19 @@ func (clc *CashLetterControl) setRecordType() {
 		return
 	}
 
-	clc.recordType = "90"
-	clc.SettlementDate = time.Now()
+	clc.recordType = RecordTypeCashLetterControl
+	// keep a SettlementDate provided by the caller, such as from JSON
+	if clc.SettlementDate.IsZero() {
+		clc.SettlementDate = time.Now()
+	}
 	clc.reserved = "              "
 }
 
+// RecordType returns the record type of the CashLetterControl, which is RecordTypeCashLetterControl once set by NewCashLetterControl or Parse
+func (clc *CashLetterControl) RecordType() string {
+	return clc.recordType
+}
+
 // Parse takes the input record string and parses the CashLetterControl values
 func (clc *CashLetterControl) Parse(record string) {
 	if utf8.RuneCountInString(record) != 80 {
@@ -80,7 +90,8 @@ func (clc *CashLetterControl) Parse(record string) {
 	// 09-16
 	clc.CashLetterItemsCount = clc.parseNumField(record[8:16])
 	// 17-30
-	clc.CashLetterTotalAmount = clc.parseNumField(record[16:30])
+	cashLetterTotalAmount, _ := clc.parseAmount(record[16:30])
+	clc.CashLetterTotalAmount = int(cashLetterTotalAmount)
 	// 31-39
 	clc.CashLetterImagesCount = clc.parseNumField(record[30:39])
 	// 40-57
@@ -90,23 +101,26 @@ func (clc *CashLetterControl) Parse(record string) {
 	// 66-66
 	clc.CreditTotalIndicator = clc.parseNumField(record[65:66])
 	// 67-80
-	clc.reserved = "              "
+	clc.reserved = clc.parseReservedField(record, 66, 80)
 }
 
 // String writes the CashLetterControl struct to a string.
 func (clc *CashLetterControl) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(clc.recordType)
-	buf.WriteString(clc.CashLetterBundleCountField())
-	buf.WriteString(clc.CashLetterItemsCountField())
-	buf.WriteString(clc.CashLetterTotalAmountField())
-	buf.WriteString(clc.CashLetterImagesCountField())
-	buf.WriteString(clc.ECEInstitutionNameField())
-	buf.WriteString(clc.SettlementDateField())
-	buf.WriteString(clc.CreditTotalIndicatorField())
-	buf.WriteString(clc.reservedField())
-	return buf.String()
+	return string(clc.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the CashLetterControl record, as String returns it, to b and returns the extended buffer.
+func (clc *CashLetterControl) AppendTo(b []byte) []byte {
+	b = append(b, clc.recordType...)
+	b = clc.appendNumericField(b, clc.CashLetterBundleCount, 6)
+	b = clc.appendNumericField(b, clc.CashLetterItemsCount, 8)
+	b = clc.appendAmount(b, int64(clc.CashLetterTotalAmount), 14)
+	b = clc.appendNumericField(b, clc.CashLetterImagesCount, 9)
+	b = clc.appendAlphaField(b, clc.ECEInstitutionName, 18)
+	b = clc.appendYYYYMMDDDate(b, clc.SettlementDate)
+	b = clc.appendNumericField(b, clc.CreditTotalIndicator, 1)
+	b = clc.appendAlphaField(b, clc.reserved, 14)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -115,9 +129,9 @@ func (clc *CashLetterControl) Validate() error {
 	if err := clc.fieldInclusion(); err != nil {
 		return err
 	}
-	if clc.recordType != "90" {
+	if clc.recordType != RecordTypeCashLetterControl {
 		msg := fmt.Sprintf(msgRecordType, 90)
-		return &FieldError{FieldName: "recordType", Value: clc.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: clc.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := clc.isAlphanumericSpecial(clc.ECEInstitutionName); err != nil {
 		return &FieldError{FieldName: "ECEInstitutionName", Value: clc.ECEInstitutionName, Msg: err.Error()}
@@ -127,6 +141,9 @@ func (clc *CashLetterControl) Validate() error {
 			return &FieldError{FieldName: "CreditTotalIndicator", Value: clc.CreditTotalIndicatorField(), Msg: err.Error()}
 		}
 	}
+	if err := clc.isDateInRange(clc.SettlementDate); err != nil {
+		return &FieldError{FieldName: "SettlementDate", Value: clc.SettlementDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -136,22 +153,22 @@ func (clc *CashLetterControl) fieldInclusion() error {
 	if clc.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: clc.recordType,
-			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?", Err: ErrFieldInclusion}
 	}
 	if clc.CashLetterItemsCount == 0 {
 		return &FieldError{FieldName: "CashLetterItemsCount",
 			Value: clc.CashLetterItemsCountField(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?", Err: ErrFieldInclusion}
 	}
 	if clc.CashLetterTotalAmount == 0 {
 		return &FieldError{FieldName: "CashLetterTotalAmount",
 			Value: clc.CashLetterTotalAmountField(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?", Err: ErrFieldInclusion}
 	}
 	if clc.SettlementDate.IsZero() {
 		return &FieldError{FieldName: "SettlementDate",
 			Value: clc.SettlementDate.String(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterControl()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -168,7 +185,23 @@ func (clc *CashLetterControl) CashLetterItemsCountField() string {
 
 // CashLetterTotalAmountField gets a string of the CashLetterTotalAmount zero padded
 func (clc *CashLetterControl) CashLetterTotalAmountField() string {
-	return clc.numericField(clc.CashLetterTotalAmount, 14)
+	return clc.formatAmount(int64(clc.CashLetterTotalAmount), 14)
+}
+
+// SetCashLetterTotalAmount sets CashLetterTotalAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 14 digit field.
+func (clc *CashLetterControl) SetCashLetterTotalAmount(cents int64) error {
+	amount, err := clc.amountFromCents("CashLetterTotalAmount", cents, 14)
+	if err != nil {
+		return err
+	}
+	clc.CashLetterTotalAmount = amount
+	return nil
+}
+
+// CashLetterTotalAmountCents returns CashLetterTotalAmount in cents
+func (clc *CashLetterControl) CashLetterTotalAmountCents() int64 {
+	return int64(clc.CashLetterTotalAmount)
 }
 
 // CashLetterImagesCountField gets a string of the CashLetterImagesCount zero padded
@@ -5,6 +5,7 @@
 package imagecashletter
 
 import (
+	"encoding/json"
 	"log"
 	"strings"
 	"testing"
@@ -228,3 +229,49 @@ func TestCashLetterControlRuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString")
 	}
 }
+
+// TestCashLetterControlSettlementDateKept validates a SettlementDate set by the caller is kept when record types
+// are set, such as by FileFromJSON, and only a zero SettlementDate is given the current time
+func TestCashLetterControlSettlementDateKept(t *testing.T) {
+	settlement := time.Date(2018, time.September, 5, 0, 0, 0, 0, time.UTC)
+	clc := &CashLetterControl{SettlementDate: settlement}
+	clc.setRecordType()
+	if !clc.SettlementDate.Equal(settlement) {
+		t.Errorf("SettlementDate %v, expected %v", clc.SettlementDate, settlement)
+	}
+	if clc.recordType != RecordTypeCashLetterControl {
+		t.Errorf("unexpected recordType %q", clc.recordType)
+	}
+
+	clc = &CashLetterControl{}
+	before := time.Now()
+	clc.setRecordType()
+	if clc.SettlementDate.Before(before) {
+		t.Errorf("SettlementDate %v, expected the current time", clc.SettlementDate)
+	}
+
+	// a SettlementDate read from JSON is kept
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	cl := NewCashLetter(mockCashLetterHeader())
+	cl.AddBundle(mockBundleChecks())
+	if err := cl.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file.AddCashLetter(cl)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file.CashLetters[0].CashLetterControl.SettlementDate = settlement
+	bs, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if date := read.CashLetters[0].CashLetterControl.SettlementDate; !date.Equal(settlement) {
+		t.Errorf("SettlementDate %v, expected %v", date, settlement)
+	}
+}
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -139,6 +138,8 @@ type CashLetterHeader struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -156,7 +157,12 @@ func (clh *CashLetterHeader) setRecordType() {
 	if clh == nil {
 		return
 	}
-	clh.recordType = "10"
+	clh.recordType = RecordTypeCashLetterHeader
+}
+
+// RecordType returns the record type of the CashLetterHeader, which is RecordTypeCashLetterHeader once set by NewCashLetterHeader or Parse
+func (clh *CashLetterHeader) RecordType() string {
+	return clh.recordType
 }
 
 // Parse takes the input record string and parses the CashLetterHeader values
@@ -196,30 +202,53 @@ func (clh *CashLetterHeader) Parse(record string) {
 	// 79-79
 	clh.UserField = clh.parseStringField(record[78:79])
 	// 80-80
-	clh.reserved = " "
+	clh.reserved = clh.parseReservedField(record, 79, 80)
+}
+
+// parseLayout takes the input record string and parses the CashLetterHeader values in the layout of the
+// StandardLevel level
+func (clh *CashLetterHeader) parseLayout(record, level string) {
+	clh.Parse(record)
+	if level == StandardLevelDSTU2003 && utf8.RuneCountInString(record) == 80 {
+		// 78-79 is a 2 character UserField
+		clh.ReturnsIndicator = ""
+		clh.UserField = clh.parseStringField(record[77:79])
+	}
+}
+
+// layoutString writes the CashLetterHeader struct to a string in the layout of the StandardLevel level
+func (clh *CashLetterHeader) layoutString(level string) string {
+	record := clh.String()
+	if level == StandardLevelDSTU2003 && len(record) == 80 {
+		return record[:77] + clh.alphaField(clh.UserField, 2) + record[79:]
+	}
+	return record
 }
 
 // String writes the CashLetterHeader struct to a string.
 func (clh *CashLetterHeader) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(clh.recordType)
-	buf.WriteString(clh.CollectionTypeIndicatorField())
-	buf.WriteString(clh.DestinationRoutingNumberField())
-	buf.WriteString(clh.ECEInstitutionRoutingNumberField())
-	buf.WriteString(clh.CashLetterBusinessDateField())
-	buf.WriteString(clh.CashLetterCreationDateField())
-	buf.WriteString(clh.CashLetterCreationTimeField())
-	buf.WriteString(clh.RecordTypeIndicatorField())
-	buf.WriteString(clh.DocumentationTypeIndicatorField())
-	buf.WriteString(clh.CashLetterIDField())
-	buf.WriteString(clh.OriginatorContactNameField())
-	buf.WriteString(clh.OriginatorContactPhoneNumberField())
-	buf.WriteString(clh.FedWorkTypeField())
-	buf.WriteString(clh.ReturnsIndicatorField())
-	buf.WriteString(clh.UserFieldField())
-	buf.WriteString(clh.reservedField())
-	return buf.String()
+	return string(clh.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the CashLetterHeader record, as String returns it, to b and returns the extended buffer.
+func (clh *CashLetterHeader) AppendTo(b []byte) []byte {
+	b = append(b, clh.recordType...)
+	b = clh.appendAlphaField(b, clh.CollectionTypeIndicator, 2)
+	b = clh.appendStringField(b, clh.DestinationRoutingNumber, 9)
+	b = clh.appendStringField(b, clh.ECEInstitutionRoutingNumber, 9)
+	b = clh.appendYYYYMMDDDate(b, clh.CashLetterBusinessDate)
+	b = clh.appendYYYYMMDDDate(b, clh.CashLetterCreationDate)
+	b = clh.appendSimpleTime(b, clh.CashLetterCreationTime)
+	b = clh.appendAlphaField(b, clh.RecordTypeIndicator, 1)
+	b = clh.appendAlphaField(b, clh.DocumentationTypeIndicator, 1)
+	b = clh.appendAlphaField(b, clh.CashLetterID, 8)
+	b = clh.appendAlphaField(b, clh.OriginatorContactName, 14)
+	b = clh.appendAlphaField(b, clh.OriginatorContactPhoneNumber, 10)
+	b = clh.appendAlphaField(b, clh.FedWorkType, 1)
+	b = clh.appendAlphaField(b, clh.ReturnsIndicator, 1)
+	b = clh.appendAlphaField(b, clh.UserField, 1)
+	b = clh.appendAlphaField(b, clh.reserved, 1)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -228,9 +257,9 @@ func (clh *CashLetterHeader) Validate() error {
 	if err := clh.fieldInclusion(); err != nil {
 		return err
 	}
-	if clh.recordType != "10" {
+	if clh.recordType != RecordTypeCashLetterHeader {
 		msg := fmt.Sprintf(msgRecordType, 10)
-		return &FieldError{FieldName: "recordType", Value: clh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: clh.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	// Mandatory
 	if err := clh.isCollectionTypeIndicator(clh.CollectionTypeIndicator); err != nil {
@@ -266,6 +295,12 @@ func (clh *CashLetterHeader) Validate() error {
 	if err := clh.isAlphanumericSpecial(clh.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: clh.UserField, Msg: err.Error()}
 	}
+	if err := clh.isDateInRange(clh.CashLetterBusinessDate); err != nil {
+		return &FieldError{FieldName: "CashLetterBusinessDate", Value: clh.CashLetterBusinessDateField(), Msg: err.Error()}
+	}
+	if err := clh.isDateInRange(clh.CashLetterCreationDate); err != nil {
+		return &FieldError{FieldName: "CashLetterCreationDate", Value: clh.CashLetterCreationDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -275,57 +310,57 @@ func (clh *CashLetterHeader) fieldInclusion() error {
 	if clh.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: clh.recordType,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.CollectionTypeIndicator == "" {
 		return &FieldError{FieldName: "CollectionTypeIndicator",
 			Value: clh.CollectionTypeIndicator,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.RecordTypeIndicator == "" {
 		return &FieldError{FieldName: "RecordTypeIndicator",
 			Value: clh.RecordTypeIndicator,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.DestinationRoutingNumber == "" {
 		return &FieldError{FieldName: "DestinationRoutingNumber",
 			Value: clh.DestinationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.ECEInstitutionRoutingNumber == "" {
 		return &FieldError{FieldName: "ECEInstitutionRoutingNumber",
 			Value: clh.ECEInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
-	if clh.DestinationRoutingNumberField() == "000000000" {
+	if clh.isZeroRoutingNumber(clh.DestinationRoutingNumber) {
 		return &FieldError{FieldName: "DestinationRoutingNumber",
 			Value: clh.DestinationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
-	if clh.ECEInstitutionRoutingNumberField() == "000000000" {
+	if clh.isZeroRoutingNumber(clh.ECEInstitutionRoutingNumber) {
 		return &FieldError{FieldName: "ECEInstitutionRoutingNumber",
 			Value: clh.ECEInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.CashLetterBusinessDate.IsZero() {
 		return &FieldError{FieldName: "CashLetterBusinessDate",
 			Value: clh.CashLetterBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.CashLetterCreationDate.IsZero() {
 		return &FieldError{FieldName: "CashLetterCreationDate",
 			Value: clh.CashLetterCreationDate.String(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.CashLetterCreationTime.IsZero() {
 		return &FieldError{FieldName: "CashLetterCreationTime",
 			Value: clh.CashLetterCreationTime.String(),
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	if clh.CashLetterID == "" {
 		return &FieldError{FieldName: "CashLetterID",
 			Value: clh.CashLetterID,
-			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use CashLetterHeader()?", Err: ErrFieldInclusion}
 	}
 	// clh.ReturnsIndicator can be ""
 	return nil
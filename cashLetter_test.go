@@ -16,6 +16,9 @@ func TestCashLetterPanics(t *testing.T) {
 	if v := cl.GetCreditItems(); v != nil {
 		t.Errorf("unexpected GetCreditItems: %v", v)
 	}
+	if v := cl.GetCredits(); v != nil {
+		t.Errorf("unexpected GetCredits: %v", v)
+	}
 }
 
 // TestCashLetterNoBundle validates no Bundle when CashLetterHeader.RecordTypeIndicator = "N"
@@ -72,3 +75,89 @@ func TestCashLetterRoutingNumberSummary(t *testing.T) {
 		}
 	}
 }
+
+// TestCashLetterBuildControl recalculates the BundleControl and CashLetterControl of a read CashLetter after
+// an item is removed
+func TestCashLetterBuildControl(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	cl := &file.CashLetters[0]
+	expected := *cl.GetControl()
+	b := cl.GetBundles()[0]
+	removed := b.Checks[len(b.Checks)-1]
+	b.Checks = b.Checks[:len(b.Checks)-1]
+
+	if err := b.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := cl.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	clc := cl.GetControl()
+	if clc.CashLetterBundleCount != expected.CashLetterBundleCount {
+		t.Errorf("CashLetterBundleCount=%d", clc.CashLetterBundleCount)
+	}
+	items := 1 + len(removed.CheckDetailAddendumA) + len(removed.CheckDetailAddendumB) + len(removed.CheckDetailAddendumC) +
+		len(removed.ImageViewDetail) + len(removed.ImageViewData) + len(removed.ImageViewAnalysis)
+	if clc.CashLetterItemsCount != expected.CashLetterItemsCount-items {
+		t.Errorf("CashLetterItemsCount=%d", clc.CashLetterItemsCount)
+	}
+	if clc.CashLetterTotalAmount != expected.CashLetterTotalAmount-removed.ItemAmount {
+		t.Errorf("CashLetterTotalAmount=%d", clc.CashLetterTotalAmount)
+	}
+	if clc.CashLetterImagesCount != expected.CashLetterImagesCount-len(removed.ImageViewDetail) {
+		t.Errorf("CashLetterImagesCount=%d", clc.CashLetterImagesCount)
+	}
+	if clc.ECEInstitutionName != expected.ECEInstitutionName || !clc.SettlementDate.Equal(expected.SettlementDate) {
+		t.Errorf("CashLetterControl fields changed: %#v", clc)
+	}
+	opts := newValidateOptions([]ValidateOption{WithCountTolerance(0)})
+	if err := cl.validateControlCounts(opts); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := cl.validateImagesCount(opts); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// a CashLetterControl is created when there is none
+	cl.CashLetterControl = nil
+	if err := cl.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if cl.GetControl().ECEInstitutionName != cl.GetHeader().ECEInstitutionRoutingNumber {
+		t.Errorf("ECEInstitutionName=%q", cl.GetControl().ECEInstitutionName)
+	}
+}
+
+// TestCashLetterCreateAddendaRecordNumbers validates Create numbers multiple CheckDetailAddendumC and
+// ReturnDetailAddendumD records in order
+func TestCashLetterCreateAddendaRecordNumbers(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	cl := &file.CashLetters[0]
+	cd := cl.Bundles[0].Checks[0]
+	rd := cl.Bundles[1].Returns[0]
+	for i := 0; i < 3; i++ {
+		cd.CheckDetailAddendumC = append(cd.CheckDetailAddendumC, cd.CheckDetailAddendumC[0])
+		rd.ReturnDetailAddendumD = append(rd.ReturnDetailAddendumD, rd.ReturnDetailAddendumD[0])
+	}
+	cd.AddendumCount = len(cd.CheckDetailAddendumA) + len(cd.CheckDetailAddendumB) + len(cd.CheckDetailAddendumC)
+	rd.AddendumCount = len(rd.ReturnDetailAddendumA) + len(rd.ReturnDetailAddendumB) + len(rd.ReturnDetailAddendumC) + len(rd.ReturnDetailAddendumD)
+
+	if err := cl.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for i, addendumC := range cd.CheckDetailAddendumC {
+		if addendumC.RecordNumber != i+1 {
+			t.Errorf("CheckDetailAddendumC[%d] RecordNumber=%d", i, addendumC.RecordNumber)
+		}
+	}
+	for i, addendumD := range rd.ReturnDetailAddendumD {
+		if addendumD.RecordNumber != i+1 {
+			t.Errorf("ReturnDetailAddendumD[%d] RecordNumber=%d", i, addendumD.RecordNumber)
+		}
+	}
+	for i, addendumA := range rd.ReturnDetailAddendumA {
+		if addendumA.RecordNumber != i+1 {
+			t.Errorf("ReturnDetailAddendumA[%d] RecordNumber=%d", i, addendumA.RecordNumber)
+		}
+	}
+}
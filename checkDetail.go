@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -15,6 +14,10 @@ import (
 
 var (
 	msgDocumentationTypeIndicator = "is Invalid"
+	msgDocumentationTypeImages    = "does not match %d image views"
+	msgImageViewDataCount         = "%d records do not match %d ImageViewDetail records"
+	msgImageViewAnalysisCount     = "%d records exceed %d ImageViewDetail records"
+	msgAddendumRecordNumber       = "is already used by another addendum of the item"
 )
 
 // CheckDetail Record
@@ -147,6 +150,8 @@ type CheckDetail struct {
 	ImageViewData []ImageViewData `json:"imageViewData"`
 	// ImageViewAnalysis
 	ImageViewAnalysis []ImageViewAnalysis `json:"imageViewAnalysis"`
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -164,7 +169,12 @@ func (cd *CheckDetail) setRecordType() {
 	if cd == nil {
 		return
 	}
-	cd.recordType = "25"
+	cd.recordType = RecordTypeCheckDetail
+}
+
+// RecordType returns the record type of the CheckDetail, which is RecordTypeCheckDetail once set by NewCheckDetail or Parse
+func (cd *CheckDetail) RecordType() string {
+	return cd.recordType
 }
 
 // Parse takes the input record string and parses the CheckDetail values
@@ -186,7 +196,8 @@ func (cd *CheckDetail) Parse(record string) {
 	// 28-47
 	cd.OnUs = cd.parseStringField(record[27:47])
 	// 48-57
-	cd.ItemAmount = cd.parseNumField(record[47:57])
+	itemAmount, _ := cd.parseAmount(record[47:57])
+	cd.ItemAmount = int(itemAmount)
 	// 58-72
 	cd.EceInstitutionItemSequenceNumber = cd.parseStringField(record[57:72])
 	// 73-73
@@ -207,24 +218,27 @@ func (cd *CheckDetail) Parse(record string) {
 
 // String writes the CheckDetail struct to a variable length string.
 func (cd *CheckDetail) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(cd.recordType)
-	buf.WriteString(cd.AuxiliaryOnUsField())
-	buf.WriteString(cd.ExternalProcessingCodeField())
-	buf.WriteString(cd.PayorBankRoutingNumberField())
-	buf.WriteString(cd.PayorBankCheckDigitField())
-	buf.WriteString(cd.OnUsField())
-	buf.WriteString(cd.ItemAmountField())
-	buf.WriteString(cd.EceInstitutionItemSequenceNumberField())
-	buf.WriteString(cd.DocumentationTypeIndicatorField())
-	buf.WriteString(cd.ReturnAcceptanceIndicatorField())
-	buf.WriteString(cd.MICRValidIndicatorField())
-	buf.WriteString(cd.BOFDIndicatorField())
-	buf.WriteString(cd.AddendumCountField())
-	buf.WriteString(cd.CorrectionIndicatorField())
-	buf.WriteString(cd.ArchiveTypeIndicatorField())
-	return buf.String()
+	return string(cd.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the CheckDetail record, as String returns it, to b and returns the extended buffer.
+func (cd *CheckDetail) AppendTo(b []byte) []byte {
+	b = append(b, cd.recordType...)
+	b = cd.appendNBSMField(b, cd.AuxiliaryOnUs, 15)
+	b = cd.appendAlphaField(b, cd.ExternalProcessingCode, 1)
+	b = cd.appendStringField(b, cd.PayorBankRoutingNumber, 8)
+	b = cd.appendStringField(b, cd.PayorBankCheckDigit, 1)
+	b = cd.appendNBSMField(b, cd.OnUs, 20)
+	b = cd.appendAmount(b, int64(cd.ItemAmount), 10)
+	b = cd.appendAlphaField(b, cd.EceInstitutionItemSequenceNumber, 15)
+	b = cd.appendAlphaField(b, cd.DocumentationTypeIndicator, 1)
+	b = cd.appendAlphaField(b, cd.ReturnAcceptanceIndicator, 1)
+	b = cd.appendNumericField(b, cd.MICRValidIndicator, 1)
+	b = cd.appendAlphaField(b, cd.BOFDIndicator, 1)
+	b = cd.appendNumericField(b, cd.AddendumCount, 2)
+	b = cd.appendNumericField(b, cd.CorrectionIndicator, 1)
+	b = cd.appendAlphaField(b, cd.ArchiveTypeIndicator, 1)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -233,9 +247,9 @@ func (cd *CheckDetail) Validate() error {
 	if err := cd.fieldInclusion(); err != nil {
 		return err
 	}
-	if cd.recordType != "25" {
+	if cd.recordType != RecordTypeCheckDetail {
 		msg := fmt.Sprintf(msgRecordType, 25)
-		return &FieldError{FieldName: "recordType", Value: cd.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: cd.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if cd.DocumentationTypeIndicator != "" {
 		// Z is valid for CashLetter DocumentationTypeIndicator only
@@ -278,38 +292,81 @@ func (cd *CheckDetail) Validate() error {
 	return nil
 }
 
+// validateImageViews checks the DocumentationTypeIndicator in effect for the CheckDetail against its
+// ImageViewDetail records. The CashLetterHeader DocumentationTypeIndicator supersedes the CheckDetail
+// value unless it is Z or blank. Values G, H, I and J require image views and all others forbid them.
+func (cd *CheckDetail) validateImageViews(cashLetterIndicator string) error {
+	indicator := cashLetterIndicator
+	if indicator == "" || indicator == "Z" {
+		indicator = cd.DocumentationTypeIndicator
+	}
+	if indicator == "" {
+		return nil
+	}
+	var imagesIncluded bool
+	switch indicator {
+	case "G", "H", "I", "J":
+		imagesIncluded = true
+	}
+	if imagesIncluded != (len(cd.ImageViewDetail) > 0) {
+		msg := fmt.Sprintf(msgDocumentationTypeImages, len(cd.ImageViewDetail))
+		return &FieldError{FieldName: "DocumentationTypeIndicator", Value: indicator, Msg: msg}
+	}
+	return nil
+}
+
+// validateImageViewCounts checks that each ImageViewDetail of the CheckDetail has one ImageViewData
+// and at most one ImageViewAnalysis.
+func (cd *CheckDetail) validateImageViewCounts() error {
+	return validateImageViewCounts(len(cd.ImageViewDetail), len(cd.ImageViewData), len(cd.ImageViewAnalysis))
+}
+
+// validateImageViewCounts compares the number of ImageViewData and ImageViewAnalysis records of an item
+// against the number of image views declared by its ImageViewDetail records.
+func validateImageViewCounts(details, data, analysis int) error {
+	if data != details {
+		msg := fmt.Sprintf(msgImageViewDataCount, data, details)
+		return &FieldError{FieldName: "ImageViewData", Value: strconv.Itoa(data), Msg: msg}
+	}
+	if analysis > details {
+		msg := fmt.Sprintf(msgImageViewAnalysisCount, analysis, details)
+		return &FieldError{FieldName: "ImageViewAnalysis", Value: strconv.Itoa(analysis), Msg: msg}
+	}
+	return nil
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the Electronic Exchange will be returned.
 func (cd *CheckDetail) fieldInclusion() error {
 	if cd.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: cd.recordType,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
 	if cd.PayorBankRoutingNumber == "" {
 		return &FieldError{FieldName: "PayorBankRoutingNumber",
 			Value: cd.PayorBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
-	if cd.PayorBankRoutingNumberField() == "00000000" {
+	if cd.isZeroRoutingNumber(cd.PayorBankRoutingNumber) {
 		return &FieldError{FieldName: "PayorBankRoutingNumber",
 			Value: cd.PayorBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
 	if cd.PayorBankCheckDigit == "" {
 		return &FieldError{FieldName: "PayorBankCheckDigit",
 			Value: cd.PayorBankCheckDigit,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
 	if cd.EceInstitutionItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "EceInstitutionItemSequenceNumber",
 			Value: cd.EceInstitutionItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
 	if cd.BOFDIndicator == "" {
 		return &FieldError{FieldName: "BOFDIndicator",
 			Value: cd.BOFDIndicator,
-			Msg:   msgFieldInclusion + ", did you use CheckDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetail()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -341,7 +398,23 @@ func (cd *CheckDetail) OnUsField() string {
 
 // ItemAmountField gets the ItemAmount right justified and zero padded
 func (cd *CheckDetail) ItemAmountField() string {
-	return cd.numericField(cd.ItemAmount, 10)
+	return cd.formatAmount(int64(cd.ItemAmount), 10)
+}
+
+// SetItemAmount sets ItemAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 10 digit field.
+func (cd *CheckDetail) SetItemAmount(cents int64) error {
+	amount, err := cd.amountFromCents("ItemAmount", cents, 10)
+	if err != nil {
+		return err
+	}
+	cd.ItemAmount = amount
+	return nil
+}
+
+// ItemAmountCents returns ItemAmount in cents
+func (cd *CheckDetail) ItemAmountCents() int64 {
+	return int64(cd.ItemAmount)
 }
 
 // EceInstitutionItemSequenceNumberField gets a string of the EceInstitutionItemSequenceNumber field
@@ -384,10 +457,20 @@ func (cd *CheckDetail) ArchiveTypeIndicatorField() string {
 	return cd.alphaField(cd.ArchiveTypeIndicator, 1)
 }
 
-// AddCheckDetailAddendumA appends an AddendumA to the CheckDetail
-func (cd *CheckDetail) AddCheckDetailAddendumA(cdAddendaA CheckDetailAddendumA) []CheckDetailAddendumA {
+// AddCheckDetailAddendumA appends an AddendumA to the CheckDetail. An AddendumA with a RecordNumber of 0 is given the
+// number following the highest RecordNumber of the CheckDetail's AddendumA records, and one with a RecordNumber
+// which is set keeps it. A FieldError is returned, and the AddendumA isn't added, if its RecordNumber is
+// already used.
+func (cd *CheckDetail) AddCheckDetailAddendumA(cdAddendaA CheckDetailAddendumA) error {
+	n, err := addendumRecordNumber(cdAddendaA.RecordNumber, cdAddendaA.RecordNumberField(), len(cd.CheckDetailAddendumA), func(i int) int {
+		return cd.CheckDetailAddendumA[i].RecordNumber
+	})
+	if err != nil {
+		return err
+	}
+	cdAddendaA.RecordNumber = n
 	cd.CheckDetailAddendumA = append(cd.CheckDetailAddendumA, cdAddendaA)
-	return cd.CheckDetailAddendumA
+	return nil
 }
 
 // GetCheckDetailAddendumA returns a slice of AddendumA for the CheckDetail
@@ -406,10 +489,20 @@ func (cd *CheckDetail) GetCheckDetailAddendumB() []CheckDetailAddendumB {
 	return cd.CheckDetailAddendumB
 }
 
-// AddCheckDetailAddendumC appends an AddendumC to the CheckDetail
-func (cd *CheckDetail) AddCheckDetailAddendumC(cdAddendaC CheckDetailAddendumC) []CheckDetailAddendumC {
+// AddCheckDetailAddendumC appends an AddendumC to the CheckDetail. An AddendumC with a RecordNumber of 0 is given the
+// number following the highest RecordNumber of the CheckDetail's AddendumC records, and one with a RecordNumber
+// which is set keeps it. A FieldError is returned, and the AddendumC isn't added, if its RecordNumber is
+// already used.
+func (cd *CheckDetail) AddCheckDetailAddendumC(cdAddendaC CheckDetailAddendumC) error {
+	n, err := addendumRecordNumber(cdAddendaC.RecordNumber, cdAddendaC.RecordNumberField(), len(cd.CheckDetailAddendumC), func(i int) int {
+		return cd.CheckDetailAddendumC[i].RecordNumber
+	})
+	if err != nil {
+		return err
+	}
+	cdAddendaC.RecordNumber = n
 	cd.CheckDetailAddendumC = append(cd.CheckDetailAddendumC, cdAddendaC)
-	return cd.CheckDetailAddendumC
+	return nil
 }
 
 // GetCheckDetailAddendumC returns a slice of AddendumC for the CheckDetail
@@ -417,6 +510,30 @@ func (cd *CheckDetail) GetCheckDetailAddendumC() []CheckDetailAddendumC {
 	return cd.CheckDetailAddendumC
 }
 
+// addendumRecordNumber returns the RecordNumber of an addendum added to an item which has count addenda of the
+// same type, whose RecordNumbers are returned by recordNumber. A RecordNumber of 0 becomes the number following the
+// highest RecordNumber in use, and a FieldError with value is returned if one which is set is already used. Addenda
+// with a RecordNumber of 0 aren't numbered and are ignored.
+func addendumRecordNumber(number int, value string, count int, recordNumber func(i int) int) (int, error) {
+	next := 1
+	for i := 0; i < count; i++ {
+		n := recordNumber(i)
+		if n == 0 {
+			continue
+		}
+		if n == number {
+			return 0, &FieldError{FieldName: "RecordNumber", Value: value, Msg: msgAddendumRecordNumber}
+		}
+		if n >= next {
+			next = n + 1
+		}
+	}
+	if number == 0 {
+		return next, nil
+	}
+	return number, nil
+}
+
 // AddImageViewDetail appends an ImageViewDetail to the CheckDetail
 func (cd *CheckDetail) AddImageViewDetail(ivDetail ImageViewDetail) []ImageViewDetail {
 	cd.ImageViewDetail = append(cd.ImageViewDetail, ivDetail)
@@ -456,3 +573,39 @@ func (cd *CheckDetail) SetEceInstitutionItemSequenceNumber(seq int) string {
 	cd.EceInstitutionItemSequenceNumber = itemSequence
 	return cd.EceInstitutionItemSequenceNumber
 }
+
+// renumber sets the CheckDetail sequence number and the addendum and image sequence numbers which mirror it
+func (cd *CheckDetail) renumber(seq int) {
+	old := normalizeSequenceNumber(cd.EceInstitutionItemSequenceNumber)
+	cd.SetEceInstitutionItemSequenceNumber(seq)
+	for i := range cd.CheckDetailAddendumA {
+		addendumA := &cd.CheckDetailAddendumA[i]
+		if normalizeSequenceNumber(addendumA.BOFDItemSequenceNumber) == old {
+			addendumA.SetBOFDItemSequenceNumber(seq)
+		}
+	}
+	for i := range cd.CheckDetailAddendumC {
+		addendumC := &cd.CheckDetailAddendumC[i]
+		if normalizeSequenceNumber(addendumC.EndorsingBankItemSequenceNumber) == old {
+			addendumC.SetEndorsingBankItemSequenceNumber(seq)
+		}
+	}
+	for i := range cd.ImageViewData {
+		ivData := &cd.ImageViewData[i]
+		if normalizeSequenceNumber(ivData.EceInstitutionItemSequenceNumber) == old {
+			ivData.EceInstitutionItemSequenceNumber = cd.EceInstitutionItemSequenceNumber
+		}
+	}
+}
+
+// copy returns a copy of the CheckDetail which shares no records or ImageData with it
+func (cd *CheckDetail) copy() *CheckDetail {
+	c := *cd
+	c.CheckDetailAddendumA = append([]CheckDetailAddendumA(nil), cd.CheckDetailAddendumA...)
+	c.CheckDetailAddendumB = append([]CheckDetailAddendumB(nil), cd.CheckDetailAddendumB...)
+	c.CheckDetailAddendumC = append([]CheckDetailAddendumC(nil), cd.CheckDetailAddendumC...)
+	c.ImageViewDetail = append([]ImageViewDetail(nil), cd.ImageViewDetail...)
+	c.ImageViewData = copyImageViewData(cd.ImageViewData)
+	c.ImageViewAnalysis = append([]ImageViewAnalysis(nil), cd.ImageViewAnalysis...)
+	return &c
+}
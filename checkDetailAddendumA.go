@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -79,6 +78,8 @@ type CheckDetailAddendumA struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -96,7 +97,12 @@ func (cdAddendumA *CheckDetailAddendumA) setRecordType() {
 	if cdAddendumA == nil {
 		return
 	}
-	cdAddendumA.recordType = "26"
+	cdAddendumA.recordType = RecordTypeCheckDetailAddendumA
+}
+
+// RecordType returns the record type of the CheckDetailAddendumA, which is RecordTypeCheckDetailAddendumA once set by NewCheckDetailAddendumA or Parse
+func (cdAddendumA *CheckDetailAddendumA) RecordType() string {
+	return cdAddendumA.recordType
 }
 
 // Parse takes the input record string and parses the CheckDetailAddendumA values
@@ -130,27 +136,30 @@ func (cdAddendumA *CheckDetailAddendumA) Parse(record string) {
 	// 77-77
 	cdAddendumA.UserField = cdAddendumA.parseStringField(record[76:77])
 	// 78-80
-	cdAddendumA.reserved = "   "
+	cdAddendumA.reserved = cdAddendumA.parseReservedField(record, 77, 80)
 }
 
 // String writes the CheckDetailAddendumA struct to a string.
 func (cdAddendumA *CheckDetailAddendumA) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(cdAddendumA.recordType)
-	buf.WriteString(cdAddendumA.RecordNumberField())
-	buf.WriteString(cdAddendumA.ReturnLocationRoutingNumberField())
-	buf.WriteString(cdAddendumA.BOFDEndorsementDateField())
-	buf.WriteString(cdAddendumA.BOFDItemSequenceNumberField())
-	buf.WriteString(cdAddendumA.BOFDAccountNumberField())
-	buf.WriteString(cdAddendumA.BOFDBranchCodeField())
-	buf.WriteString(cdAddendumA.PayeeNameField())
-	buf.WriteString(cdAddendumA.TruncationIndicatorField())
-	buf.WriteString(cdAddendumA.BOFDConversionIndicatorField())
-	buf.WriteString(cdAddendumA.BOFDCorrectionIndicatorField())
-	buf.WriteString(cdAddendumA.UserFieldField())
-	buf.WriteString(cdAddendumA.reservedField())
-	return buf.String()
+	return string(cdAddendumA.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the CheckDetailAddendumA record, as String returns it, to b and returns the extended buffer.
+func (cdAddendumA *CheckDetailAddendumA) AppendTo(b []byte) []byte {
+	b = append(b, cdAddendumA.recordType...)
+	b = cdAddendumA.appendNumericField(b, cdAddendumA.RecordNumber, 1)
+	b = cdAddendumA.appendStringField(b, cdAddendumA.ReturnLocationRoutingNumber, 9)
+	b = cdAddendumA.appendYYYYMMDDDate(b, cdAddendumA.BOFDEndorsementDate)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.BOFDItemSequenceNumber, 15)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.BOFDAccountNumber, 18)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.BOFDBranchCode, 5)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.PayeeName, 15)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.TruncationIndicator, 1)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.BOFDConversionIndicator, 1)
+	b = cdAddendumA.appendNumericField(b, cdAddendumA.BOFDCorrectionIndicator, 1)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.UserField, 1)
+	b = cdAddendumA.appendAlphaField(b, cdAddendumA.reserved, 3)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -159,9 +168,9 @@ func (cdAddendumA *CheckDetailAddendumA) Validate() error {
 	if err := cdAddendumA.fieldInclusion(); err != nil {
 		return err
 	}
-	if cdAddendumA.recordType != "26" {
+	if cdAddendumA.recordType != RecordTypeCheckDetailAddendumA {
 		msg := fmt.Sprintf(msgRecordType, 26)
-		return &FieldError{FieldName: "recordType", Value: cdAddendumA.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: cdAddendumA.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := cdAddendumA.isNumeric(cdAddendumA.ReturnLocationRoutingNumber); err != nil {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
@@ -201,6 +210,9 @@ func (cdAddendumA *CheckDetailAddendumA) Validate() error {
 	if err := cdAddendumA.isAlphanumericSpecial(cdAddendumA.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: cdAddendumA.UserField, Msg: err.Error()}
 	}
+	if err := cdAddendumA.isDateInRange(cdAddendumA.BOFDEndorsementDate); err != nil {
+		return &FieldError{FieldName: "BOFDEndorsementDate", Value: cdAddendumA.BOFDEndorsementDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -210,37 +222,37 @@ func (cdAddendumA *CheckDetailAddendumA) fieldInclusion() error {
 	if cdAddendumA.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: cdAddendumA.recordType,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumA.RecordNumber == 0 {
 		return &FieldError{FieldName: "RecordNumber",
 			Value: cdAddendumA.RecordNumberField(),
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumA.ReturnLocationRoutingNumber == "" {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
 			Value: cdAddendumA.ReturnLocationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
-	if cdAddendumA.ReturnLocationRoutingNumberField() == "000000000" {
+	if cdAddendumA.isZeroRoutingNumber(cdAddendumA.ReturnLocationRoutingNumber) {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
 			Value: cdAddendumA.ReturnLocationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumA.BOFDEndorsementDate.IsZero() {
 		return &FieldError{FieldName: "BOFDEndorsementDate",
 			Value: cdAddendumA.BOFDEndorsementDate.String(),
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumA.BOFDItemSequenceNumber == "               " {
 		return &FieldError{FieldName: "BOFDItemSequenceNumber",
 			Value: cdAddendumA.BOFDItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumA.TruncationIndicator == "" {
 		return &FieldError{FieldName: "TruncationIndicator",
 			Value: cdAddendumA.TruncationIndicator,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
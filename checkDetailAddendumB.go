@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -45,6 +44,8 @@ type CheckDetailAddendumB struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -62,7 +63,12 @@ func (cdAddendumB *CheckDetailAddendumB) setRecordType() {
 	if cdAddendumB == nil {
 		return
 	}
-	cdAddendumB.recordType = "27"
+	cdAddendumB.recordType = RecordTypeCheckDetailAddendumB
+}
+
+// RecordType returns the record type of the CheckDetailAddendumB, which is RecordTypeCheckDetailAddendumB once set by NewCheckDetailAddendumB or Parse
+func (cdAddendumB *CheckDetailAddendumB) RecordType() string {
+	return cdAddendumB.recordType
 }
 
 // Parse takes the input record string and parses the CheckDetailAddendumB values
@@ -92,23 +98,26 @@ func (cdAddendumB *CheckDetailAddendumB) Parse(record string) {
 	// 38+X - 41+X
 	cdAddendumB.UserField = cdAddendumB.parseStringField(record[37+imageRefLength : 41+imageRefLength])
 	// 42+X - 46+X
-	cdAddendumB.reserved = cdAddendumB.parseStringField(record[41+imageRefLength : 46+imageRefLength])
+	cdAddendumB.reserved = cdAddendumB.parseReservedField(record, 41+imageRefLength, 46+imageRefLength)
 }
 
 // String writes the CheckDetailAddendumB struct to a string.
 func (cdAddendumB *CheckDetailAddendumB) String() string {
-	var buf strings.Builder
-	buf.Grow(46)
-	buf.WriteString(cdAddendumB.recordType)
-	buf.WriteString(cdAddendumB.ImageReferenceKeyIndicatorField())
-	buf.WriteString(cdAddendumB.MicrofilmArchiveSequenceNumberField())
-	buf.WriteString(cdAddendumB.LengthImageReferenceKeyField())
-	buf.Grow(cdAddendumB.parseNumField(cdAddendumB.LengthImageReferenceKey))
-	buf.WriteString(cdAddendumB.ImageReferenceKeyField())
-	buf.WriteString(cdAddendumB.DescriptionField())
-	buf.WriteString(cdAddendumB.UserFieldField())
-	buf.WriteString(cdAddendumB.reservedField())
-	return buf.String()
+	size := 46 + cdAddendumB.parseNumField(cdAddendumB.LengthImageReferenceKey)
+	return string(cdAddendumB.AppendTo(make([]byte, 0, size)))
+}
+
+// AppendTo appends the CheckDetailAddendumB record, as String returns it, to b and returns the extended buffer.
+func (cdAddendumB *CheckDetailAddendumB) AppendTo(b []byte) []byte {
+	b = append(b, cdAddendumB.recordType...)
+	b = cdAddendumB.appendNumericField(b, cdAddendumB.ImageReferenceKeyIndicator, 1)
+	b = cdAddendumB.appendAlphaField(b, cdAddendumB.MicrofilmArchiveSequenceNumber, 15)
+	b = cdAddendumB.appendStringField(b, cdAddendumB.LengthImageReferenceKey, 4)
+	b = cdAddendumB.appendAlphaField(b, cdAddendumB.ImageReferenceKey, uint(cdAddendumB.parseNumField(cdAddendumB.LengthImageReferenceKey)))
+	b = cdAddendumB.appendAlphaField(b, cdAddendumB.Description, 15)
+	b = cdAddendumB.appendAlphaField(b, cdAddendumB.UserField, 4)
+	b = cdAddendumB.appendAlphaField(b, cdAddendumB.reserved, 5)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -117,9 +126,9 @@ func (cdAddendumB *CheckDetailAddendumB) Validate() error {
 	if err := cdAddendumB.fieldInclusion(); err != nil {
 		return err
 	}
-	if cdAddendumB.recordType != "27" {
+	if cdAddendumB.recordType != RecordTypeCheckDetailAddendumB {
 		msg := fmt.Sprintf(msgRecordType, 27)
-		return &FieldError{FieldName: "recordType", Value: cdAddendumB.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: cdAddendumB.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	// Mandatory
 	if err := cdAddendumB.isImageReferenceKeyIndicator(cdAddendumB.ImageReferenceKeyIndicator); err != nil {
@@ -144,12 +153,12 @@ func (cdAddendumB *CheckDetailAddendumB) fieldInclusion() error {
 	if cdAddendumB.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: cdAddendumB.recordType,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumB()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumB()?", Err: ErrFieldInclusion}
 	}
 	if cdAddendumB.MicrofilmArchiveSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "MicrofilmArchiveSequenceNumber",
 			Value: cdAddendumB.MicrofilmArchiveSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumB()?"}
+			Msg:   msgFieldInclusion + ", did you use CheckDetailAddendumB()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
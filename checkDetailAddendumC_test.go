@@ -250,6 +250,20 @@ func TestCDAddendumCBOFDReturnReason(t *testing.T) {
 	}
 }
 
+// TestCDAddendumCReturnReasonCode validates ReturnReason against the return reason codes
+func TestCDAddendumCReturnReasonCode(t *testing.T) {
+	cdAddendumC := mockCheckDetailAddendumC()
+	cdAddendumC.ReturnReason = "N"
+	if err := cdAddendumC.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	cdAddendumC.ReturnReason = "a"
+	err := cdAddendumC.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ReturnReason" || e.Value != "a" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestCDAddendumCUserField validation
 func TestCDAddendumCUserField(t *testing.T) {
 	cdAddendumC := mockCheckDetailAddendumC()
@@ -276,6 +290,38 @@ func TestCDAddendumCEndorsingBankIdentifier(t *testing.T) {
 	}
 }
 
+// TestCDAddendumCEndorsingBankType validates the typed EndorsingBankIdentifier
+func TestCDAddendumCEndorsingBankType(t *testing.T) {
+	cdAddendumC := mockCheckDetailAddendumC()
+	cdAddendumC.EndorsingBankIdentifier = 3
+	if err := cdAddendumC.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	bankType, err := cdAddendumC.EndorsingBankType()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if bankType != EndorsingBankPayor || bankType.String() != "Payor Bank" {
+		t.Errorf("unexpected EndorsingBankType: %v", bankType)
+	}
+
+	cdAddendumC.EndorsingBankIdentifier = 10
+	if err := cdAddendumC.Validate(); err != nil {
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "EndorsingBankIdentifier" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+	if _, err := cdAddendumC.EndorsingBankType(); err != nil {
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "EndorsingBankIdentifier" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+}
+
 // FieldInclusion
 
 // TestCDAddendumCFIRecordType validation
@@ -373,3 +373,109 @@ func TestCDFIBOFDIndicator(t *testing.T) {
 		}
 	}
 }
+
+// TestCheckDetailSetItemAmount validates setting ItemAmount from cents
+func TestCheckDetailSetItemAmount(t *testing.T) {
+	cd := mockCheckDetail()
+	if err := cd.SetItemAmount(12345); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if v := cd.ItemAmountField(); v != "0000012345" {
+		t.Errorf("ItemAmount Expected '0000012345' got: %v", v)
+	}
+	if v := cd.ItemAmountCents(); v != 12345 {
+		t.Errorf("ItemAmountCents Expected 12345 got: %v", v)
+	}
+
+	line := cd.String()
+	read := new(CheckDetail)
+	read.Parse(line)
+	if v := read.ItemAmountCents(); v != 12345 {
+		t.Errorf("ItemAmountCents Expected 12345 got: %v", v)
+	}
+
+	for _, cents := range []int64{-1, 10000000000} {
+		err := cd.SetItemAmount(cents)
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "ItemAmount" {
+			t.Errorf("%d: %T: %s", cents, err, err)
+		}
+	}
+	if v := cd.ItemAmountCents(); v != 12345 {
+		t.Errorf("ItemAmount changed by an invalid amount: %v", v)
+	}
+	if err := cd.SetItemAmount(9999999999); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestCheckDetailMICRLine assembles the MICR line of a CheckDetail
+func TestCheckDetailMICRLine(t *testing.T) {
+	cd := mockCheckDetail()
+	if line := cd.MICRLine(); line != "⑈123456789⑈ ⑆031300012⑆ 5558881⑈ ⑇0000100000⑇" {
+		t.Errorf("MICRLine=%q", line)
+	}
+
+	cd.AuxiliaryOnUs = ""
+	cd.ExternalProcessingCode = "5"
+	cd.OnUs = "12-345/6789/"
+	if line := cd.MICRLine(); line != "5 ⑆031300012⑆ 12⑉345⑈6789⑈ ⑇0000100000⑇" {
+		t.Errorf("MICRLine=%q", line)
+	}
+
+	cd.ExternalProcessingCode = ""
+	cd.OnUs = ""
+	cd.ItemAmount = 0
+	if line := cd.MICRLine(); line != "⑆031300012⑆ ⑇0000000000⑇" {
+		t.Errorf("MICRLine=%q", line)
+	}
+}
+
+// TestCheckDetailAddAddendumRecordNumber validates addenda are numbered when added and duplicates are rejected
+func TestCheckDetailAddAddendumRecordNumber(t *testing.T) {
+	cd := mockCheckDetail()
+	for i := 0; i < 3; i++ {
+		cdAddendumA := mockCheckDetailAddendumA()
+		cdAddendumA.RecordNumber = 0
+		if err := cd.AddCheckDetailAddendumA(cdAddendumA); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+	}
+	for i, cdAddendumA := range cd.CheckDetailAddendumA {
+		if cdAddendumA.RecordNumber != i+1 {
+			t.Errorf("CheckDetailAddendumA %d: RecordNumber %d", i, cdAddendumA.RecordNumber)
+		}
+	}
+
+	// an explicit RecordNumber is kept, and numbering continues from the highest
+	cdAddendumC := mockCheckDetailAddendumC()
+	cdAddendumC.RecordNumber = 5
+	if err := cd.AddCheckDetailAddendumC(cdAddendumC); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	cdAddendumC.RecordNumber = 0
+	if err := cd.AddCheckDetailAddendumC(cdAddendumC); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := cd.CheckDetailAddendumC[1].RecordNumber; n != 6 {
+		t.Errorf("CheckDetailAddendumC RecordNumber %d, expected 6", n)
+	}
+
+	cdAddendumA := mockCheckDetailAddendumA()
+	cdAddendumA.RecordNumber = 2
+	err := cd.AddCheckDetailAddendumA(cdAddendumA)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "RecordNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(cd.CheckDetailAddendumA) != 3 {
+		t.Errorf("duplicate CheckDetailAddendumA was added")
+	}
+
+	// an addendum appended directly without a RecordNumber doesn't collide with the next one added
+	cd.CheckDetailAddendumA = append(cd.CheckDetailAddendumA, CheckDetailAddendumA{})
+	if err := cd.AddCheckDetailAddendumA(CheckDetailAddendumA{}); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := cd.CheckDetailAddendumA[4].RecordNumber; n != 4 {
+		t.Errorf("CheckDetailAddendumA RecordNumber %d, expected 4", n)
+	}
+}
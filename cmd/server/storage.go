@@ -6,6 +6,7 @@ package main
 
 import (
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/moov-io/imagecashletter"
@@ -33,6 +34,8 @@ func (r *memoryICLFileRepository) getFiles() ([]*imagecashletter.File, error) {
 		f := *v
 		out = append(out, &f)
 	}
+	// map iteration is random, so list files by ID for stable responses
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
 	return out, nil
 }
 
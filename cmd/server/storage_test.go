@@ -83,3 +83,25 @@ func TestMemoryStorage(t *testing.T) {
 		t.Errorf("files=%#v error=%v", files, err)
 	}
 }
+
+func TestMemoryStorage__getFilesOrder(t *testing.T) {
+	repo := &memoryICLFileRepository{
+		files: make(map[string]*imagecashletter.File),
+	}
+	for _, id := range []string{"c", "a", "d", "b"} {
+		if err := repo.saveFile(&imagecashletter.File{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := repo.getFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids string
+	for i := range files {
+		ids += files[i].ID
+	}
+	if ids != "abcd" {
+		t.Errorf("files listed as %s", ids)
+	}
+}
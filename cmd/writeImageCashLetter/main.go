@@ -228,9 +228,13 @@ func write(path string) {
 					ivAnalysis.TransitEndorsementUsability = 2
 
 					// Add CheckDetailAddendum* to CheckDetail
-					cd.AddCheckDetailAddendumA(cdAddendumA)
+					if err := cd.AddCheckDetailAddendumA(cdAddendumA); err != nil {
+						log.Fatal(err)
+					}
 					cd.AddCheckDetailAddendumB(cdAddendumB)
-					cd.AddCheckDetailAddendumC(cdAddendumC)
+					if err := cd.AddCheckDetailAddendumC(cdAddendumC); err != nil {
+						log.Fatal(err)
+					}
 
 					// Add ImageView* to CheckDetail
 					cd.AddImageViewDetail(ivDetail)
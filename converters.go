@@ -5,13 +5,65 @@
 package imagecashletter
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// Errors specific to parsing and setting amounts
+var (
+	msgAmountNegative = "is a negative amount"
+	msgAmountWidth    = "does not fit in %d digits"
+	msgAmountNumeric  = "is not a numeric amount"
+)
+
+// maxAmountDigits is the most digits an amount can have and fit in an int64
+const maxAmountDigits = 18
+
 // converters handles golang to imagecashletter type Converters
-type converters struct{}
+type converters struct {
+	// alphaFill and numericFill are the fill characters of alphanumeric and numeric fields, or 0 for the
+	// blank and zero fill of the standard. They are only set by Writer on a copy of each record it writes
+	// with WriteFillOption.
+	alphaFill   byte
+	numericFill byte
+}
+
+// setFill sets the fill characters of alphanumeric and numeric fields
+func (c *converters) setFill(alphaFill, numericFill byte) {
+	c.alphaFill = alphaFill
+	c.numericFill = numericFill
+}
+
+// fieldBufferSize is the size of the buffer a field is formatted in before it's returned as a string,
+// which holds every fixed width field without allocating
+const fieldBufferSize = 64
+
+// appendAlphaPad appends n fill characters for an alphanumeric field, which are blanks by default
+func (c *converters) appendAlphaPad(b []byte, n int) []byte {
+	if c.alphaFill == 0 {
+		return appendFill(b, ' ', n)
+	}
+	return appendFill(b, c.alphaFill, n)
+}
+
+// appendNumericPad appends n fill characters for a numeric field, which are zeros by default
+func (c *converters) appendNumericPad(b []byte, n int) []byte {
+	if c.numericFill == 0 {
+		return appendFill(b, '0', n)
+	}
+	return appendFill(b, c.numericFill, n)
+}
+
+// appendFill appends n copies of fill to b
+func appendFill(b []byte, fill byte, n int) []byte {
+	for ; n > 0; n-- {
+		b = append(b, fill)
+	}
+	return b
+}
 
 func (c *converters) parseNumField(r string) (s int) {
 	s, _ = strconv.Atoi(strings.TrimSpace(r))
@@ -23,6 +75,15 @@ func (c *converters) parseStringField(r string) (s string) {
 	return s
 }
 
+// parseReservedField returns the unmodified contents of a reserved field so any data a sender placed in
+// it is written back by String, or blanks if the record ends before the field.
+func (c *converters) parseReservedField(record string, start, end int) string {
+	if len(record) < end {
+		return strings.Repeat(" ", end-start)
+	}
+	return record[start:end]
+}
+
 // stringToBytesField
 func (c *converters) stringToBytesField(r string) (b []byte) {
 	b = []byte(r)
@@ -35,7 +96,13 @@ func (c *converters) formatYYYYMMDDDate(t time.Time) string {
 	return t.Format("20060102")
 }
 
-// parseYYYMMDDDate returns a time.Time when passed time as YYYYMMDD
+// appendYYYYMMDDDate appends t to b as YYYYMMDD, as formatYYYYMMDDDate returns it
+func (c *converters) appendYYYYMMDDDate(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, "20060102")
+}
+
+// parseYYYMMDDDate returns a time.Time when passed time as YYYYMMDD, or the zero time when s isn't a valid
+// date. Reader reports invalid dates with validator.isYYYYMMDDDate before records are parsed.
 func (c *converters) parseYYYYMMDDDate(s string) time.Time {
 	t, _ := time.Parse("20060102", s)
 	return t
@@ -46,50 +113,164 @@ func (c *converters) formatSimpleTime(t time.Time) string {
 	return t.Format("1504")
 }
 
+// appendSimpleTime appends t to b as HHMM, as formatSimpleTime returns it
+func (c *converters) appendSimpleTime(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, "1504")
+}
+
 // parseSimpleTime returns a time.Time when passed a string of HHMM
 func (c *converters) parseSimpleTime(s string) time.Time {
 	t, _ := time.Parse("1504", s)
 	return t
 }
 
+// parseAmount returns the cents of an amount field, which holds digits with two implied decimal places.
+// Leading and trailing blanks are ignored and a blank field is zero. As every X9 amount field is unsigned,
+// a leading sign is rejected as isNumeric rejects it.
+func (c *converters) parseAmount(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if len(s) > maxAmountDigits {
+		return 0, fmt.Errorf(msgAmountWidth, maxAmountDigits)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, errors.New(msgAmountNumeric)
+		}
+	}
+	cents, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.New(msgAmountNumeric)
+	}
+	return cents, nil
+}
+
+// formatAmount returns cents right-justified and zero filled in width digits. As with numericField
+// the most significant digits of an amount wider than width are dropped, which Validate and
+// WriteStrictFieldWidthsOption guard against. A negative amount is written with a leading minus sign.
+func (c *converters) formatAmount(cents int64, width int) string {
+	var buf [fieldBufferSize]byte
+	return string(c.appendAmount(buf[:0], cents, width))
+}
+
+// appendAmount appends cents to b as formatAmount returns it
+func (c *converters) appendAmount(b []byte, cents int64, width int) []byte {
+	if cents < 0 {
+		b = append(b, '-')
+		width--
+	}
+	var digits [20]byte
+	s := strconv.AppendInt(digits[:0], cents, 10)
+	if cents < 0 {
+		s = s[1:]
+	}
+	if width < 0 {
+		width = 0
+	}
+	if len(s) > width {
+		return append(b, s[len(s)-width:]...)
+	}
+	b = c.appendNumericPad(b, width-len(s))
+	return append(b, s...)
+}
+
+// amountFromCents returns cents as the int stored in an amount field of width digits, or a FieldError
+// if cents is negative or has more than width digits.
+func (c *converters) amountFromCents(fieldName string, cents int64, width int) (int, error) {
+	value := strconv.FormatInt(cents, 10)
+	if cents < 0 {
+		return 0, &FieldError{FieldName: fieldName, Value: value, Msg: msgAmountNegative}
+	}
+	if len(value) > width || int64(int(cents)) != cents {
+		return 0, &FieldError{FieldName: fieldName, Value: value, Msg: fmt.Sprintf(msgAmountWidth, width)}
+	}
+	return int(cents), nil
+}
+
 // alphaField Alphanumeric and Alphabetic fields are left-justified and space filled.
 func (c *converters) alphaField(s string, max uint) string {
+	if uint(len(s)) >= max {
+		return s[:max]
+	}
+	var buf [fieldBufferSize]byte
+	return string(c.appendAlphaField(buf[:0], s, max))
+}
+
+// appendAlphaField appends s to b as alphaField returns it
+func (c *converters) appendAlphaField(b []byte, s string, max uint) []byte {
 	ln := uint(len(s))
 	if ln > max {
-		return s[:max]
+		return append(b, s[:max]...)
 	}
-	s += strings.Repeat(" ", int(max-ln))
-	return s
+	b = append(b, s...)
+	return c.appendAlphaPad(b, int(max-ln))
+}
+
+// appendBytesField appends data to b as alphaField returns string(data), without converting data
+func (c *converters) appendBytesField(b []byte, data []byte, max uint) []byte {
+	ln := uint(len(data))
+	if ln > max {
+		return append(b, data[:max]...)
+	}
+	b = append(b, data...)
+	return c.appendAlphaPad(b, int(max-ln))
 }
 
 // numericField right-justified, unsigned, and zero filled
 func (c *converters) numericField(n int, max uint) string {
-	s := strconv.Itoa(n)
+	var buf [fieldBufferSize]byte
+	return string(c.appendNumericField(buf[:0], n, max))
+}
+
+// appendNumericField appends n to b as numericField returns it
+func (c *converters) appendNumericField(b []byte, n int, max uint) []byte {
+	var digits [20]byte
+	s := strconv.AppendInt(digits[:0], int64(n), 10)
 	ln := uint(len(s))
 	if ln > max {
-		return s[ln-max:]
+		return append(b, s[ln-max:]...)
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	b = c.appendNumericPad(b, int(max-ln))
+	return append(b, s...)
 }
 
 // nbsmField is a numeric-blank/special MICR (NBSM) or numeric-blank/special MICR On-Us (NBSMOS)
 // which are right-justified and blank filled
 func (c *converters) nbsmField(s string, max uint) string {
+	if uint(len(s)) >= max {
+		return s[uint(len(s))-max:]
+	}
+	var buf [fieldBufferSize]byte
+	return string(c.appendNBSMField(buf[:0], s, max))
+}
+
+// appendNBSMField appends s to b as nbsmField returns it
+func (c *converters) appendNBSMField(b []byte, s string, max uint) []byte {
 	ln := uint(len(s))
 	if ln > max {
-		return s[ln-max:]
+		return append(b, s[ln-max:]...)
 	}
-	s = strings.Repeat(" ", int(max-ln)) + s
-	return s
+	b = appendFill(b, ' ', int(max-ln))
+	return append(b, s...)
 }
 
 // stringField slices to max length and zero filled
 func (c *converters) stringField(s string, max uint) string {
+	if uint(len(s)) >= max {
+		return s[:max]
+	}
+	var buf [fieldBufferSize]byte
+	return string(c.appendStringField(buf[:0], s, max))
+}
+
+// appendStringField appends s to b as stringField returns it
+func (c *converters) appendStringField(b []byte, s string, max uint) []byte {
 	ln := uint(len(s))
 	if ln > max {
-		return s[:max]
+		return append(b, s[:max]...)
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	b = c.appendNumericPad(b, int(max-ln))
+	return append(b, s...)
 }
@@ -0,0 +1,124 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"testing"
+)
+
+// TestParseAmount validates parsing amount fields into cents
+func TestParseAmount(t *testing.T) {
+	var c converters
+	tests := []struct {
+		value string
+		cents int64
+		err   bool
+	}{
+		{"0000000000", 0, false},
+		{"0000012345", 12345, false},
+		{"          ", 0, false},
+		{"  12345   ", 12345, false},
+		{"9999999999999999", 9999999999999999, false},
+		{"999999999999999999", 999999999999999999, false},
+		{"-0000012345", 0, true},
+		{"1234567890123456789", 0, true},
+		{"00000123.45", 0, true},
+		{"0000 12345", 0, true},
+		{"12345ABCDE", 0, true},
+		{"+000012345", 0, true},
+		{"-", 0, true},
+	}
+	for _, test := range tests {
+		cents, err := c.parseAmount(test.value)
+		if test.err != (err != nil) {
+			t.Errorf("%q: unexpected error %v", test.value, err)
+		}
+		if cents != test.cents {
+			t.Errorf("%q: expected %d got %d", test.value, test.cents, cents)
+		}
+	}
+}
+
+// TestFormatAmount validates formatting cents into amount fields
+func TestFormatAmount(t *testing.T) {
+	var c converters
+	tests := []struct {
+		cents int64
+		width int
+		value string
+	}{
+		{0, 10, "0000000000"},
+		{12345, 10, "0000012345"},
+		{9999999999, 10, "9999999999"},
+		{12345678901, 10, "2345678901"},
+		{9999999999999999, 16, "9999999999999999"},
+		{-12345, 10, "-000012345"},
+	}
+	for _, test := range tests {
+		if v := c.formatAmount(test.cents, test.width); v != test.value {
+			t.Errorf("%d: expected %q got %q", test.cents, test.value, v)
+		}
+		if len(test.value) == test.width && test.cents >= 0 && test.cents < 10000000000 {
+			if cents, err := c.parseAmount(test.value); err != nil || cents != test.cents {
+				t.Errorf("%q: round trip got %d %v", test.value, cents, err)
+			}
+		}
+	}
+}
+
+// TestFieldFill validates alphanumeric fields are blank filled and numeric fields are zero filled unless
+// other fill characters are set
+func TestFieldFill(t *testing.T) {
+	var c converters
+	check := func(name, value, expected string) {
+		t.Helper()
+		if value != expected {
+			t.Errorf("%s: expected %q got %q", name, expected, value)
+		}
+	}
+	check("alphaField", c.alphaField("AB", 5), "AB   ")
+	check("alphaField", c.alphaField("ABCDEF", 5), "ABCDE")
+	check("numericField", c.numericField(12, 5), "00012")
+	check("stringField", c.stringField("12", 4), "0012")
+	check("formatAmount", c.formatAmount(12345, 10), "0000012345")
+	check("nbsmField", c.nbsmField("12", 4), "  12")
+
+	c.setFill('*', ' ')
+	check("alphaField", c.alphaField("AB", 5), "AB***")
+	check("numericField", c.numericField(12, 5), "   12")
+	check("stringField", c.stringField("12", 4), "  12")
+	check("formatAmount", c.formatAmount(12345, 10), "     12345")
+	check("nbsmField", c.nbsmField("12", 4), "  12")
+
+	c.setFill(0, 0)
+	check("alphaField", c.alphaField("AB", 5), "AB   ")
+	check("numericField", c.numericField(12, 5), "00012")
+}
+
+// TestAppendFields validates the append helpers extend the buffer with the field as the string helpers
+// format it
+func TestAppendFields(t *testing.T) {
+	var c converters
+	check := func(name string, value []byte, expected string) {
+		t.Helper()
+		if string(value) != "01"+expected {
+			t.Errorf("%s: expected %q got %q", name, "01"+expected, value)
+		}
+	}
+	prefix := func() []byte { return []byte("01") }
+	check("appendAlphaField", c.appendAlphaField(prefix(), "AB", 5), c.alphaField("AB", 5))
+	check("appendAlphaField", c.appendAlphaField(prefix(), "ABCDEF", 5), c.alphaField("ABCDEF", 5))
+	check("appendNumericField", c.appendNumericField(prefix(), 12, 5), c.numericField(12, 5))
+	check("appendNumericField", c.appendNumericField(prefix(), 123456, 5), c.numericField(123456, 5))
+	check("appendStringField", c.appendStringField(prefix(), "12", 4), c.stringField("12", 4))
+	check("appendNBSMField", c.appendNBSMField(prefix(), "12", 4), c.nbsmField("12", 4))
+	check("appendAmount", c.appendAmount(prefix(), -12345, 10), c.formatAmount(-12345, 10))
+	check("appendBytesField", c.appendBytesField(prefix(), []byte("AB"), 4), "AB  ")
+	check("appendBytesField", c.appendBytesField(prefix(), []byte("ABCDEF"), 4), "ABCD")
+
+	c.setFill('*', ' ')
+	check("appendAlphaField", c.appendAlphaField(prefix(), "AB", 5), "AB***")
+	check("appendNumericField", c.appendNumericField(prefix(), 12, 5), "   12")
+}
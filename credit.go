@@ -0,0 +1,283 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Credit Record (Type 61) as defined by X9.100-187-2016, which is distinct from the CreditItem Record (Type 62).
+// Credit(s) are read and written with CreditItem(s), following the CashLetterHeader and preceding the first
+// BundleHeader of a Cash Letter.
+type Credit struct {
+	// ID is a client defined string used as a reference to this record.
+	ID string `json:"id"`
+	// RecordType defines the type of record.
+	recordType string
+	// AuxiliaryOnUs identifies a code used at the discretion of the creditor bank.
+	AuxiliaryOnUs string `json:"auxiliaryOnUs"`
+	// ExternalProcessingCode identifies a code used for special purposes as authorized by the Accredited
+	// Standards Committee X9. Also known as Position 44.
+	ExternalProcessingCode string `json:"externalProcessingCode"`
+	// PayorBankRoutingNumber is the routing number of the institution receiving the credit.
+	// Format: TTTTAAAAC, where:
+	// TTTT: Federal Reserve Prefix
+	// AAAA: ABA Institution Identifier
+	// C: Check Digit
+	PayorBankRoutingNumber string `json:"payorBankRoutingNumber"`
+	// CreditAccountNumberOnUs identifies the account being credited and may include a serial number or
+	// transaction code.
+	CreditAccountNumberOnUs string `json:"creditAccountNumberOnUs"`
+	// ItemAmount identifies the amount of the credit.  All amounts fields have two implied decimal points.
+	// e.g., 100000 is $1,000.00
+	ItemAmount int `json:"itemAmount"`
+	// EceInstitutionItemSequenceNumber identifies a number assigned by the institution that creates the Credit.
+	// Field must contain a numeric value. It cannot be all blanks.
+	EceInstitutionItemSequenceNumber string `json:"eceInstitutionItemSequenceNumber"`
+	// DocumentationTypeIndicator identifies a code that indicates the type of documentation that supports the
+	// credit. See CheckDetail.DocumentationTypeIndicator for values.
+	DocumentationTypeIndicator string `json:"documentationTypeIndicator"`
+	// AccountTypeCode is a code that indicates the type of account to which this Credit is associated.
+	// See CreditItem.AccountTypeCode for values.
+	AccountTypeCode string `json:"accountTypeCode"`
+	// SourceWorkCode is a code used to identify the source of the work associated with this Credit.
+	// See CreditItem.SourceWorkCode for values.
+	SourceWorkCode string `json:"sourceWorkCode"`
+	// WorkType is a code used at the discretion of the exchange partners to identify the type of work.
+	WorkType string `json:"workType"`
+	// DebitCreditIndicator is a code used at the discretion of the exchange partners to identify whether
+	// the record is a debit or a credit.
+	DebitCreditIndicator string `json:"debitCreditIndicator"`
+	// reserved is a field reserved for future use.  Reserved should be blank.
+	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
+	// validator is composed for imagecashletter data validation
+	validator
+	// converters is composed for imagecashletter to golang Converters
+	converters
+}
+
+// NewCredit returns a new Credit with default values for non exported fields
+func NewCredit() *Credit {
+	cr := &Credit{}
+	cr.setRecordType()
+	return cr
+}
+
+func (cr *Credit) setRecordType() {
+	if cr == nil {
+		return
+	}
+	cr.recordType = RecordTypeCredit
+	cr.reserved = "  "
+}
+
+// RecordType returns the record type of the Credit, which is RecordTypeCredit once set by NewCredit or Parse
+func (cr *Credit) RecordType() string {
+	return cr.recordType
+}
+
+// Parse takes the input record string and parses the Credit values
+func (cr *Credit) Parse(record string) {
+	if utf8.RuneCountInString(record) < 80 {
+		return // line is too short
+	}
+	// Character position 1-2, Always "61"
+	cr.setRecordType()
+	// 03-17
+	cr.AuxiliaryOnUs = cr.parseStringField(record[2:17])
+	// 18-18
+	cr.ExternalProcessingCode = cr.parseStringField(record[17:18])
+	// 19-27
+	cr.PayorBankRoutingNumber = cr.parseStringField(record[18:27])
+	// 28-47
+	cr.CreditAccountNumberOnUs = cr.parseStringField(record[27:47])
+	// 48-57
+	itemAmount, _ := cr.parseAmount(record[47:57])
+	cr.ItemAmount = int(itemAmount)
+	// 58-72
+	cr.EceInstitutionItemSequenceNumber = cr.parseStringField(record[57:72])
+	// 73-73
+	cr.DocumentationTypeIndicator = cr.parseStringField(record[72:73])
+	// 74-74
+	cr.AccountTypeCode = cr.parseStringField(record[73:74])
+	// 75-76
+	cr.SourceWorkCode = cr.parseStringField(record[74:76])
+	// 77-77
+	cr.WorkType = cr.parseStringField(record[76:77])
+	// 78-78
+	cr.DebitCreditIndicator = cr.parseStringField(record[77:78])
+	// 79-80
+	cr.reserved = cr.parseReservedField(record, 78, 80)
+}
+
+// String writes the Credit struct to a string.
+func (cr *Credit) String() string {
+	return string(cr.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the Credit record, as String returns it, to b and returns the extended buffer.
+func (cr *Credit) AppendTo(b []byte) []byte {
+	b = append(b, cr.recordType...)
+	b = cr.appendNBSMField(b, cr.AuxiliaryOnUs, 15)
+	b = cr.appendAlphaField(b, cr.ExternalProcessingCode, 1)
+	b = cr.appendStringField(b, cr.PayorBankRoutingNumber, 9)
+	b = cr.appendNBSMField(b, cr.CreditAccountNumberOnUs, 20)
+	b = cr.appendAmount(b, int64(cr.ItemAmount), 10)
+	b = cr.appendAlphaField(b, cr.EceInstitutionItemSequenceNumber, 15)
+	b = cr.appendAlphaField(b, cr.DocumentationTypeIndicator, 1)
+	b = cr.appendAlphaField(b, cr.AccountTypeCode, 1)
+	b = cr.appendAlphaField(b, cr.SourceWorkCode, 2)
+	b = cr.appendAlphaField(b, cr.WorkType, 1)
+	b = cr.appendAlphaField(b, cr.DebitCreditIndicator, 1)
+	b = cr.appendAlphaField(b, cr.reserved, 2)
+	return b
+}
+
+// Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
+// The first error encountered is returned and stops the parsing.
+func (cr *Credit) Validate() error {
+	if err := cr.fieldInclusion(); err != nil {
+		return err
+	}
+	if cr.recordType != RecordTypeCredit {
+		msg := fmt.Sprintf(msgRecordType, 61)
+		return &FieldError{FieldName: "recordType", Value: cr.recordType, Msg: msg, Err: ErrRecordType}
+	}
+	if err := cr.isNumeric(cr.PayorBankRoutingNumber); err != nil {
+		return &FieldError{FieldName: "PayorBankRoutingNumber", Value: cr.PayorBankRoutingNumber, Msg: err.Error()}
+	}
+	if cr.DocumentationTypeIndicator != "" {
+		// Z is valid for CashLetter DocumentationTypeIndicator only
+		if cr.DocumentationTypeIndicator == "Z" {
+			msg := fmt.Sprint(msgDocumentationTypeIndicator)
+			return &FieldError{FieldName: "DocumentationTypeIndicator", Value: cr.DocumentationTypeIndicator, Msg: msg}
+		}
+		if err := cr.isDocumentationTypeIndicator(cr.DocumentationTypeIndicator); err != nil {
+			return &FieldError{FieldName: "DocumentationTypeIndicator", Value: cr.DocumentationTypeIndicator, Msg: err.Error()}
+		}
+	}
+	if cr.AccountTypeCode != "" {
+		if err := cr.isAccountTypeCode(cr.AccountTypeCode); err != nil {
+			return &FieldError{FieldName: "AccountTypeCode", Value: cr.AccountTypeCode, Msg: err.Error()}
+		}
+	}
+	if cr.SourceWorkCode != "" {
+		if err := cr.isSourceWorkCode(cr.SourceWorkCode); err != nil {
+			return &FieldError{FieldName: "SourceWorkCode", Value: cr.SourceWorkCode, Msg: err.Error()}
+		}
+	}
+	if err := cr.isAlphanumeric(cr.WorkType); err != nil {
+		return &FieldError{FieldName: "WorkType", Value: cr.WorkType, Msg: err.Error()}
+	}
+	if err := cr.isAlphanumeric(cr.DebitCreditIndicator); err != nil {
+		return &FieldError{FieldName: "DebitCreditIndicator", Value: cr.DebitCreditIndicator, Msg: err.Error()}
+	}
+	return nil
+}
+
+// fieldInclusion validate mandatory fields are not default values. If fields are
+// invalid the Electronic Exchange will be returned.
+func (cr *Credit) fieldInclusion() error {
+	if cr.recordType == "" {
+		return &FieldError{FieldName: "recordType",
+			Value: cr.recordType,
+			Msg:   msgFieldInclusion + ", did you use Credit()?", Err: ErrFieldInclusion}
+	}
+	if cr.PayorBankRoutingNumber == "" {
+		return &FieldError{FieldName: "PayorBankRoutingNumber",
+			Value: cr.PayorBankRoutingNumber,
+			Msg:   msgFieldInclusion + ", did you use Credit()?", Err: ErrFieldInclusion}
+	}
+	if cr.isZeroRoutingNumber(cr.PayorBankRoutingNumber) {
+		return &FieldError{FieldName: "PayorBankRoutingNumber",
+			Value: cr.PayorBankRoutingNumber,
+			Msg:   msgFieldInclusion + ", did you use Credit()?", Err: ErrFieldInclusion}
+	}
+	if cr.EceInstitutionItemSequenceNumberField() == "               " {
+		return &FieldError{FieldName: "EceInstitutionItemSequenceNumber",
+			Value: cr.EceInstitutionItemSequenceNumber,
+			Msg:   msgFieldInclusion + ", did you use Credit()?", Err: ErrFieldInclusion}
+	}
+	return nil
+}
+
+// AuxiliaryOnUsField gets the AuxiliaryOnUs field
+func (cr *Credit) AuxiliaryOnUsField() string {
+	return cr.nbsmField(cr.AuxiliaryOnUs, 15)
+}
+
+// ExternalProcessingCodeField gets the ExternalProcessingCode field
+func (cr *Credit) ExternalProcessingCodeField() string {
+	return cr.alphaField(cr.ExternalProcessingCode, 1)
+}
+
+// PayorBankRoutingNumberField gets the PayorBankRoutingNumber field
+func (cr *Credit) PayorBankRoutingNumberField() string {
+	return cr.stringField(cr.PayorBankRoutingNumber, 9)
+}
+
+// CreditAccountNumberOnUsField gets the CreditAccountNumberOnUs field
+func (cr *Credit) CreditAccountNumberOnUsField() string {
+	return cr.nbsmField(cr.CreditAccountNumberOnUs, 20)
+}
+
+// ItemAmountField gets the ItemAmount field
+func (cr *Credit) ItemAmountField() string {
+	return cr.formatAmount(int64(cr.ItemAmount), 10)
+}
+
+// SetItemAmount sets ItemAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 10 digit field.
+func (cr *Credit) SetItemAmount(cents int64) error {
+	amount, err := cr.amountFromCents("ItemAmount", cents, 10)
+	if err != nil {
+		return err
+	}
+	cr.ItemAmount = amount
+	return nil
+}
+
+// ItemAmountCents returns ItemAmount in cents
+func (cr *Credit) ItemAmountCents() int64 {
+	return int64(cr.ItemAmount)
+}
+
+// EceInstitutionItemSequenceNumberField gets the EceInstitutionItemSequenceNumber field
+func (cr *Credit) EceInstitutionItemSequenceNumberField() string {
+	return cr.alphaField(cr.EceInstitutionItemSequenceNumber, 15)
+}
+
+// DocumentationTypeIndicatorField gets the DocumentationTypeIndicator field
+func (cr *Credit) DocumentationTypeIndicatorField() string {
+	return cr.alphaField(cr.DocumentationTypeIndicator, 1)
+}
+
+// AccountTypeCodeField gets the AccountTypeCode field
+func (cr *Credit) AccountTypeCodeField() string {
+	return cr.alphaField(cr.AccountTypeCode, 1)
+}
+
+// SourceWorkCodeField gets the SourceWorkCode field
+func (cr *Credit) SourceWorkCodeField() string {
+	return cr.alphaField(cr.SourceWorkCode, 2)
+}
+
+// WorkTypeField gets the WorkType field
+func (cr *Credit) WorkTypeField() string {
+	return cr.alphaField(cr.WorkType, 1)
+}
+
+// DebitCreditIndicatorField gets the DebitCreditIndicator field
+func (cr *Credit) DebitCreditIndicatorField() string {
+	return cr.alphaField(cr.DebitCreditIndicator, 1)
+}
+
+// reservedField gets reserved - blank space
+func (cr *Credit) reservedField() string {
+	return cr.alphaField(cr.reserved, 2)
+}
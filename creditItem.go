@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -110,6 +109,8 @@ type CreditItem struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	validator
 	// converters is composed for imagecashletter to golang Converters
 	converters
@@ -126,7 +127,12 @@ func (ci *CreditItem) setRecordType() {
 	if ci == nil {
 		return
 	}
-	ci.recordType = "62"
+	ci.recordType = RecordTypeCreditItem
+}
+
+// RecordType returns the record type of the CreditItem, which is RecordTypeCreditItem once set by NewCreditItem or Parse
+func (ci *CreditItem) RecordType() string {
+	return ci.recordType
 }
 
 // Parse takes the input record string and parses the CreditItem values
@@ -145,7 +151,8 @@ func (ci *CreditItem) Parse(record string) {
 	// 28-47
 	ci.OnUs = ci.parseStringField(record[27:47])
 	// 48-61
-	ci.ItemAmount = ci.parseNumField(record[47:61])
+	itemAmount, _ := ci.parseAmount(record[47:61])
+	ci.ItemAmount = int(itemAmount)
 	// 62-76
 	ci.CreditItemSequenceNumber = ci.parseStringField(record[61:76])
 	// 77-77
@@ -157,26 +164,29 @@ func (ci *CreditItem) Parse(record string) {
 	// 81-96
 	ci.UserField = ci.parseStringField(record[80:96])
 	// 97-100
-	ci.reserved = "    "
+	ci.reserved = ci.parseReservedField(record, 96, 100)
 }
 
 // String writes the CreditItem struct to a variable length string.
 func (ci *CreditItem) String() string {
-	var buf strings.Builder
-	buf.Grow(100)
-	buf.WriteString(ci.recordType)
-	buf.WriteString(ci.AuxiliaryOnUsField())
-	buf.WriteString(ci.ExternalProcessingCodeField())
-	buf.WriteString(ci.PostingBankRoutingNumberField())
-	buf.WriteString(ci.OnUsField())
-	buf.WriteString(ci.ItemAmountField())
-	buf.WriteString(ci.CreditItemSequenceNumberField())
-	buf.WriteString(ci.DocumentationTypeIndicatorField())
-	buf.WriteString(ci.AccountTypeCodeField())
-	buf.WriteString(ci.SourceWorkCodeField())
-	buf.WriteString(ci.UserFieldField())
-	buf.WriteString(ci.reservedField())
-	return buf.String()
+	return string(ci.AppendTo(make([]byte, 0, 100)))
+}
+
+// AppendTo appends the CreditItem record, as String returns it, to b and returns the extended buffer.
+func (ci *CreditItem) AppendTo(b []byte) []byte {
+	b = append(b, ci.recordType...)
+	b = ci.appendNBSMField(b, ci.AuxiliaryOnUs, 15)
+	b = ci.appendAlphaField(b, ci.ExternalProcessingCode, 1)
+	b = ci.appendStringField(b, ci.PostingBankRoutingNumber, 9)
+	b = ci.appendNBSMField(b, ci.OnUs, 20)
+	b = ci.appendAmount(b, int64(ci.ItemAmount), 14)
+	b = ci.appendAlphaField(b, ci.CreditItemSequenceNumber, 15)
+	b = ci.appendAlphaField(b, ci.DocumentationTypeIndicator, 1)
+	b = ci.appendAlphaField(b, ci.AccountTypeCode, 1)
+	b = ci.appendAlphaField(b, ci.SourceWorkCode, 2)
+	b = ci.appendAlphaField(b, ci.UserField, 16)
+	b = ci.appendAlphaField(b, ci.reserved, 4)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -185,9 +195,9 @@ func (ci *CreditItem) Validate() error {
 	if err := ci.fieldInclusion(); err != nil {
 		return err
 	}
-	if ci.recordType != "62" {
+	if ci.recordType != RecordTypeCreditItem {
 		msg := fmt.Sprintf(msgRecordType, 62)
-		return &FieldError{FieldName: "recordType", Value: ci.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ci.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if ci.DocumentationTypeIndicator != "" {
 		// Z is valid for CashLetter DocumentationTypeIndicator only
@@ -222,22 +232,22 @@ func (ci *CreditItem) fieldInclusion() error {
 	if ci.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: ci.recordType,
-			Msg:   msgFieldInclusion + ", did you use CreditItem()?"}
+			Msg:   msgFieldInclusion + ", did you use CreditItem()?", Err: ErrFieldInclusion}
 	}
 	if ci.PostingBankRoutingNumber == "" {
 		return &FieldError{FieldName: "PostingBankRoutingNumber",
 			Value: ci.PostingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CreditItem()?"}
+			Msg:   msgFieldInclusion + ", did you use CreditItem()?", Err: ErrFieldInclusion}
 	}
-	if ci.PostingBankRoutingNumberField() == "000000000" {
+	if ci.isZeroRoutingNumber(ci.PostingBankRoutingNumber) {
 		return &FieldError{FieldName: "PostingBankRoutingNumber",
 			Value: ci.PostingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use CreditItem()?"}
+			Msg:   msgFieldInclusion + ", did you use CreditItem()?", Err: ErrFieldInclusion}
 	}
 	if ci.CreditItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "CreditItemSequenceNumber",
 			Value: ci.CreditItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use CreditItem()?"}
+			Msg:   msgFieldInclusion + ", did you use CreditItem()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -264,7 +274,23 @@ func (ci *CreditItem) OnUsField() string {
 
 // ItemAmountField gets the temAmount field
 func (ci *CreditItem) ItemAmountField() string {
-	return ci.numericField(ci.ItemAmount, 14)
+	return ci.formatAmount(int64(ci.ItemAmount), 14)
+}
+
+// SetItemAmount sets ItemAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 14 digit field.
+func (ci *CreditItem) SetItemAmount(cents int64) error {
+	amount, err := ci.amountFromCents("ItemAmount", cents, 14)
+	if err != nil {
+		return err
+	}
+	ci.ItemAmount = amount
+	return nil
+}
+
+// ItemAmountCents returns ItemAmount in cents
+func (ci *CreditItem) ItemAmountCents() int64 {
+	return int64(ci.ItemAmount)
 }
 
 // CreditItemSequenceNumberField gets the CreditItemSequenceNumber field
@@ -0,0 +1,251 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// mockCredit creates a Credit
+func mockCredit() *Credit {
+	cr := NewCredit()
+	cr.AuxiliaryOnUs = "123456789"
+	cr.ExternalProcessingCode = ""
+	cr.PayorBankRoutingNumber = "031300012"
+	cr.CreditAccountNumberOnUs = "5558881"
+	cr.ItemAmount = 100000 // 1000.00
+	cr.EceInstitutionItemSequenceNumber = "1              "
+	cr.DocumentationTypeIndicator = "G"
+	cr.AccountTypeCode = "1"
+	cr.SourceWorkCode = "01"
+	cr.WorkType = ""
+	cr.DebitCreditIndicator = ""
+	return cr
+}
+
+// TestMockCredit creates a Credit
+func TestMockCredit(t *testing.T) {
+	cr := mockCredit()
+	if err := cr.Validate(); err != nil {
+		t.Error("mockCredit does not validate and will break other tests: ", err)
+	}
+	if cr.recordType != "61" {
+		t.Error("recordType does not validate")
+	}
+	if cr.AuxiliaryOnUs != "123456789" {
+		t.Error("AuxiliaryOnUs does not validate")
+	}
+	if cr.PayorBankRoutingNumber != "031300012" {
+		t.Error("PayorBankRoutingNumber does not validate")
+	}
+	if cr.CreditAccountNumberOnUs != "5558881" {
+		t.Error("CreditAccountNumberOnUs does not validate")
+	}
+	if cr.ItemAmount != 100000 {
+		t.Error("ItemAmount does not validate")
+	}
+	if cr.EceInstitutionItemSequenceNumber != "1              " {
+		t.Error("EceInstitutionItemSequenceNumber does not validate")
+	}
+	if cr.DocumentationTypeIndicator != "G" {
+		t.Error("DocumentationTypeIndicator does not validate")
+	}
+	if cr.AccountTypeCode != "1" {
+		t.Error("AccountTypeCode does not validate")
+	}
+	if cr.SourceWorkCode != "01" {
+		t.Error("SourceWorkCode does not validate")
+	}
+}
+
+func TestCreditCrash(t *testing.T) {
+	cr := &Credit{}
+	cr.Parse(`61      123456789 031300012`)
+	if cr.PayorBankRoutingNumber != "" {
+		t.Errorf("expected empty cr.PayorBankRoutingNumber=%s", cr.PayorBankRoutingNumber)
+	}
+}
+
+// TestParseCredit validates parsing a Credit
+func TestParseCredit(t *testing.T) {
+	var line = "61      123456789 031300012             555888100001000001              G101    "
+	r := NewReader(strings.NewReader(line))
+	r.line = line
+	clh := mockCashLetterHeader()
+	r.addCurrentCashLetter(NewCashLetter(clh))
+	if err := r.parseCredit(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	record := r.currentCashLetter.GetCredits()[0]
+
+	if record.recordType != "61" {
+		t.Errorf("RecordType Expected '61' got: %v", record.recordType)
+	}
+	if record.AuxiliaryOnUs != "123456789" {
+		t.Errorf("AuxiliaryOnUs Expected '123456789' got: %v", record.AuxiliaryOnUs)
+	}
+	if record.ExternalProcessingCode != "" {
+		t.Errorf("ExternalProcessingCode Expected '' got: %v", record.ExternalProcessingCode)
+	}
+	if record.PayorBankRoutingNumber != "031300012" {
+		t.Errorf("PayorBankRoutingNumber Expected '031300012' got: %v", record.PayorBankRoutingNumber)
+	}
+	if record.CreditAccountNumberOnUs != "5558881" {
+		t.Errorf("CreditAccountNumberOnUs Expected '5558881' got: %v", record.CreditAccountNumberOnUs)
+	}
+	if record.ItemAmount != 100000 {
+		t.Errorf("ItemAmount Expected '100000' got: %v", record.ItemAmount)
+	}
+	if record.EceInstitutionItemSequenceNumber != "1" {
+		t.Errorf("EceInstitutionItemSequenceNumber Expected '1' got: %v", record.EceInstitutionItemSequenceNumber)
+	}
+	if record.DocumentationTypeIndicator != "G" {
+		t.Errorf("DocumentationTypeIndicator Expected 'G' got: %v", record.DocumentationTypeIndicator)
+	}
+	if record.AccountTypeCode != "1" {
+		t.Errorf("AccountTypeCode Expected '1' got: %v", record.AccountTypeCode)
+	}
+	if record.SourceWorkCode != "01" {
+		t.Errorf("SourceWorkCode Expected '01' got: %v", record.SourceWorkCode)
+	}
+	if record.WorkType != "" {
+		t.Errorf("WorkType Expected '' got: %v", record.WorkType)
+	}
+	if record.DebitCreditIndicator != "" {
+		t.Errorf("DebitCreditIndicator Expected '' got: %v", record.DebitCreditIndicator)
+	}
+}
+
+// TestParseCreditOutsideCashLetter validates a Credit must follow a CashLetterHeader
+func TestParseCreditOutsideCashLetter(t *testing.T) {
+	var line = "61      123456789 031300012             555888100001000001              G101    "
+	r := NewReader(strings.NewReader(line))
+	r.line = line
+	r.addCurrentCashLetter(CashLetter{})
+	err := r.parseCredit()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), msgFileCredit) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// testCreditString validates parsing a Credit
+func testCreditString(t testing.TB) {
+	var line = "61      123456789 031300012             555888100001000001              G101    "
+	r := NewReader(strings.NewReader(line))
+	r.line = line
+	clh := mockCashLetterHeader()
+	r.addCurrentCashLetter(NewCashLetter(clh))
+	if err := r.parseCredit(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	record := r.currentCashLetter.GetCredits()[0]
+
+	if record.String() != line {
+		t.Errorf("Strings do not match")
+	}
+}
+
+// TestCreditString tests validating that a known parsed Credit can return to a string of the same value
+func TestCreditString(t *testing.T) {
+	testCreditString(t)
+}
+
+// BenchmarkCreditString benchmarks validating that a known parsed Credit
+// can return to a string of the same value
+func BenchmarkCreditString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCreditString(b)
+	}
+}
+
+// TestCreditValidate validates each field check in Credit.Validate
+func TestCreditValidate(t *testing.T) {
+	tests := []struct {
+		fieldName string
+		modify    func(cr *Credit)
+	}{
+		{"recordType", func(cr *Credit) { cr.recordType = "00" }},
+		{"recordType", func(cr *Credit) { cr.recordType = "" }},
+		{"PayorBankRoutingNumber", func(cr *Credit) { cr.PayorBankRoutingNumber = "" }},
+		{"PayorBankRoutingNumber", func(cr *Credit) { cr.PayorBankRoutingNumber = "000000000" }},
+		{"PayorBankRoutingNumber", func(cr *Credit) { cr.PayorBankRoutingNumber = "03130001A" }},
+		{"EceInstitutionItemSequenceNumber", func(cr *Credit) { cr.EceInstitutionItemSequenceNumber = "" }},
+		{"DocumentationTypeIndicator", func(cr *Credit) { cr.DocumentationTypeIndicator = "Z" }},
+		{"DocumentationTypeIndicator", func(cr *Credit) { cr.DocumentationTypeIndicator = "P" }},
+		{"AccountTypeCode", func(cr *Credit) { cr.AccountTypeCode = "Z" }},
+		{"SourceWorkCode", func(cr *Credit) { cr.SourceWorkCode = "99" }},
+		{"WorkType", func(cr *Credit) { cr.WorkType = "®" }},
+		{"DebitCreditIndicator", func(cr *Credit) { cr.DebitCreditIndicator = "©" }},
+	}
+	for _, tc := range tests {
+		cr := mockCredit()
+		tc.modify(cr)
+		err := cr.Validate()
+		if err == nil {
+			t.Errorf("%s: expected error", tc.fieldName)
+			continue
+		}
+		if e, ok := err.(*FieldError); !ok || e.FieldName != tc.fieldName {
+			t.Errorf("%s: %T: %s", tc.fieldName, err, err)
+		}
+	}
+}
+
+// TestICLWriteCredit writes and reads back an ICL file with a Credit and a CreditItem
+func TestICLWriteCredit(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+
+	cd := mockCheckDetail()
+	cd.AddCheckDetailAddendumA(mockCheckDetailAddendumA())
+	cd.AddCheckDetailAddendumB(mockCheckDetailAddendumB())
+	cd.AddCheckDetailAddendumC(mockCheckDetailAddendumC())
+	cd.AddImageViewDetail(mockImageViewDetail())
+	cd.AddImageViewData(mockImageViewData())
+	cd.AddImageViewAnalysis(mockImageViewAnalysis())
+	bundle := NewBundle(mockBundleHeader())
+	bundle.AddCheckDetail(cd)
+
+	cl := NewCashLetter(mockCashLetterHeader())
+	cl.AddCreditItem(mockCreditItem())
+	cl.AddCredit(mockCredit())
+	cl.AddBundle(bundle)
+	if err := cl.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file.AddCashLetter(cl)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	b := &bytes.Buffer{}
+	if err := NewWriter(b).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	r := NewReader(strings.NewReader(b.String()))
+	read, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := read.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	credits := read.CashLetters[0].GetCredits()
+	if len(credits) != 1 {
+		t.Fatalf("expected 1 Credit, got %d", len(credits))
+	}
+	if credits[0].String() != mockCredit().String() {
+		t.Errorf("Credit %q does not match %q", credits[0].String(), mockCredit().String())
+	}
+	if n := len(read.CashLetters[0].GetCreditItems()); n != 1 {
+		t.Errorf("expected 1 CreditItem, got %d", n)
+	}
+}
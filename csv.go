@@ -0,0 +1,137 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// csvHeader is the first row written by File.WriteCSV
+var csvHeader = []string{
+	"RecordType",
+	"CashLetterID",
+	"BundleID",
+	"ItemSequenceNumber",
+	"RoutingNumber",
+	"OnUs",
+	"Amount",
+	"BusinessDate",
+	"ReturnReason",
+}
+
+// CSVOption can be used to change the rows written by File.WriteCSV
+type CSVOption func(*csvOptions)
+
+type csvOptions struct {
+	includeReturns bool
+}
+
+// CSVIncludeReturnsOption sets if ReturnDetail rows are written, which they are by default.
+func CSVIncludeReturnsOption(include bool) CSVOption {
+	return func(opts *csvOptions) {
+		opts.includeReturns = include
+	}
+}
+
+// WriteCSV writes a header row followed by one row for each CheckDetail and ReturnDetail in the File
+// in the order they appear. The columns are:
+//
+// - RecordType: CheckDetail or ReturnDetail
+// - CashLetterID: CashLetterHeader CashLetterID
+// - BundleID: BundleHeader BundleID
+// - ItemSequenceNumber: EceInstitutionItemSequenceNumber
+// - RoutingNumber: PayorBankRoutingNumber followed by PayorBankCheckDigit
+// - OnUs: OnUs, which usually holds the account number
+// - Amount: ItemAmount in dollars, such as 1000.00
+// - BusinessDate: BundleHeader BundleBusinessDate as YYYY-MM-DD
+// - ReturnReason: ReturnDetail ReturnReason, blank for a CheckDetail
+//
+// WriteCSV is only for export and CSV files can't be read back into a File.
+func (f *File) WriteCSV(w io.Writer, opts ...CSVOption) error {
+	o := &csvOptions{includeReturns: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		cashLetterID := ""
+		if cl.CashLetterHeader != nil {
+			cashLetterID = cl.CashLetterHeader.CashLetterID
+		}
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			bundleID, businessDate := "", ""
+			if b.BundleHeader != nil {
+				bundleID = b.BundleHeader.BundleID
+				businessDate = csvDate(b.BundleHeader.BundleBusinessDate)
+			}
+			for _, cd := range b.Checks {
+				err := cw.Write([]string{
+					"CheckDetail",
+					cashLetterID,
+					bundleID,
+					cd.EceInstitutionItemSequenceNumber,
+					cd.PayorBankRoutingNumber + cd.PayorBankCheckDigit,
+					cd.OnUs,
+					csvAmount(cd.ItemAmount),
+					businessDate,
+					"",
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if !o.includeReturns {
+				continue
+			}
+			for _, rd := range b.Returns {
+				err := cw.Write([]string{
+					"ReturnDetail",
+					cashLetterID,
+					bundleID,
+					rd.EceInstitutionItemSequenceNumber,
+					rd.PayorBankRoutingNumber + rd.PayorBankCheckDigit,
+					rd.OnUs,
+					csvAmount(rd.ItemAmount),
+					businessDate,
+					rd.ReturnReason,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvAmount formats an amount in cents as dollars with two decimal places
+func csvAmount(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// csvDate formats t as YYYY-MM-DD, or blank if t is the zero time
+func csvDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestFileWriteCSV validates the header and item rows written by WriteCSV
+func TestFileWriteCSV(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	file.CashLetters[0].Bundles[0].Checks[1].ItemAmount = 123456
+
+	var buf bytes.Buffer
+	if err := file.WriteCSV(&buf); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(rows) != 9 {
+		t.Fatalf("expected 9 rows, got %d", len(rows))
+	}
+	expected := []string{
+		"RecordType,CashLetterID,BundleID,ItemSequenceNumber,RoutingNumber,OnUs,Amount,BusinessDate,ReturnReason",
+		"CheckDetail,A1,9999,1,031300012,5558881,1000.00,2018-10-03,",
+		"CheckDetail,A1,9999,2,031300012,5558881,1234.56,2018-10-03,",
+		"ReturnDetail,A1,9999,1,031300012,5558881,1000.00,2018-10-03,A",
+	}
+	for i := range expected {
+		if rows[i] != expected[i] {
+			t.Errorf("row %d: expected %q got %q", i, expected[i], rows[i])
+		}
+	}
+}
+
+// TestFileWriteCSVExcludeReturns validates ReturnDetail rows are omitted by CSVIncludeReturnsOption(false)
+func TestFileWriteCSVExcludeReturns(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+
+	var buf bytes.Buffer
+	if err := file.WriteCSV(&buf, CSVIncludeReturnsOption(false)); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+	for _, row := range rows[1:] {
+		if !strings.HasPrefix(row, "CheckDetail,") {
+			t.Errorf("unexpected row %q", row)
+		}
+	}
+}
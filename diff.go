@@ -0,0 +1,127 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Difference is a field whose value differs between two Files compared by File.Diff
+type Difference struct {
+	// Path locates the field, such as CashLetters[0].Bundles[2].Checks[5].ItemAmount
+	Path string `json:"path"`
+	// Value is the field's value in the File Diff was called on
+	Value string `json:"value"`
+	// Other is the field's value in the File passed to Diff
+	Other string `json:"other"`
+}
+
+// String returns the Difference as "path: value != other"
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Path, d.Value, d.Other)
+}
+
+// Values used in a Difference for a record or slice element only present in one File
+const (
+	diffMissing = "<missing>"
+	diffPresent = "<present>"
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Equal returns true if the File and other have the same parsed content, as reported by Diff.
+func (f *File) Equal(other *File) bool {
+	return len(f.Diff(other)) == 0
+}
+
+// Diff returns the differences between the parsed content of the File and other in the order the
+// fields are written. Fillers and reserved fields, the client defined ID fields and state which is
+// not written, such as record types, are not compared. String fields are compared without leading and
+// trailing spaces, so fields differing only in padding are equal. A record or slice element present in only one File is
+// reported once with a Value or Other of "<missing>".
+func (f *File) Diff(other *File) []Difference {
+	switch {
+	case f == nil && other == nil:
+		return nil
+	case f == nil:
+		return []Difference{{Path: "File", Value: diffMissing, Other: diffPresent}}
+	case other == nil:
+		return []Difference{{Path: "File", Value: diffPresent, Other: diffMissing}}
+	}
+	var diffs []Difference
+	diffValues("", reflect.ValueOf(f).Elem(), reflect.ValueOf(other).Elem(), &diffs)
+	return diffs
+}
+
+// diffValues appends the differences between a and b, which have the same type, to diffs
+func diffValues(path string, a, b reflect.Value, diffs *[]Difference) {
+	switch {
+	case a.Type() == timeType:
+		at, bt := a.Interface().(time.Time), b.Interface().(time.Time)
+		if !at.Equal(bt) {
+			*diffs = append(*diffs, Difference{Path: path, Value: at.String(), Other: bt.String()})
+		}
+	case a.Type() == bytesType:
+		if !bytes.Equal(a.Bytes(), b.Bytes()) {
+			*diffs = append(*diffs, Difference{Path: path, Value: diffBytes(a.Bytes()), Other: diffBytes(b.Bytes())})
+		}
+	case a.Kind() == reflect.Ptr:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil():
+			*diffs = append(*diffs, Difference{Path: path, Value: diffMissing, Other: diffPresent})
+		case b.IsNil():
+			*diffs = append(*diffs, Difference{Path: path, Value: diffPresent, Other: diffMissing})
+		default:
+			diffValues(path, a.Elem(), b.Elem(), diffs)
+		}
+	case a.Kind() == reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" || field.Anonymous || field.Name == "ID" {
+				// unexported, composed validator and converters, or client defined
+				continue
+			}
+			name := field.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			diffValues(name, a.Field(i), b.Field(i), diffs)
+		}
+	case a.Kind() == reflect.String:
+		if as, bs := strings.TrimSpace(a.String()), strings.TrimSpace(b.String()); as != bs {
+			*diffs = append(*diffs, Difference{Path: path, Value: as, Other: bs})
+		}
+	case a.Kind() == reflect.Slice:
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= a.Len():
+				*diffs = append(*diffs, Difference{Path: elemPath, Value: diffMissing, Other: diffPresent})
+			case i >= b.Len():
+				*diffs = append(*diffs, Difference{Path: elemPath, Value: diffPresent, Other: diffMissing})
+			default:
+				diffValues(elemPath, a.Index(i), b.Index(i), diffs)
+			}
+		}
+	default:
+		if a.Interface() != b.Interface() {
+			*diffs = append(*diffs, Difference{Path: path, Value: fmt.Sprint(a.Interface()), Other: fmt.Sprint(b.Interface())})
+		}
+	}
+}
+
+// diffBytes describes binary data such as ImageData in a Difference
+func diffBytes(b []byte) string {
+	return fmt.Sprintf("%d bytes", len(b))
+}
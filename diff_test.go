@@ -0,0 +1,100 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func readDiffFile(t *testing.T) *File {
+	t.Helper()
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &file
+}
+
+// TestFile__EqualIdentical validates a File equals itself read again and after being written
+func TestFile__EqualIdentical(t *testing.T) {
+	file, other := readDiffFile(t), readDiffFile(t)
+	other.ID = "other"
+	if diffs := file.Diff(other); len(diffs) != 0 {
+		t.Errorf("unexpected differences: %v", diffs)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+	written, err := NewReader(&buf).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.Equal(&written) {
+		t.Errorf("unexpected differences: %v", file.Diff(&written))
+	}
+
+	var nilFile *File
+	if !nilFile.Equal(nil) || file.Equal(nil) {
+		t.Error("unexpected nil File comparison")
+	}
+}
+
+// TestFile__DiffField validates a single changed field is reported with its path
+func TestFile__DiffField(t *testing.T) {
+	file, other := readDiffFile(t), readDiffFile(t)
+	cd := other.CashLetters[0].Bundles[0].Checks[1]
+	cd.ItemAmount++
+	// padding is not compared
+	cd.PayorBankRoutingNumber = " " + cd.PayorBankRoutingNumber + " "
+
+	diffs := file.Diff(other)
+	if len(diffs) != 1 {
+		t.Fatalf("unexpected differences: %v", diffs)
+	}
+	want := Difference{
+		Path:  "CashLetters[0].Bundles[0].Checks[1].ItemAmount",
+		Value: strconv.Itoa(cd.ItemAmount - 1),
+		Other: strconv.Itoa(cd.ItemAmount),
+	}
+	if diffs[0] != want {
+		t.Errorf("unexpected difference: %v", diffs[0])
+	}
+	if file.Equal(other) {
+		t.Error("expected files to differ")
+	}
+}
+
+// TestFile__DiffStructure validates records present in only one File are reported
+func TestFile__DiffStructure(t *testing.T) {
+	file, other := readDiffFile(t), readDiffFile(t)
+	b := other.CashLetters[0].Bundles[0]
+	b.Checks = b.Checks[:len(b.Checks)-1]
+	other.CashLetters[0].Bundles[1].BundleControl = nil
+
+	diffs := file.Diff(other)
+	if len(diffs) != 2 {
+		t.Fatalf("unexpected differences: %v", diffs)
+	}
+	if d := diffs[0]; d.Value != diffPresent || d.Other != diffMissing {
+		t.Errorf("unexpected difference: %v", d)
+	}
+	if d := diffs[1]; d.Path != "CashLetters[0].Bundles[1].BundleControl" || d.Other != diffMissing {
+		t.Errorf("unexpected difference: %v", d)
+	}
+	if diffs := other.Diff(file); diffs[0].Value != diffMissing {
+		t.Errorf("unexpected difference: %v", diffs[0])
+	}
+}
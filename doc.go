@@ -0,0 +1,21 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package imagecashletter reads, writes and validates X9.100-187 Image Cash Letter files.
+//
+// The package changes none of its own state as it is used. Its package level regular expressions and code
+// tables are only read and the built-in ValidationProfiles are new values on each call, so independent
+// Readers, Writers and Files can be used from any number of goroutines. A Reader or Writer is used by one
+// goroutine at a time.
+//
+// A File can be validated with Validate, ValidateWith and ValidateConcurrent, written by Writers, marshaled
+// and summarized from many goroutines at once, as none of them change the File. Methods which change the
+// File, such as Create, Recalculate and Renumber, must not run alongside any other use of it.
+// FindCheckDetailBySequence and FindReturnDetailBySequence build an index stored in the File on the first
+// lookup, which may be made by several goroutines at once.
+//
+// The exported package level variables, such as CustomerReturnCodeDict and AdministrativeReturnCodeDict
+// which are read when ReturnReason is validated, must be treated as read-only, as changing them races with
+// every goroutine using the package.
+package imagecashletter
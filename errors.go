@@ -10,4 +10,11 @@ import (
 
 var (
 	ErrNilFile = errors.New("given nil File")
+
+	// ErrFieldInclusion is the Err of a FieldError, FileError, BundleError or CashLetterError of a
+	// mandatory field which has a default value, so it's matched by errors.Is
+	ErrFieldInclusion = errors.New(msgFieldInclusion)
+	// ErrRecordType is the Err of a FieldError or FileError of a record whose record type is unknown or
+	// isn't the type expected, so it's matched by errors.Is
+	ErrRecordType = errors.New("unexpected record type")
 )
@@ -151,9 +151,13 @@ func main() {
 	ivAnalysis.BOFDEndorsementUsability = 2
 	ivAnalysis.TransitEndorsementUsability = 2
 
-	cd.AddCheckDetailAddendumA(cdAddendumA)
+	if err := cd.AddCheckDetailAddendumA(cdAddendumA); err != nil {
+		log.Fatalf("Could not add CheckDetailAddendumA: %s\n", err)
+	}
 	cd.AddCheckDetailAddendumB(cdAddendumB)
-	cd.AddCheckDetailAddendumC(cdAddendumC)
+	if err := cd.AddCheckDetailAddendumC(cdAddendumC); err != nil {
+		log.Fatalf("Could not add CheckDetailAddendumC: %s\n", err)
+	}
 
 	cd.AddImageViewDetail(ivDetail)
 	cd.AddImageViewData(ivData)
@@ -191,9 +195,13 @@ func main() {
 	cdTwo.CorrectionIndicator = 0
 	cdTwo.ArchiveTypeIndicator = "B"
 
-	cdTwo.AddCheckDetailAddendumA(cdAddendumA)
+	if err := cdTwo.AddCheckDetailAddendumA(cdAddendumA); err != nil {
+		log.Fatalf("Could not add CheckDetailAddendumA: %s\n", err)
+	}
 	cdTwo.AddCheckDetailAddendumB(cdAddendumB)
-	cdTwo.AddCheckDetailAddendumC(cdAddendumC)
+	if err := cdTwo.AddCheckDetailAddendumC(cdAddendumC); err != nil {
+		log.Fatalf("Could not add CheckDetailAddendumC: %s\n", err)
+	}
 	cdTwo.AddImageViewDetail(ivDetail)
 	cdTwo.AddImageViewData(ivData)
 	cdTwo.AddImageViewAnalysis(ivAnalysis)
@@ -320,10 +328,14 @@ func main() {
 	rdivAnalysis.BOFDEndorsementUsability = 2
 	rdivAnalysis.TransitEndorsementUsability = 2
 
-	rd.AddReturnDetailAddendumA(rdAddendumA)
+	if err := rd.AddReturnDetailAddendumA(rdAddendumA); err != nil {
+		log.Fatalf("Could not add ReturnDetailAddendumA: %s\n", err)
+	}
 	rd.AddReturnDetailAddendumB(rdAddendumB)
 	rd.AddReturnDetailAddendumC(rdAddendumC)
-	rd.AddReturnDetailAddendumD(rdAddendumD)
+	if err := rd.AddReturnDetailAddendumD(rdAddendumD); err != nil {
+		log.Fatalf("Could not add ReturnDetailAddendumD: %s\n", err)
+	}
 	rd.AddImageViewDetail(rdivDetail)
 	rd.AddImageViewData(rdivData)
 	rd.AddImageViewAnalysis(rdivAnalysis)
@@ -359,10 +371,14 @@ func main() {
 	rdTwo.ArchiveTypeIndicator = "B"
 	rdTwo.TimesReturned = 0
 
-	rdTwo.AddReturnDetailAddendumA(rdAddendumA)
+	if err := rdTwo.AddReturnDetailAddendumA(rdAddendumA); err != nil {
+		log.Fatalf("Could not add ReturnDetailAddendumA: %s\n", err)
+	}
 	rdTwo.AddReturnDetailAddendumB(rdAddendumB)
 	rdTwo.AddReturnDetailAddendumC(rdAddendumC)
-	rdTwo.AddReturnDetailAddendumD(rdAddendumD)
+	if err := rdTwo.AddReturnDetailAddendumD(rdAddendumD); err != nil {
+		log.Fatalf("Could not add ReturnDetailAddendumD: %s\n", err)
+	}
 	rdTwo.AddImageViewDetail(rdivDetail)
 	rdTwo.AddImageViewData(rdivData)
 	rdTwo.AddImageViewAnalysis(rdivAnalysis)
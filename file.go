@@ -6,10 +6,22 @@ package imagecashletter
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // https://en.wikipedia.org/wiki/Substitute_check
@@ -21,26 +33,27 @@ import (
 //
 // Record Types
 const (
-	fileHeaderPos           = "01"
-	cashLetterHeaderPos     = "10"
-	bundleHeaderPos         = "20"
-	checkDetailPos          = "25"
-	checkDetailAddendumAPos = "26"
-	checkDetailAddendumBPos = "27"
-	checkDetailAddendumCPos = "28"
-	returnDetailPos         = "31"
-	returnAddendumAPos      = "32"
-	returnAddendumBPos      = "33"
-	returnAddendumCPos      = "34"
-	returnAddendumDPos      = "35"
-	imageViewDetailPos      = "50"
-	imageViewDataPos        = "52"
-	imageViewAnalysisPos    = "54"
-	creditItemPos           = "62"
-	bundleControlPos        = "70"
-	routingNumberSummaryPos = "85"
-	cashLetterControlPos    = "90"
-	fileControlPos          = "99"
+	fileHeaderPos           = RecordTypeFileHeader
+	cashLetterHeaderPos     = RecordTypeCashLetterHeader
+	bundleHeaderPos         = RecordTypeBundleHeader
+	checkDetailPos          = RecordTypeCheckDetail
+	checkDetailAddendumAPos = RecordTypeCheckDetailAddendumA
+	checkDetailAddendumBPos = RecordTypeCheckDetailAddendumB
+	checkDetailAddendumCPos = RecordTypeCheckDetailAddendumC
+	returnDetailPos         = RecordTypeReturnDetail
+	returnAddendumAPos      = RecordTypeReturnDetailAddendumA
+	returnAddendumBPos      = RecordTypeReturnDetailAddendumB
+	returnAddendumCPos      = RecordTypeReturnDetailAddendumC
+	returnAddendumDPos      = RecordTypeReturnDetailAddendumD
+	imageViewDetailPos      = RecordTypeImageViewDetail
+	imageViewDataPos        = RecordTypeImageViewData
+	imageViewAnalysisPos    = RecordTypeImageViewAnalysis
+	creditPos               = RecordTypeCredit
+	creditItemPos           = RecordTypeCreditItem
+	bundleControlPos        = RecordTypeBundleControl
+	routingNumberSummaryPos = RecordTypeRoutingNumberSummary
+	cashLetterControlPos    = RecordTypeCashLetterControl
+	fileControlPos          = RecordTypeFileControl
 	// no longer supported by the standard
 	// accountTotalsDetailPos  = "40"
 	// nonHitTotalsDetailPos   = "41"
@@ -52,18 +65,38 @@ var (
 	//msgFileCalculatedControlEquality = "calculated %v is out-of-balance with control %v"
 	// specific messages
 	msgRecordLength             = "Must be at least 80 characters and found %d"
+	msgRecordTypeLength         = "record type %s expected %d bytes, got %d"
+	msgRecordTypeMinLength      = "record type %s expected at least %d bytes, got %d"
+	msgRecordSize               = "Record exceeds the maximum record size of %d bytes"
 	msgFileCashLetterInside     = "Inside of current cash letter"
 	msgFileCashLetterControl    = "Cash letter control without a current cash letter"
 	msgFileRoutingNumberSummary = "Routing Number Summary without a current cash letter"
 	msgFileBundleOutside        = "Outside of current bundle"
 	msgFileBundleInside         = "Inside of current bundle"
 	msgFileBundleControl        = "Bundle control without a current bundle"
+	msgFileBundleHeader         = "Bundle header outside of cash letter"
 	msgFileControl              = "None or more than one file control exists"
+	msgFileBundleControlEOF     = "Bundle control missing at end of file"
+	msgFileCashLetterControlEOF = "Cash letter control missing at end of file"
 	msgFileHeader               = "None or more than one file headers exists"
 	msgUnknownRecordType        = "%s is an unknown record type"
 	msgFileCashLetterID         = "%s is not unique"
 	msgRecordType               = "received expecting %d"
 	msgFileCreditItem           = "Credit item outside of cash letter"
+	msgFileCredit               = "Credit outside of cash letter"
+	msgFileImageViewData        = "Image view data without a preceding image view detail"
+	msgFileImageViewAnalysis    = "Image view analysis without a preceding image view data"
+	msgFileImageDataSkipped     = "was skipped when reading and can not be written"
+	msgFileMergeHeader          = "%s does not match %s"
+	msgFileCashLetterIndex      = "index out of range with %d cash letters"
+	msgFileSplitSize            = "requires %d bytes which exceeds the maximum of %d"
+	msgFileRoundTrip            = "written output differs from input at byte offset %d (line %d)"
+	msgFileRoundTripDiff        = "%d fields differ after writing and reading: %s"
+	msgFileRoutingNumberMatch   = "does not match %s %s"
+	msgBlockSize                = "%d is not a valid block size"
+	msgBlockedRecordLength      = "record length %d exceeds the %d bytes remaining"
+	msgBlockFill                = "unexpected data after block fill"
+	msgFieldWidth               = "%d characters exceed the %s field width of %d"
 )
 
 // FileError is an error describing issues validating a file
@@ -71,13 +104,23 @@ type FileError struct {
 	FieldName string
 	Value     string
 	Msg       string
+	// Err is the sentinel error, such as ErrFieldInclusion, the error was built from
+	Err error
 }
 
 func (e *FileError) Error() string {
 	return fmt.Sprintf("%s %s", e.FieldName, e.Msg)
 }
 
+// Unwrap returns the sentinel error the error was built from, if any, for errors.Is
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
 // File is an imagecashletter file
+//
+// A File has no maps, so encoding/json writes it the same way every time: object keys in the order of
+// the struct fields and records in the order of their slices, which is the order they were read in.
 type File struct {
 	// ID is a client defined string used as a reference to this record
 	ID string `json:"id"`
@@ -89,6 +132,20 @@ type File struct {
 	Bundles []Bundle `json:"bundle,omitempty"`
 	// FileControl is an imagecashletter FileControl
 	Control FileControl `json:"fileControl"`
+	// Layout is the StandardLevel whose record layouts the File is written with, which is the FileHeader
+	// StandardLevel when blank. It is set by Reader when ReadStandardLevelOption is used.
+	Layout string `json:"layout,omitempty"`
+	// imageDataSkipped is set when the File was read without ImageViewData.ImageData
+	imageDataSkipped bool
+	// itemIndex holds the *itemIndex built by the first FindCheckDetailBySequence or FindReturnDetailBySequence
+	// call. It's an atomic.Value so lookups by concurrent goroutines don't race when building it.
+	itemIndex atomic.Value
+}
+
+// itemIndex maps normalized item sequence numbers to CheckDetail and ReturnDetail records
+type itemIndex struct {
+	checks  map[string]*CheckDetail
+	returns map[string]*ReturnDetail
 }
 
 // NewFile constructs a file template with a FileHeader and FileControl.
@@ -157,6 +214,337 @@ func FileFromJSON(bs []byte) (*File, error) {
 	return file, nil
 }
 
+// MarshalJSONWithImages returns the File encoded as JSON with each non-empty ImageViewData.ImageData
+// written to its own file in dir instead of inline as base64. The JSON has an "imageDataRef" with
+// the name of the image file in place of "imageData". Image files are named after the SHA-256 hash
+// of their contents so identical images are written once. dir is created if it doesn't exist.
+func (f *File) MarshalJSONWithImages(dir string) ([]byte, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	doc, err := toJSONTree(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	err = walkJSONImages(doc, func(image map[string]interface{}) error {
+		encoded, ok := image["imageData"].(string)
+		if !ok || encoded == "" {
+			return nil
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		name := hex.EncodeToString(sum[:]) + ".img"
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+		delete(image, "imageData")
+		image["imageDataRef"] = name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// MarshalJSONMetadataOnly returns the File encoded as JSON without the ImageData of any ImageViewData,
+// for storing file metadata without the check images. The "imageData" of each ImageViewData is left out
+// while every other field, including LengthImageData, is kept. FileFromJSON reads the JSON into a File
+// whose ImageViewData records have no ImageData.
+func (f *File) MarshalJSONMetadataOnly() ([]byte, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	doc, err := toJSONTree(f)
+	if err != nil {
+		return nil, err
+	}
+	err = walkJSONImages(doc, func(image map[string]interface{}) error {
+		delete(image, "imageData")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// FileFromJSONWithImages reads JSON produced by File.MarshalJSONWithImages, loading each
+// "imageDataRef" from dir, and returns the File as FileFromJSON does.
+func FileFromJSONWithImages(bs []byte, dir string) (*File, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no JSON data provided")
+	}
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("problem reading file: %v", err)
+	}
+	err := walkJSONImages(doc, func(image map[string]interface{}) error {
+		name, ok := image["imageDataRef"].(string)
+		if !ok {
+			return nil
+		}
+		if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+			return fmt.Errorf("invalid imageDataRef %q", name)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		delete(image, "imageDataRef")
+		image["imageData"] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	bs, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return FileFromJSON(bs)
+}
+
+// toJSONTree returns v encoded as JSON and decoded into generic maps and slices
+func toJSONTree(v interface{}) (interface{}, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// walkJSONImages calls fn with each object in the "imageViewData" arrays of a decoded JSON File
+func walkJSONImages(doc interface{}, fn func(image map[string]interface{}) error) error {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if images, ok := value.([]interface{}); ok && key == "imageViewData" {
+				for i := range images {
+					if image, ok := images[i].(map[string]interface{}); ok {
+						if err := fn(image); err != nil {
+							return err
+						}
+					}
+				}
+				continue
+			}
+			if err := walkJSONImages(value, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if err := walkJSONImages(v[i], fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FileFromXML attempts to return a *File object assuming the input is valid XML as produced by
+// File.XMLBytes. Elements are named after the Go record and field names, and ImageViewData
+// DigitalSignature and ImageData are base64 encoded.
+//
+// Callers should always check for a nil-error before using the returned file.
+//
+// The File returned may not be valid and callers should confirm with Validate().
+// Invalid files may be rejected by other Financial Institutions or ICL tools.
+func FileFromXML(bs []byte) (*File, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no XML data provided")
+	}
+	file := NewFile()
+	if err := xml.Unmarshal(bs, file); err != nil {
+		return nil, fmt.Errorf("problem reading file: %v", err)
+	}
+
+	file.setRecordTypes()
+
+	if err := file.Create(); err != nil {
+		return file, err
+	}
+	if err := file.Validate(); err != nil {
+		return file, err
+	}
+	return file, nil
+}
+
+// XMLBytes returns the File encoded as XML. See FileFromXML for the format.
+func (f *File) XMLBytes() ([]byte, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	return xml.MarshalIndent(f, "", "  ")
+}
+
+// WriteTo writes the File to w with a Writer and returns the number of bytes written, including
+// line terminators. It implements io.WriterTo.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := NewWriter(cw).Write(f)
+	return cw.n, err
+}
+
+// ReadFrom replaces the File with one read from r with a Reader and returns the number of bytes read.
+// It implements io.ReaderFrom. The File is left unchanged when an error is returned.
+func (f *File) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	file, err := NewReader(cr).Read()
+	if err != nil {
+		return cr.n, err
+	}
+	*f = file
+	return cr.n, nil
+}
+
+// countingWriter is an io.Writer which counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// countingReader is an io.Reader which counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// VerifyRoundTrip reads an imagecashletter file from b, writes it back out and returns an error
+// describing the first byte offset where the written output differs from b. Line endings are
+// normalized to "\n" and a missing newline after the last record is ignored before comparing.
+// The error is a FileError with the FieldName "RoundTrip" and the byte offset as its Value.
+//
+// VerifyRoundTrip checks that the bytes of an existing file survive being read and written. To check
+// that a File built in memory survives being written and read use the File's VerifyRoundTrip method.
+func VerifyRoundTrip(b []byte) error {
+	file, err := NewReader(bytes.NewReader(b)).Read()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		return err
+	}
+
+	input := bytes.Replace(b, []byte("\r\n"), []byte("\n"), -1)
+	if len(input) > 0 && input[len(input)-1] != '\n' {
+		input = append(input, '\n')
+	}
+	output := buf.Bytes()
+
+	offset := 0
+	for offset < len(input) && offset < len(output) && input[offset] == output[offset] {
+		offset++
+	}
+	if offset == len(input) && offset == len(output) {
+		return nil
+	}
+	line := bytes.Count(input[:offset], []byte("\n")) + 1
+	msg := fmt.Sprintf(msgFileRoundTrip, offset, line)
+	return &FileError{FieldName: "RoundTrip", Value: strconv.Itoa(offset), Msg: msg}
+}
+
+// VerifyRoundTrip writes the File, reads the output back and returns a FileError listing every Difference
+// between the File and the File read, as Diff reports them, when they are not Equal. Fields which are not
+// kept when written, such as the time of day of a date field or a value longer than its field, are found
+// this way.
+//
+// The package level VerifyRoundTrip function starts from the bytes of a file and compares bytes, while this
+// method starts from a File and compares parsed fields, so it reports differences which the function can't
+// see and ignores padding which the function reports. Both return a FileError with the FieldName
+// "RoundTrip", but here its Value is the Path of the first Difference rather than a byte offset.
+func (f *File) VerifyRoundTrip() error {
+	if f == nil {
+		return ErrNilFile
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(f); err != nil {
+		return err
+	}
+	var opts []ReaderOption
+	if f.Layout != "" {
+		opts = append(opts, ReadStandardLevelOption(f.Layout))
+	}
+	read, err := NewReader(&buf, opts...).Read()
+	if err != nil {
+		return err
+	}
+	diffs := f.Diff(&read)
+	if len(diffs) == 0 {
+		return nil
+	}
+	fields := make([]string, len(diffs))
+	for i := range diffs {
+		fields[i] = diffs[i].String()
+	}
+	msg := fmt.Sprintf(msgFileRoundTripDiff, len(diffs), strings.Join(fields, "; "))
+	return &FileError{FieldName: "RoundTrip", Value: diffs[0].Path, Msg: msg}
+}
+
+// FileFromJSONReader attempts to return a *File object from the JSON document read from r, assuming
+// it's valid JSON. Unlike FileFromJSON the document is streamed rather than held in memory: it's decoded
+// a record at a time, and the base64 "imageData" of each ImageViewData is decoded as it's read, so
+// beyond the File returned only the JSON of the current record and one image's bytes are held at once.
+//
+// Callers should always check for a nil-error before using the returned file.
+//
+// The File returned may not be valid and callers should confirm with Validate().
+// Invalid files may be rejected by other Financial Institutions or ICL tools.
+func FileFromJSONReader(r io.Reader) (*File, error) {
+	if r == nil {
+		return nil, errors.New("no JSON data provided")
+	}
+
+	// decoding over NewFile keeps the FileHeader and FileControl defaults for missing fields
+	file := NewFile()
+	d := newJSONFileDecoder(r)
+	if err := d.decodeFile(file); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("no JSON data provided")
+		}
+		return nil, fmt.Errorf("problem reading file: %v", err)
+	}
+	d.setImages(file)
+
+	file.setRecordTypes()
+
+	if err := file.Create(); err != nil {
+		return file, err
+	}
+	if err := file.Validate(); err != nil {
+		return file, err
+	}
+	return file, nil
+}
+
 // Create creates a valid imagecashletter File
 func (f *File) Create() error {
 	if f == nil {
@@ -189,10 +577,15 @@ func (f *File) Create() error {
 		}
 		cashLetterRecordCount = cashLetterRecordCount + 2
 
-		fileTotalItemCount = fileTotalItemCount + len(cl.GetCreditItems())
-
-		if len(cl.GetCreditItems()) > 0 {
-			fileTotalItemCount = fileTotalItemCount + len(cl.GetCreditItems())
+		// Credit Items
+		for _, ci := range cl.allCreditItems() {
+			fileTotalItemCount = fileTotalItemCount + 1
+			fileTotalAmount = fileTotalAmount + ci.ItemAmount
+			creditIndicator = 1
+		}
+		for _, cr := range cl.allCredits() {
+			fileTotalItemCount = fileTotalItemCount + 1
+			fileTotalAmount = fileTotalAmount + cr.ItemAmount
 			creditIndicator = 1
 		}
 
@@ -240,17 +633,212 @@ func (f *File) Create() error {
 	return nil
 }
 
-// Validate validates an ICL File
+// Recalculate creates the BundleControl and CashLetterControl of every CashLetter, as CashLetter.Create
+// does, and the FileControl. CashLetter.Create resets item sequence numbers, so the File is renumbered
+// with Renumber before the FileControl is created.
+func (f *File) Recalculate() error {
+	if f == nil {
+		return ErrNilFile
+	}
+	for i := range f.CashLetters {
+		if err := f.CashLetters[i].Create(); err != nil {
+			return err
+		}
+	}
+	f.Renumber()
+	return f.Create()
+}
+
+// Validate validates an ICL File, and that the CashLetterImagesCount of each CashLetterControl matches the
+// ImageViewDetail records of the CashLetter's Bundles.
 func (f *File) Validate() error {
+	return f.validate(newValidateOptions(nil))
+}
+
+// validate checks the rules of Validate, comparing the CashLetterImagesCount of each CashLetter with
+// its image views within the count tolerance of opts
+func (f *File) validate(opts *validateOptions) error {
 	if f == nil {
 		return ErrNilFile
 	}
 	if err := f.CashLetterIDUnique(); err != nil {
 		return err
 	}
+	for i := range f.CashLetters {
+		if err := f.CashLetters[i].validateImagesCount(opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImageDataSkipped returns true if the File was read with ReadSkipImageDataOption, in which case
+// every ImageViewData.ImageData is empty.
+func (f *File) ImageDataSkipped() bool {
+	return f != nil && f.imageDataSkipped
+}
+
+// ValidateWith validates an ICL File as Validate does, checks the number of Bundles in each CashLetter and
+// items in each Bundle are within the maximums, and applies the additional checks enabled by each
+// ValidateOption. A ValidationProfile such as ProfileFedForward() can be passed to
+// check the rules of a clearing channel.
+func (f *File) ValidateWith(opts ...ValidateOption) error {
+	o := newValidateOptions(opts)
+	if err := f.validate(o); err != nil {
+		return err
+	}
+	if err := f.validateMaxCounts(o); err != nil {
+		return err
+	}
+	if o.checkControlCounts {
+		if err := f.validateControlCounts(o); err != nil {
+			return err
+		}
+	}
+	if o.collectionTypes {
+		if err := f.validateCollectionTypes(); err != nil {
+			return err
+		}
+	}
+	if o.bundleSequence {
+		if err := f.validateBundleSequences(); err != nil {
+			return err
+		}
+	}
+	if o.checkBusinessDates {
+		if err := f.validateBusinessDates(o); err != nil {
+			return err
+		}
+	}
+	if o.strict {
+		if err := f.validateStrict(o); err != nil {
+			return err
+		}
+	}
+	if o.profile != nil {
+		if err := o.profile.validateFile(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateConcurrent validates an ICL File as Validate does and then validates every record of each
+// CashLetter, spreading the CashLetters across workers goroutines. When more than one CashLetter is
+// invalid the error from the CashLetter with the lowest index is returned. If workers is less than
+// one runtime.NumCPU is used.
+func (f *File) ValidateConcurrent(workers int) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(f.CashLetters) {
+		workers = len(f.CashLetters)
+	}
+	errs := make([]error, len(f.CashLetters))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = f.CashLetters[i].validateRecords()
+			}
+		}()
+	}
+	for i := range f.CashLetters {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// TotalAmount returns the sum in cents of the TotalAmount of every CashLetter in the File, which is the
+// FileTotalAmount calculated by Create.
+func (f *File) TotalAmount() int64 {
+	if f == nil {
+		return 0
+	}
+	var total int64
+	for i := range f.CashLetters {
+		total = total + f.CashLetters[i].TotalAmount()
+	}
+	return total
+}
+
+// IsTest returns true if the FileHeader TestFileIndicator marks the File as a test file. A File with
+// an invalid TestFileIndicator is neither a test nor a production file.
+func (f *File) IsTest() bool {
+	return f != nil && f.Header.IsTest()
+}
+
+// IsProduction returns true if the FileHeader TestFileIndicator marks the File as a production file
+func (f *File) IsProduction() bool {
+	return f != nil && f.Header.IsProduction()
+}
+
+// IsForward returns true if the File holds only forward presentment items: each CashLetter and Bundle
+// is forward presentment (CollectionTypeIndicator 00, 01 or 02) or holds only CheckDetail records, and
+// none is a return or holds ReturnDetail records.
+func (f *File) IsForward() bool {
+	forward, returns := f.itemKinds()
+	return forward && !returns
+}
+
+// IsReturns returns true if the File holds only returns: each CashLetter and Bundle is a return or
+// return notification (CollectionTypeIndicator 03 through 06) or holds only ReturnDetail records, and
+// none is forward presentment or holds CheckDetail records.
+func (f *File) IsReturns() bool {
+	forward, returns := f.itemKinds()
+	return returns && !forward
+}
+
+// ContainsMixed returns true if the File holds both forward presentment items and returns, by the
+// CollectionTypeIndicators and records IsForward and IsReturns consider. A File which fails the checks of
+// WithCollectionTypes because a forward presentment CashLetter holds returns is mixed.
+func (f *File) ContainsMixed() bool {
+	forward, returns := f.itemKinds()
+	return forward && returns
+}
+
+// itemKinds reports whether the File holds forward presentment items and returns, from the
+// CollectionTypeIndicator of each CashLetterHeader and BundleHeader and the records of each Bundle
+func (f *File) itemKinds() (forward bool, returns bool) {
+	if f == nil {
+		return false, false
+	}
+	kind := func(code string) {
+		forward = forward || isForwardCollectionType(code)
+		returns = returns || isReturnCollectionType(code)
+	}
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		if cl.CashLetterHeader != nil {
+			kind(cl.CashLetterHeader.CollectionTypeIndicator)
+		}
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			if b.BundleHeader != nil {
+				kind(b.BundleHeader.CollectionTypeIndicator)
+			}
+			forward = forward || len(b.Checks) > 0
+			returns = returns || len(b.Returns) > 0
+		}
+	}
+	return forward, returns
+}
+
 // SetHeader allows for header to be built.
 func (f *File) SetHeader(h FileHeader) *File {
 	f.Header = h
@@ -259,10 +847,188 @@ func (f *File) SetHeader(h FileHeader) *File {
 
 // AddCashLetter appends a CashLetter to the imagecashletter.File
 func (f *File) AddCashLetter(cashLetter CashLetter) []CashLetter {
+	f.ResetItemIndex()
 	f.CashLetters = append(f.CashLetters, cashLetter)
 	return f.CashLetters
 }
 
+// Merge appends the CashLetters of others to f and recomputes the FileControl. Each File must have a
+// FileHeader with the same ImmediateOrigin, ImmediateDestination and StandardLevel as f, and every
+// CashLetterID must be unique across the merged File. The merged File holds its own copy of every
+// CashLetter and is renumbered as by Renumber. f is not modified if an error is returned.
+func (f *File) Merge(others ...*File) error {
+	if f == nil {
+		return ErrNilFile
+	}
+	cashLetterIDs := make(map[string]bool)
+	for _, cl := range f.CashLetters {
+		cashLetterIDs[cl.CashLetterHeader.CashLetterID] = true
+	}
+	for _, other := range others {
+		if other == nil {
+			return ErrNilFile
+		}
+		if other.Header.ImmediateOrigin != f.Header.ImmediateOrigin {
+			msg := fmt.Sprintf(msgFileMergeHeader, other.Header.ImmediateOrigin, f.Header.ImmediateOrigin)
+			return &FileError{FieldName: "ImmediateOrigin", Value: other.Header.ImmediateOrigin, Msg: msg}
+		}
+		if other.Header.ImmediateDestination != f.Header.ImmediateDestination {
+			msg := fmt.Sprintf(msgFileMergeHeader, other.Header.ImmediateDestination, f.Header.ImmediateDestination)
+			return &FileError{FieldName: "ImmediateDestination", Value: other.Header.ImmediateDestination, Msg: msg}
+		}
+		if other.Header.StandardLevel != f.Header.StandardLevel {
+			msg := fmt.Sprintf(msgFileMergeHeader, other.Header.StandardLevel, f.Header.StandardLevel)
+			return &FileError{FieldName: "StandardLevel", Value: other.Header.StandardLevel, Msg: msg}
+		}
+		for _, cl := range other.CashLetters {
+			cashLetterID := cl.CashLetterHeader.CashLetterID
+			if cashLetterIDs[cashLetterID] {
+				msg := fmt.Sprintf(msgFileCashLetterID, cashLetterID)
+				return &FileError{FieldName: "CashLetterID", Value: cashLetterID, Msg: msg}
+			}
+			cashLetterIDs[cashLetterID] = true
+		}
+	}
+
+	merged := *f
+	merged.CashLetters = make([]CashLetter, 0, len(f.CashLetters))
+	for i := range f.CashLetters {
+		merged.CashLetters = append(merged.CashLetters, f.CashLetters[i].copy())
+	}
+	for _, other := range others {
+		for i := range other.CashLetters {
+			merged.CashLetters = append(merged.CashLetters, other.CashLetters[i].copy())
+		}
+		merged.imageDataSkipped = merged.imageDataSkipped || other.imageDataSkipped
+	}
+	merged.Renumber()
+	if err := merged.Create(); err != nil {
+		return err
+	}
+	*f = merged
+	return nil
+}
+
+// CashLetterFile returns a new File containing only the CashLetter at index, with a copy of the
+// FileHeader and a FileControl computed for that CashLetter.
+func (f *File) CashLetterFile(index int) (*File, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	if index < 0 || index >= len(f.CashLetters) {
+		msg := fmt.Sprintf(msgFileCashLetterIndex, len(f.CashLetters))
+		return nil, &FileError{FieldName: "CashLetters", Value: strconv.Itoa(index), Msg: msg}
+	}
+	return f.cashLetterFile(f.CashLetters[index : index+1])
+}
+
+// Split partitions the CashLetters of f across as few Files as possible where each File encodes
+// to at most maxBytes. A CashLetter is never divided between Files. Each File has a copy of the
+// FileHeader and a FileControl computed for its CashLetters.
+func (f *File) Split(maxBytes int) ([]*File, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	// FileHeader and FileControl are fixed length records written in every File
+	overhead := len(f.Header.String()) + len(f.Control.String()) + 2
+
+	var files []*File
+	start, size := 0, overhead
+	for i := range f.CashLetters {
+		n, err := encodedCashLetterSize(f.CashLetters[i])
+		if err != nil {
+			return nil, err
+		}
+		if overhead+n > maxBytes {
+			msg := fmt.Sprintf(msgFileSplitSize, overhead+n, maxBytes)
+			return nil, &FileError{FieldName: "CashLetterID", Value: f.CashLetters[i].CashLetterHeader.CashLetterID, Msg: msg}
+		}
+		if size+n > maxBytes {
+			file, err := f.cashLetterFile(f.CashLetters[start:i])
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			start, size = i, overhead
+		}
+		size = size + n
+	}
+	if start < len(f.CashLetters) {
+		file, err := f.cashLetterFile(f.CashLetters[start:])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// SplitByCashLetter returns a File for each CashLetter of f as CashLetterFiles does, regardless of its size.
+// Each File has its own copy of the CashLetter's records and passes Validate on its own when f is valid.
+// An empty slice is returned if any of the Files can not be created; use CashLetterFiles for the error.
+func (f *File) SplitByCashLetter() []*File {
+	files, err := f.CashLetterFiles()
+	if err != nil {
+		return []*File{}
+	}
+	return files
+}
+
+// CashLetterFiles returns a complete File for each CashLetter of f, regardless of its size, so each
+// CashLetter can be sent as its own file. Each File has a copy of the FileHeader, a FileControl computed
+// for its CashLetter and a copy of the CashLetter's records, including ImageData, which it shares with
+// neither f nor the other Files. When f is valid each File passes Validate. The error of the first
+// CashLetter whose File can't be created is returned.
+func (f *File) CashLetterFiles() ([]*File, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	files := make([]*File, 0, len(f.CashLetters))
+	for i := range f.CashLetters {
+		file, err := f.cashLetterFile([]CashLetter{f.CashLetters[i].copy()})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// encodedCashLetterSize returns the number of bytes Writer uses to encode cl
+func encodedCashLetterSize(cl CashLetter) (int, error) {
+	var counter byteCounter
+	w := NewWriter(&counter)
+	if err := w.writeCashLetter(&File{CashLetters: []CashLetter{cl}}); err != nil {
+		return 0, err
+	}
+	if err := w.w.Flush(); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// byteCounter is an io.Writer which counts the bytes written to it
+type byteCounter struct {
+	n int
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n = c.n + len(p)
+	return len(p), nil
+}
+
+// cashLetterFile returns a new File with the FileHeader of f containing cashLetters
+func (f *File) cashLetterFile(cashLetters []CashLetter) (*File, error) {
+	file := NewFile()
+	file.SetHeader(f.Header)
+	file.CashLetters = append(file.CashLetters, cashLetters...)
+	file.imageDataSkipped = f.imageDataSkipped
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
 // CashLetterIDUnique verifies multiple CashLetters in a file have a unique CashLetterID
 func (f *File) CashLetterIDUnique() error {
 	if f == nil || len(f.CashLetters) == 0 {
@@ -279,6 +1045,201 @@ func (f *File) CashLetterIDUnique() error {
 	return nil
 }
 
+// AllCheckDetails returns every CheckDetail in the File in the order they appear.
+func (f *File) AllCheckDetails() []*CheckDetail {
+	var checks []*CheckDetail
+	f.ForEachItem(func(_ *CashLetter, _ *Bundle, cd *CheckDetail) error {
+		checks = append(checks, cd)
+		return nil
+	})
+	return checks
+}
+
+// AllReturnDetails returns every ReturnDetail in the File in the order they appear.
+func (f *File) AllReturnDetails() []*ReturnDetail {
+	var returns []*ReturnDetail
+	f.ForEachReturnItem(func(_ *CashLetter, _ *Bundle, rd *ReturnDetail) error {
+		returns = append(returns, rd)
+		return nil
+	})
+	return returns
+}
+
+// ForEachItem calls fn with each CheckDetail in the File along with its CashLetter and Bundle.
+// Iteration stops at the first error returned by fn, which is returned.
+func (f *File) ForEachItem(fn func(cl *CashLetter, b *Bundle, cd *CheckDetail) error) error {
+	if f == nil {
+		return nil
+	}
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			for _, cd := range b.Checks {
+				if err := fn(cl, b, cd); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ForEachReturnItem calls fn with each ReturnDetail in the File along with its CashLetter and Bundle.
+// Iteration stops at the first error returned by fn, which is returned.
+func (f *File) ForEachReturnItem(fn func(cl *CashLetter, b *Bundle, rd *ReturnDetail) error) error {
+	if f == nil {
+		return nil
+	}
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			for _, rd := range b.Returns {
+				if err := fn(cl, b, rd); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FindCheckDetailBySequence returns the first CheckDetail in the File with an
+// EceInstitutionItemSequenceNumber matching seq. Leading zeros and surrounding spaces are ignored.
+//
+// An index of the File's items is built on the first lookup and reused afterwards. Items added
+// or changed by other means than AddCashLetter or Merge after a lookup are not found, so call
+// ResetItemIndex after modifying the File's items. Lookups may be made by concurrent goroutines, but
+// not while the File is being modified.
+func (f *File) FindCheckDetailBySequence(seq string) (*CheckDetail, bool) {
+	if f == nil {
+		return nil, false
+	}
+	cd, ok := f.buildItemIndex().checks[normalizeSequenceNumber(seq)]
+	return cd, ok
+}
+
+// FindReturnDetailBySequence returns the first ReturnDetail in the File with an
+// EceInstitutionItemSequenceNumber matching seq. See FindCheckDetailBySequence for details.
+func (f *File) FindReturnDetailBySequence(seq string) (*ReturnDetail, bool) {
+	if f == nil {
+		return nil, false
+	}
+	rd, ok := f.buildItemIndex().returns[normalizeSequenceNumber(seq)]
+	return rd, ok
+}
+
+// ResetItemIndex discards the index used by FindCheckDetailBySequence and FindReturnDetailBySequence.
+func (f *File) ResetItemIndex() {
+	if f != nil {
+		f.itemIndex.Store((*itemIndex)(nil))
+	}
+}
+
+// buildItemIndex returns the index of the File's items, building and storing it if there isn't one.
+// Goroutines which build it at the same time each store an identical index.
+func (f *File) buildItemIndex() *itemIndex {
+	if idx, _ := f.itemIndex.Load().(*itemIndex); idx != nil {
+		return idx
+	}
+	idx := &itemIndex{
+		checks:  make(map[string]*CheckDetail),
+		returns: make(map[string]*ReturnDetail),
+	}
+	f.ForEachItem(func(_ *CashLetter, _ *Bundle, cd *CheckDetail) error {
+		seq := normalizeSequenceNumber(cd.EceInstitutionItemSequenceNumber)
+		if _, exists := idx.checks[seq]; !exists {
+			idx.checks[seq] = cd
+		}
+		return nil
+	})
+	f.ForEachReturnItem(func(_ *CashLetter, _ *Bundle, rd *ReturnDetail) error {
+		seq := normalizeSequenceNumber(rd.EceInstitutionItemSequenceNumber)
+		if _, exists := idx.returns[seq]; !exists {
+			idx.returns[seq] = rd
+		}
+		return nil
+	})
+	f.itemIndex.Store(idx)
+	return idx
+}
+
+// normalizeSequenceNumber removes padding from an item sequence number
+func normalizeSequenceNumber(seq string) string {
+	seq = strings.TrimLeft(strings.TrimSpace(seq), "0")
+	if seq == "" {
+		return "0"
+	}
+	return seq
+}
+
+// Renumber rewrites the sequence numbers of the File so they are contiguous: bundles are numbered
+// 1..n within each CashLetter, and CheckDetail and ReturnDetail records 1..n within each Bundle.
+// CreditItem and Credit records are numbered 1..n within each CashLetter. Addendum and
+// ImageViewData sequence numbers which matched their detail's previous sequence number are
+// updated to the new value. CashLetterHeader has no sequence number, so CashLetterID is unchanged.
+//
+// Renumber is typically called after Merge, and before Create when the control records should
+// reflect the renumbered File.
+func (f *File) Renumber() {
+	if f == nil {
+		return
+	}
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		for x, ci := range cl.allCreditItems() {
+			ci.CreditItemSequenceNumber = strconv.Itoa(x + 1)
+		}
+		for x, cr := range cl.allCredits() {
+			cr.EceInstitutionItemSequenceNumber = strconv.Itoa(x + 1)
+		}
+		for x, b := range cl.Bundles {
+			if b.BundleHeader != nil {
+				b.BundleHeader.SetBundleSequenceNumber(x + 1)
+			}
+			seq := 1
+			for _, cd := range b.Checks {
+				cd.renumber(seq)
+				seq++
+			}
+			for _, rd := range b.Returns {
+				rd.renumber(seq)
+				seq++
+			}
+		}
+	}
+	f.ResetItemIndex()
+}
+
+// AverageImageBytes returns the mean length of ImageData across all ImageViewData records
+// in the File, or 0 if the File contains no ImageViewData records.
+func (f *File) AverageImageBytes() float64 {
+	total, count := 0, 0
+	f.ForEachItem(func(_ *CashLetter, _ *Bundle, cd *CheckDetail) error {
+		for _, ivData := range cd.ImageViewData {
+			total += len(ivData.ImageData)
+			count++
+		}
+		return nil
+	})
+	f.ForEachReturnItem(func(_ *CashLetter, _ *Bundle, rd *ReturnDetail) error {
+		for _, ivData := range rd.ImageViewData {
+			total += len(ivData.ImageData)
+			count++
+		}
+		return nil
+	})
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
+
 func (f *File) setRecordTypes() {
 	if f == nil {
 		return
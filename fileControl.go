@@ -6,11 +6,12 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
-// FileControl Record
+// FileControl Record. Unlike the FileHeader, it does not identify the immediate origin or destination of the
+// File: its fields are the totals of the File, which are validated against its CashLetters, and a contact at
+// the institution which created it.
 type FileControl struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
@@ -38,6 +39,8 @@ type FileControl struct {
 	CreditTotalIndicator int `json:"creditTotalIndicator"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -56,10 +59,15 @@ func (fc *FileControl) setRecordType() {
 		return
 	}
 
-	fc.recordType = "99"
+	fc.recordType = RecordTypeFileControl
 	fc.reserved = "               "
 }
 
+// RecordType returns the record type of the FileControl, which is RecordTypeFileControl once set by NewFileControl or Parse
+func (fc *FileControl) RecordType() string {
+	return fc.recordType
+}
+
 // Parse takes the input record string and parses the FileControl values
 func (fc *FileControl) Parse(record string) {
 	if utf8.RuneCountInString(record) < 65 {
@@ -74,7 +82,8 @@ func (fc *FileControl) Parse(record string) {
 	// 17-24
 	fc.TotalItemCount = fc.parseNumField(record[16:24])
 	// 25-40
-	fc.FileTotalAmount = fc.parseNumField(record[24:40])
+	fileTotalAmount, _ := fc.parseAmount(record[24:40])
+	fc.FileTotalAmount = int(fileTotalAmount)
 	// 41-54
 	fc.ImmediateOriginContactName = fc.parseStringField(record[40:54])
 	// 55-64
@@ -82,23 +91,26 @@ func (fc *FileControl) Parse(record string) {
 	// 65-65
 	fc.CreditTotalIndicator = fc.parseNumField(record[64:65])
 	// 66-80 reserved - Leave blank
-	fc.reserved = "               "
+	fc.reserved = fc.parseReservedField(record, 65, 80)
 }
 
 // String writes the FileControl struct to a string.
 func (fc *FileControl) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(fc.recordType)
-	buf.WriteString(fc.CashLetterCountField())
-	buf.WriteString(fc.TotalRecordCountField())
-	buf.WriteString(fc.TotalItemCountField())
-	buf.WriteString(fc.FileTotalAmountField())
-	buf.WriteString(fc.ImmediateOriginContactNameField())
-	buf.WriteString(fc.ImmediateOriginContactPhoneNumberField())
-	buf.WriteString(fc.CreditTotalIndicatorField())
-	buf.WriteString(fc.reservedField())
-	return buf.String()
+	return string(fc.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the FileControl record, as String returns it, to b and returns the extended buffer.
+func (fc *FileControl) AppendTo(b []byte) []byte {
+	b = append(b, fc.recordType...)
+	b = fc.appendNumericField(b, fc.CashLetterCount, 6)
+	b = fc.appendNumericField(b, fc.TotalRecordCount, 8)
+	b = fc.appendNumericField(b, fc.TotalItemCount, 8)
+	b = fc.appendAmount(b, int64(fc.FileTotalAmount), 16)
+	b = fc.appendAlphaField(b, fc.ImmediateOriginContactName, 14)
+	b = fc.appendAlphaField(b, fc.ImmediateOriginContactPhoneNumber, 10)
+	b = fc.appendNumericField(b, fc.CreditTotalIndicator, 1)
+	b = fc.appendAlphaField(b, fc.reserved, 15)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -107,9 +119,9 @@ func (fc *FileControl) Validate() error {
 	if err := fc.fieldInclusion(); err != nil {
 		return err
 	}
-	if fc.recordType != "99" {
+	if fc.recordType != RecordTypeFileControl {
 		msg := fmt.Sprintf(msgRecordType, 99)
-		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := fc.isAlphanumericSpecial(fc.ImmediateOriginContactName); err != nil {
 		return &FieldError{FieldName: "ImmediateOriginContactName",
@@ -134,27 +146,27 @@ func (fc *FileControl) fieldInclusion() error {
 	if fc.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: fc.recordType,
-			Msg:   msgFieldInclusion + ", did you use FileControl()?"}
+			Msg:   msgFieldInclusion + ", did you use FileControl()?", Err: ErrFieldInclusion}
 	}
 	if fc.CashLetterCount == 0 {
 		return &FieldError{FieldName: "CashLetterCount",
 			Value: fc.CashLetterCountField(),
-			Msg:   msgFieldInclusion + ", did you use FileControl()?"}
+			Msg:   msgFieldInclusion + ", did you use FileControl()?", Err: ErrFieldInclusion}
 	}
 	if fc.TotalRecordCount == 0 {
 		return &FieldError{FieldName: "TotalRecordCount",
 			Value: fc.TotalRecordCountField(),
-			Msg:   msgFieldInclusion + ", did you use FileControl()?"}
+			Msg:   msgFieldInclusion + ", did you use FileControl()?", Err: ErrFieldInclusion}
 	}
 	if fc.TotalItemCount == 0 {
 		return &FieldError{FieldName: "TotalItemCount",
 			Value: fc.TotalItemCountField(),
-			Msg:   msgFieldInclusion + ", did you use FileControl()?"}
+			Msg:   msgFieldInclusion + ", did you use FileControl()?", Err: ErrFieldInclusion}
 	}
 	if fc.FileTotalAmount == 0 {
 		return &FieldError{FieldName: "FileTotalAmount",
 			Value: fc.FileTotalAmountField(),
-			Msg:   msgFieldInclusion + ", did you use FileControl()?"}
+			Msg:   msgFieldInclusion + ", did you use FileControl()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -176,7 +188,23 @@ func (fc *FileControl) TotalItemCountField() string {
 
 // FileTotalAmountField gets a string of FileTotalAmount zero padded
 func (fc *FileControl) FileTotalAmountField() string {
-	return fc.numericField(fc.FileTotalAmount, 16)
+	return fc.formatAmount(int64(fc.FileTotalAmount), 16)
+}
+
+// SetFileTotalAmount sets FileTotalAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 16 digit field.
+func (fc *FileControl) SetFileTotalAmount(cents int64) error {
+	amount, err := fc.amountFromCents("FileTotalAmount", cents, 16)
+	if err != nil {
+		return err
+	}
+	fc.FileTotalAmount = amount
+	return nil
+}
+
+// FileTotalAmountCents returns FileTotalAmount in cents
+func (fc *FileControl) FileTotalAmountCents() int64 {
+	return int64(fc.FileTotalAmount)
 }
 
 // ImmediateOriginContactNameField gets the ImmediateOriginContactName field padded
@@ -265,3 +265,25 @@ func TestFileControlRuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString")
 	}
 }
+
+// TestFileControlSetFileTotalAmount validates setting FileTotalAmount from cents
+func TestFileControlSetFileTotalAmount(t *testing.T) {
+	fc := mockFileControl()
+	if err := fc.SetFileTotalAmount(12345); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if v := fc.FileTotalAmountField(); v != "0000000000012345" {
+		t.Errorf("FileTotalAmount Expected '0000000000012345' got: %v", v)
+	}
+	if v := fc.FileTotalAmountCents(); v != 12345 {
+		t.Errorf("FileTotalAmountCents Expected 12345 got: %v", v)
+	}
+	err := fc.SetFileTotalAmount(-12345)
+	if e, ok := err.(*FieldError); !ok || e.Msg != msgAmountNegative {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = fc.SetFileTotalAmount(10000000000000000)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "FileTotalAmount" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -87,6 +86,8 @@ type FileHeader struct {
 	// A–J Reserved for Canadian use
 	// Other - as defined by clearing arrangements.
 	CompanionDocumentIndicator string `json:"companionDocumentIndicator"`
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for ImageCashLetter data validation
 	validator
 	// converters is composed for ImageCashLetter to golang Converters
@@ -104,10 +105,15 @@ func (fh *FileHeader) setRecordType() {
 	if fh == nil {
 		return
 	}
-	fh.recordType = "01"
+	fh.recordType = RecordTypeFileHeader
 	fh.StandardLevel = "35"
 }
 
+// RecordType returns the record type of the FileHeader, which is RecordTypeFileHeader once set by NewFileHeader or Parse
+func (fh *FileHeader) RecordType() string {
+	return fh.recordType
+}
+
 // Parse takes the input record string and parses the FileHeader values
 func (fh *FileHeader) Parse(record string) {
 	if utf8.RuneCountInString(record) != 80 {
@@ -143,25 +149,50 @@ func (fh *FileHeader) Parse(record string) {
 	fh.CompanionDocumentIndicator = fh.parseStringField(record[79:80])
 }
 
+// parseLayout takes the input record string and parses the FileHeader values in the layout of the
+// StandardLevel level, or of the StandardLevel parsed from record when level is blank
+func (fh *FileHeader) parseLayout(record, level string) {
+	fh.Parse(record)
+	if level == "" {
+		level = fh.StandardLevel
+	}
+	if level == StandardLevelDSTU2003 {
+		// 80-80 is reserved
+		fh.CompanionDocumentIndicator = ""
+	}
+}
+
+// layoutString writes the FileHeader struct to a string in the layout of the StandardLevel level
+func (fh *FileHeader) layoutString(level string) string {
+	record := fh.String()
+	if level == StandardLevelDSTU2003 && len(record) == 80 {
+		return record[:79] + " "
+	}
+	return record
+}
+
 // String writes the FileHeader struct to a string.
 func (fh *FileHeader) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(fh.recordType)
-	buf.WriteString(fh.StandardLevelField())
-	buf.WriteString(fh.TestFileIndicatorField())
-	buf.WriteString(fh.ImmediateDestinationField())
-	buf.WriteString(fh.ImmediateOriginField())
-	buf.WriteString(fh.FileCreationDateField())
-	buf.WriteString(fh.FileCreationTimeField())
-	buf.WriteString(fh.ResendIndicatorField())
-	buf.WriteString(fh.ImmediateDestinationNameField())
-	buf.WriteString(fh.ImmediateOriginNameField())
-	buf.WriteString(fh.FileIDModifierField())
-	buf.WriteString(fh.CountryCodeField())
-	buf.WriteString(fh.UserFieldField())
-	buf.WriteString(fh.CompanionDocumentIndicatorField())
-	return buf.String()
+	return string(fh.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the FileHeader record, as String returns it, to b and returns the extended buffer.
+func (fh *FileHeader) AppendTo(b []byte) []byte {
+	b = append(b, fh.recordType...)
+	b = fh.appendAlphaField(b, fh.StandardLevel, 2)
+	b = fh.appendAlphaField(b, fh.TestFileIndicator, 1)
+	b = fh.appendStringField(b, fh.ImmediateDestination, 9)
+	b = fh.appendStringField(b, fh.ImmediateOrigin, 9)
+	b = fh.appendYYYYMMDDDate(b, fh.FileCreationDate)
+	b = fh.appendSimpleTime(b, fh.FileCreationTime)
+	b = fh.appendAlphaField(b, fh.ResendIndicator, 1)
+	b = fh.appendAlphaField(b, fh.ImmediateDestinationName, 18)
+	b = fh.appendAlphaField(b, fh.ImmediateOriginName, 18)
+	b = fh.appendAlphaField(b, fh.FileIDModifier, 1)
+	b = fh.appendAlphaField(b, fh.CountryCode, 2)
+	b = fh.appendAlphaField(b, fh.UserField, 4)
+	b = fh.appendAlphaField(b, fh.CompanionDocumentIndicator, 1)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -170,9 +201,9 @@ func (fh *FileHeader) Validate() error {
 	if err := fh.fieldInclusion(); err != nil {
 		return err
 	}
-	if fh.recordType != "01" {
+	if fh.recordType != RecordTypeFileHeader {
 		msg := fmt.Sprintf(msgRecordType, 01)
-		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := fh.isStandardLevel(fh.StandardLevel); err != nil {
 		return &FieldError{FieldName: "StandardLevel", Value: fh.StandardLevel, Msg: err.Error()}
@@ -209,6 +240,9 @@ func (fh *FileHeader) Validate() error {
 	if err := fh.isAlphanumericSpecial(fh.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: fh.UserField, Msg: err.Error()}
 	}
+	if err := fh.isDateInRange(fh.FileCreationDate); err != nil {
+		return &FieldError{FieldName: "FileCreationDate", Value: fh.FileCreationDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -218,52 +252,52 @@ func (fh *FileHeader) fieldInclusion() error {
 	if fh.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: fh.recordType,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.StandardLevel == "" {
 		return &FieldError{FieldName: "StandardLevel",
 			Value: fh.StandardLevel,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.TestFileIndicator == "" {
 		return &FieldError{FieldName: "TestFileIndicator",
 			Value: fh.TestFileIndicator,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.ResendIndicator == "" {
 		return &FieldError{FieldName: "ResendIndicator",
 			Value: fh.ResendIndicator,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.ImmediateDestination == "" {
 		return &FieldError{FieldName: "ImmediateDestination",
 			Value: fh.ImmediateDestination,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.ImmediateOrigin == "" {
 		return &FieldError{FieldName: "ImmediateOrigin",
 			Value: fh.ImmediateOrigin,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.ImmediateOriginField() == "000000000" {
 		return &FieldError{FieldName: "ImmediateOrigin",
 			Value: fh.ImmediateOrigin,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.ImmediateDestinationField() == "000000000" {
 		return &FieldError{FieldName: "ImmediateDestination",
 			Value: fh.ImmediateDestination,
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.FileCreationDate.IsZero() {
 		return &FieldError{FieldName: "FileCreationDate",
 			Value: fh.FileCreationDate.String(),
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	if fh.FileCreationTime.IsZero() {
 		return &FieldError{FieldName: "FileCreationTime",
 			Value: fh.FileCreationTime.String(),
-			Msg:   msgFieldInclusion + ", did you use FileHeader()?"}
+			Msg:   msgFieldInclusion + ", did you use FileHeader()?", Err: ErrFieldInclusion}
 	}
 	return nil
 
@@ -333,3 +367,18 @@ func (fh *FileHeader) FileIDModifierField() string {
 func (fh *FileHeader) CompanionDocumentIndicatorField() string {
 	return fh.alphaField(fh.CompanionDocumentIndicator, 1)
 }
+
+// IsTest returns true if the TestFileIndicator marks the file as a test file (T)
+func (fh *FileHeader) IsTest() bool {
+	return fh.TestFileIndicator == "T"
+}
+
+// IsProduction returns true if the TestFileIndicator marks the file as a production file (P)
+func (fh *FileHeader) IsProduction() bool {
+	return fh.TestFileIndicator == "P"
+}
+
+// IsResend returns true if the ResendIndicator marks the file as previously transmitted (Y)
+func (fh *FileHeader) IsResend() bool {
+	return fh.ResendIndicator == "Y"
+}
@@ -433,6 +433,26 @@ func TestFHFieldInclusionCreationDate(t *testing.T) {
 	}
 }
 
+// TestFHCreationDateRange validates FileCreationDate is between 1990 and 2099
+func TestFHCreationDateRange(t *testing.T) {
+	for _, date := range []time.Time{
+		time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		fh := mockFileHeader()
+		fh.FileCreationDate = date
+		err := fh.Validate()
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "FileCreationDate" || e.Msg != msgDateRange {
+			t.Errorf("%s: %T: %s", date, err, err)
+		}
+	}
+	fh := mockFileHeader()
+	fh.FileCreationDate = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := fh.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestFHFieldInclusionCreationTime validates FieldInclusion
 func TestFHFieldInclusionCreationTime(t *testing.T) {
 	fh := mockFileHeader()
@@ -456,3 +476,64 @@ func TestFileHeaderRuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString")
 	}
 }
+
+// TestFileHeaderIndicators validates the StandardLevel, TestFileIndicator and ResendIndicator against
+// their defined values
+func TestFileHeaderIndicators(t *testing.T) {
+	cases := []struct {
+		field string
+		set   func(fh *FileHeader, v string)
+		valid []string
+		bad   []string
+	}{
+		{"StandardLevel", func(fh *FileHeader, v string) { fh.StandardLevel = v }, []string{"03", "30", "35"}, []string{"01", "3", "36", "AB"}},
+		{"TestFileIndicator", func(fh *FileHeader, v string) { fh.TestFileIndicator = v }, []string{"T", "P"}, []string{"t", "p", "S", "Y"}},
+		{"ResendIndicator", func(fh *FileHeader, v string) { fh.ResendIndicator = v }, []string{"Y", "N"}, []string{"y", "n", "R", "1"}},
+	}
+	for _, c := range cases {
+		for _, v := range c.valid {
+			fh := mockFileHeader()
+			c.set(&fh, v)
+			if err := fh.Validate(); err != nil {
+				t.Errorf("%s %q: %T: %s", c.field, v, err, err)
+			}
+		}
+		for _, v := range c.bad {
+			fh := mockFileHeader()
+			c.set(&fh, v)
+			err := fh.Validate()
+			if e, ok := err.(*FieldError); !ok || e.FieldName != c.field || e.Value != v {
+				t.Errorf("%s %q: %T: %s", c.field, v, err, err)
+			}
+		}
+	}
+}
+
+// TestFileHeaderIsTest reports whether a FileHeader is for a test, production or resent file
+func TestFileHeaderIsTest(t *testing.T) {
+	fh := mockFileHeader()
+	fh.TestFileIndicator = "T"
+	fh.ResendIndicator = "N"
+	if !fh.IsTest() || fh.IsProduction() || fh.IsResend() {
+		t.Errorf("IsTest=%v IsProduction=%v IsResend=%v", fh.IsTest(), fh.IsProduction(), fh.IsResend())
+	}
+	fh.TestFileIndicator = "P"
+	fh.ResendIndicator = "Y"
+	if fh.IsTest() || !fh.IsProduction() || !fh.IsResend() {
+		t.Errorf("IsTest=%v IsProduction=%v IsResend=%v", fh.IsTest(), fh.IsProduction(), fh.IsResend())
+	}
+
+	file := NewFile()
+	file.SetHeader(fh)
+	if file.IsTest() || !file.IsProduction() {
+		t.Errorf("IsTest=%v IsProduction=%v", file.IsTest(), file.IsProduction())
+	}
+	file.Header.TestFileIndicator = "S"
+	if file.IsTest() || file.IsProduction() {
+		t.Errorf("IsTest=%v IsProduction=%v", file.IsTest(), file.IsProduction())
+	}
+	var nilFile *File
+	if nilFile.IsTest() || nilFile.IsProduction() {
+		t.Error("nil File is neither test nor production")
+	}
+}
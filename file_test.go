@@ -5,9 +5,19 @@
 package imagecashletter
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // mockFile creates an imagecashletter file
@@ -55,3 +65,1156 @@ func TestFile__FileFromJSON(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+// TestFile__JSONStable marshals the same File repeatedly and expects identical JSON
+func TestFile__JSONStable(t *testing.T) {
+	readJSON := func() []byte {
+		fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fd.Close()
+		file, err := NewReader(fd).Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := json.Marshal(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bs
+	}
+
+	expected := readJSON()
+	for i := 0; i < 5; i++ {
+		if bs := readJSON(); !bytes.Equal(bs, expected) {
+			t.Fatalf("JSON changed on marshal %d", i)
+		}
+	}
+
+	// JSON read by FileFromJSON is written back the same
+	file, err := FileFromJSON(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bs, expected) {
+		t.Errorf("JSON changed after FileFromJSON:\n%s\n%s", bs, expected)
+	}
+}
+
+func TestFile__AverageImageBytes(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// one 12 byte image ("hello, world") and seven 1 byte images
+	if avg := file.AverageImageBytes(); avg != 2.375 {
+		t.Errorf("unexpected average image bytes: %v", avg)
+	}
+
+	if avg := mockFile().AverageImageBytes(); avg != 0 {
+		t.Errorf("expected 0 for a file without images, got %v", avg)
+	}
+}
+
+// mockMergeFile creates an imagecashletter file with a bundle of checks in a single cash letter
+func mockMergeFile(cashLetterID string) *File {
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	clh := mockCashLetterHeader()
+	clh.CashLetterID = cashLetterID
+	cl := NewCashLetter(clh)
+	cl.AddBundle(mockBundleChecks())
+	if err := cl.Create(); err != nil {
+		panic(err)
+	}
+	file.AddCashLetter(cl)
+	if err := file.Create(); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+func TestFile__Merge(t *testing.T) {
+	file := mockMergeFile("A1")
+	other := mockMergeFile("A2")
+	other.CashLetters[0].Bundles[0].BundleHeader.BundleSequenceNumber = "7"
+	if err := file.Merge(other); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if seq := file.CashLetters[1].Bundles[0].BundleHeader.BundleSequenceNumber; seq != "1" {
+		t.Errorf("BundleSequenceNumber %q, expected 1", seq)
+	}
+	file.CashLetters[1].Bundles[0].Checks[0].ItemAmount++
+	if other.CashLetters[0].Bundles[0].Checks[0].ItemAmount == file.CashLetters[1].Bundles[0].Checks[0].ItemAmount {
+		t.Error("merged File shares records with the File merged into it")
+	}
+	file.CashLetters[1].Bundles[0].Checks[0].ItemAmount--
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if file.Control.CashLetterCount != 2 {
+		t.Errorf("CashLetterCount: %d", file.Control.CashLetterCount)
+	}
+	if file.Control.TotalItemCount != 2*other.Control.TotalItemCount {
+		t.Errorf("TotalItemCount: %d", file.Control.TotalItemCount)
+	}
+	if file.Control.FileTotalAmount != 2*other.Control.FileTotalAmount {
+		t.Errorf("FileTotalAmount: %d", file.Control.FileTotalAmount)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__MergeMismatch(t *testing.T) {
+	file := mockMergeFile("A1")
+
+	other := mockMergeFile("A2")
+	other.Header.ImmediateOrigin = "231380104"
+	err := file.Merge(other)
+	if e, ok := err.(*FileError); !ok || e.FieldName != "ImmediateOrigin" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	other = mockMergeFile("A2")
+	other.Header.StandardLevel = "50"
+	err = file.Merge(other)
+	if e, ok := err.(*FileError); !ok || e.FieldName != "StandardLevel" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	err = file.Merge(mockMergeFile("A1"))
+	if e, ok := err.(*FileError); !ok || e.FieldName != "CashLetterID" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	if len(file.CashLetters) != 1 {
+		t.Errorf("file was modified: %d cash letters", len(file.CashLetters))
+	}
+}
+
+func TestFile__Renumber(t *testing.T) {
+	file := mockMergeFile("A1")
+	if err := file.Merge(mockMergeFile("A2")); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// duplicate and gapped sequence numbers
+	for i := range file.CashLetters {
+		cl := &file.CashLetters[i]
+		cl.AddBundle(mockBundleReturns())
+		for _, b := range cl.Bundles {
+			b.BundleHeader.BundleSequenceNumber = "7"
+		}
+		b := cl.Bundles[0]
+		second := mockCheckDetail()
+		second.AddCheckDetailAddendumA(mockCheckDetailAddendumA())
+		second.AddCheckDetailAddendumB(mockCheckDetailAddendumB())
+		second.AddCheckDetailAddendumC(mockCheckDetailAddendumC())
+		b.AddCheckDetail(second)
+		for _, cd := range b.Checks {
+			cd.EceInstitutionItemSequenceNumber = "000000000000009"
+			cd.CheckDetailAddendumA[0].BOFDItemSequenceNumber = "9"
+			cd.CheckDetailAddendumC[0].EndorsingBankItemSequenceNumber = "42"
+		}
+	}
+
+	file.Renumber()
+
+	for _, cl := range file.CashLetters {
+		for x, b := range cl.Bundles {
+			if want := strconv.Itoa(x + 1); b.BundleHeader.BundleSequenceNumber != want {
+				t.Errorf("BundleSequenceNumber %q, expected %q", b.BundleHeader.BundleSequenceNumber, want)
+			}
+		}
+		for x, cd := range cl.Bundles[0].Checks {
+			want := strconv.Itoa(x + 1)
+			if cd.EceInstitutionItemSequenceNumber != want {
+				t.Errorf("EceInstitutionItemSequenceNumber %q, expected %q", cd.EceInstitutionItemSequenceNumber, want)
+			}
+			if seq := cd.CheckDetailAddendumA[0].BOFDItemSequenceNumber; seq != want {
+				t.Errorf("BOFDItemSequenceNumber %q, expected %q", seq, want)
+			}
+			// does not mirror the CheckDetail sequence number
+			if seq := cd.CheckDetailAddendumC[0].EndorsingBankItemSequenceNumber; seq != "42" {
+				t.Errorf("EndorsingBankItemSequenceNumber %q, expected 42", seq)
+			}
+		}
+		if seq := cl.Bundles[1].Returns[0].EceInstitutionItemSequenceNumber; seq != "1" {
+			t.Errorf("ReturnDetail EceInstitutionItemSequenceNumber %q, expected 1", seq)
+		}
+	}
+	if _, ok := file.FindCheckDetailBySequence("2"); !ok {
+		t.Error("expected to find renumbered CheckDetail 2")
+	}
+
+	if err := file.Recalculate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__CashLetterFile(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range file.CashLetters {
+		clFile, err := file.CashLetterFile(i)
+		if err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if err := clFile.ValidateWith(WithCountTolerance(0)); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+		if n := len(clFile.CashLetters); n != 1 {
+			t.Fatalf("cash letter %d: unexpected %d cash letters", i, n)
+		}
+		if id := clFile.CashLetters[0].CashLetterHeader.CashLetterID; id != file.CashLetters[i].CashLetterHeader.CashLetterID {
+			t.Errorf("cash letter %d: unexpected CashLetterID %s", i, id)
+		}
+		if clFile.Header != file.Header {
+			t.Errorf("cash letter %d: FileHeader mismatch", i)
+		}
+	}
+
+	for _, index := range []int{-1, len(file.CashLetters)} {
+		if _, err := file.CashLetterFile(index); err != nil {
+			if e, ok := err.(*FileError); !ok || e.FieldName != "CashLetters" {
+				t.Errorf("%T: %s", err, err)
+			}
+		} else {
+			t.Errorf("index %d: expected error", index)
+		}
+	}
+}
+
+func TestFile__Split(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the whole file fits in one part
+	parts, err := file.Split(len(bs) + 1)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(parts) != 1 || len(parts[0].CashLetters) != 2 {
+		t.Fatalf("unexpected parts: %d", len(parts))
+	}
+
+	// each cash letter must go in its own part
+	parts, err = file.Split(len(bs) - 1)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("unexpected parts: %d", len(parts))
+	}
+	for i := range parts {
+		if err := parts[i].Validate(); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+		var buf bytes.Buffer
+		if err := NewWriter(&buf).Write(parts[i]); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() > len(bs)-1 {
+			t.Errorf("part %d is %d bytes", i, buf.Len())
+		}
+	}
+
+	// the parts recombine to the original contents
+	merged := parts[0]
+	if err := merged.Merge(parts[1:]...); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if merged.Control.CashLetterCount != file.Control.CashLetterCount ||
+		merged.Control.TotalRecordCount != file.Control.TotalRecordCount ||
+		merged.Control.TotalItemCount != file.Control.TotalItemCount ||
+		merged.Control.FileTotalAmount != file.Control.FileTotalAmount {
+		t.Errorf("FileControl mismatch:\n %#v\n %#v", merged.Control, file.Control)
+	}
+	for i := range file.CashLetters {
+		if merged.CashLetters[i].CashLetterHeader.CashLetterID != file.CashLetters[i].CashLetterHeader.CashLetterID {
+			t.Errorf("cash letter %d out of order", i)
+		}
+	}
+
+	// a single cash letter is too large
+	if _, err := file.Split(100); err != nil {
+		if e, ok := err.(*FileError); !ok || e.FieldName != "CashLetterID" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+}
+
+func TestFile__SplitByCashLetter(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := file.SplitByCashLetter()
+	if len(parts) != len(file.CashLetters) {
+		t.Fatalf("unexpected parts: %d", len(parts))
+	}
+	for i := range parts {
+		if err := parts[i].Validate(); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+	}
+	if err := parts[0].Merge(parts[1:]...); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if parts[0].Control.TotalItemCount != file.Control.TotalItemCount {
+		t.Errorf("TotalItemCount %d != %d", parts[0].Control.TotalItemCount, file.Control.TotalItemCount)
+	}
+
+	// the parts share no records with file
+	parts = file.SplitByCashLetter()
+	parts[0].CashLetters[0].Bundles[0].Checks[0].ItemAmount++
+	if parts[0].CashLetters[0].Bundles[0].Checks[0].ItemAmount == file.CashLetters[0].Bundles[0].Checks[0].ItemAmount {
+		t.Error("file shares records with its parts")
+	}
+
+	// an empty result rather than nil when a part can't be created
+	file.CashLetters[1].CashLetterHeader.RecordTypeIndicator = "N"
+	if parts := file.SplitByCashLetter(); parts == nil || len(parts) != 0 {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+}
+
+func TestFile__CashLetterFiles(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := file.CashLetterFiles()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(files) != len(file.CashLetters) {
+		t.Fatalf("unexpected files: %d", len(files))
+	}
+	for i := range files {
+		if err := files[i].ValidateWith(WithCountTolerance(0)); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+		if n := len(files[i].CashLetters); n != 1 {
+			t.Fatalf("cash letter %d: unexpected %d cash letters", i, n)
+		}
+		if images := files[i].CashLetters[0].Bundles[0].Checks[0].ImageViewData; len(images) == 0 {
+			t.Fatalf("cash letter %d: expected image view data", i)
+		}
+	}
+
+	// the records of each File are copies
+	cd := files[0].CashLetters[0].Bundles[0].Checks[0]
+	cd.PayorBankRoutingNumber = "99999999"
+	cd.CheckDetailAddendumA[0].BOFDAccountNumber = "changed"
+	cd.ImageViewData[0].ImageData[0]++
+	files[0].CashLetters[0].CashLetterHeader.CashLetterID = "ZZ"
+	original := file.CashLetters[0].Bundles[0].Checks[0]
+	if original.PayorBankRoutingNumber == cd.PayorBankRoutingNumber ||
+		original.CheckDetailAddendumA[0].BOFDAccountNumber == cd.CheckDetailAddendumA[0].BOFDAccountNumber ||
+		original.ImageViewData[0].ImageData[0] == cd.ImageViewData[0].ImageData[0] ||
+		file.CashLetters[0].CashLetterHeader.CashLetterID == "ZZ" {
+		t.Error("File shares records with the produced File")
+	}
+
+	// the reason a File can't be created is returned
+	file.CashLetters[1].CashLetterHeader.RecordTypeIndicator = "N"
+	_, err = file.CashLetterFiles()
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "RecordTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if _, err := (*File)(nil).CashLetterFiles(); err != ErrNilFile {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// mockMultiBundleFile creates an imagecashletter file with two cash letters of check and return bundles
+func mockMultiBundleFile() *File {
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	for _, id := range []string{"A1", "A2"} {
+		clh := mockCashLetterHeader()
+		clh.CashLetterID = id
+		cl := NewCashLetter(clh)
+		cl.AddBundle(mockBundleChecks())
+		cl.AddBundle(mockBundleReturns())
+		cl.AddBundle(mockBundleChecks())
+		file.AddCashLetter(cl)
+	}
+	if err := file.Create(); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+func TestFile__ForEachItem(t *testing.T) {
+	file := mockMultiBundleFile()
+
+	checks := file.AllCheckDetails()
+	if len(checks) != 4 {
+		t.Errorf("unexpected %d checks", len(checks))
+	}
+	returns := file.AllReturnDetails()
+	if len(returns) != 2 {
+		t.Errorf("unexpected %d returns", len(returns))
+	}
+
+	seen := make(map[*CheckDetail]int)
+	err := file.ForEachItem(func(cl *CashLetter, b *Bundle, cd *CheckDetail) error {
+		if cl == nil || b == nil {
+			t.Error("nil CashLetter or Bundle")
+		}
+		seen[cd]++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cd := range checks {
+		if seen[cd] != 1 {
+			t.Errorf("CheckDetail visited %d times", seen[cd])
+		}
+	}
+	seenReturns := make(map[*ReturnDetail]int)
+	file.ForEachReturnItem(func(_ *CashLetter, _ *Bundle, rd *ReturnDetail) error {
+		seenReturns[rd]++
+		return nil
+	})
+	for _, rd := range returns {
+		if seenReturns[rd] != 1 {
+			t.Errorf("ReturnDetail visited %d times", seenReturns[rd])
+		}
+	}
+
+	// stop on the first error
+	visited := 0
+	stop := errors.New("stop")
+	err = file.ForEachItem(func(_ *CashLetter, _ *Bundle, _ *CheckDetail) error {
+		visited++
+		return stop
+	})
+	if err != stop || visited != 1 {
+		t.Errorf("visited=%d err=%v", visited, err)
+	}
+}
+
+func TestFile__FindBySequence(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := file.CashLetters[0].Bundles[0].Checks[1]
+	for _, seq := range []string{"2", "000000000000002", "  2", "2 ", " 0002 "} {
+		cd, ok := file.FindCheckDetailBySequence(seq)
+		if !ok || cd != first {
+			t.Errorf("%q: CheckDetail not found", seq)
+		}
+	}
+	if _, ok := file.FindCheckDetailBySequence("20"); ok {
+		t.Error("unexpected CheckDetail found")
+	}
+
+	rd, ok := file.FindReturnDetailBySequence("00001")
+	if !ok || rd != file.CashLetters[0].Bundles[1].Returns[0] {
+		t.Error("ReturnDetail not found")
+	}
+	if _, ok := file.FindReturnDetailBySequence("3"); ok {
+		t.Error("unexpected ReturnDetail found")
+	}
+
+	// changes require the index to be reset
+	first.EceInstitutionItemSequenceNumber = "99"
+	if _, ok := file.FindCheckDetailBySequence("99"); ok {
+		t.Error("expected cached index")
+	}
+	file.ResetItemIndex()
+	if cd, ok := file.FindCheckDetailBySequence("99"); !ok || cd != first {
+		t.Error("CheckDetail not found after reset")
+	}
+
+	// concurrent lookups, including the first which builds the index, don't race
+	file.ResetItemIndex()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if cd, ok := file.FindCheckDetailBySequence("99"); !ok || cd != first {
+				t.Error("CheckDetail not found")
+			}
+			if _, ok := file.FindReturnDetailBySequence("1"); !ok {
+				t.Error("ReturnDetail not found")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyRoundTrip(bs); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// CRLF line endings are normalized
+	if err := VerifyRoundTrip(bytes.Replace(bs, []byte("\n"), []byte("\r\n"), -1)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// right-justify the CashLetterID of the second record, which is left-justified when written
+	modified := append([]byte(nil), bs...)
+	secondLine := bytes.IndexByte(modified, '\n') + 1
+	copy(modified[secondLine+44:], " A1")
+	err = VerifyRoundTrip(modified)
+	if e, ok := err.(*FileError); !ok || e.Value != strconv.Itoa(secondLine+44) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// data in reserved fields is kept when read
+	modified = append([]byte(nil), bs...)
+	modified[secondLine+79] = 'X'
+	if err := VerifyRoundTrip(modified); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__VerifyRoundTrip(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.VerifyRoundTrip(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// the time of day of a date field is not written
+	clh := file.CashLetters[1].CashLetterHeader
+	clh.CashLetterBusinessDate = clh.CashLetterBusinessDate.Add(9 * time.Hour)
+	err = file.VerifyRoundTrip()
+	e, ok := err.(*FileError)
+	if !ok || e.FieldName != "RoundTrip" || e.Value != "CashLetters[1].CashLetterHeader.CashLetterBusinessDate" {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !strings.Contains(e.Msg, "1 fields differ") {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var nilFile *File
+	if err := nilFile.VerifyRoundTrip(); err != ErrNilFile {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__XML(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xmlBytes, err := file.XMLBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(xmlBytes, []byte("<ImageData>aGVsbG8sIHdvcmxk</ImageData>")) {
+		t.Errorf("missing base64 ImageData:\n%s", xmlBytes)
+	}
+
+	xmlFile, err := FileFromXML(xmlBytes)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// XML -> File -> XML is stable
+	again, err := xmlFile.XMLBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(xmlBytes, again) {
+		t.Errorf("XML is not stable:\n%s\n%s", xmlBytes, again)
+	}
+
+	// the same logical file from JSON and XML
+	jsonFromJSON, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonFromXML, err := json.Marshal(xmlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(jsonFromJSON, jsonFromXML) {
+		t.Errorf("JSON and XML files differ:\n%s\n%s", jsonFromJSON, jsonFromXML)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(xmlFile); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("hello, world")) {
+		t.Errorf("unexpected ICL file:\n%s", buf.String())
+	}
+
+	if _, err := FileFromXML(nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFile__JSONWithImages(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "icl-images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out, err := file.MarshalJSONWithImages(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte(`"imageData"`)) {
+		t.Errorf("unexpected inline image data:\n%s", out)
+	}
+	// identical images are only written once
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 {
+		t.Errorf("unexpected %d image files", len(infos))
+	}
+
+	read, err := FileFromJSONWithImages(out, dir)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	expected, actual := file.AllCheckDetails(), read.AllCheckDetails()
+	if len(expected) != len(actual) {
+		t.Fatalf("%d checks != %d", len(actual), len(expected))
+	}
+	for i := range expected {
+		for j := range expected[i].ImageViewData {
+			if !bytes.Equal(expected[i].ImageViewData[j].ImageData, actual[i].ImageViewData[j].ImageData) {
+				t.Errorf("check %d image %d: %q != %q", i, j, actual[i].ImageViewData[j].ImageData, expected[i].ImageViewData[j].ImageData)
+			}
+		}
+	}
+
+	// references may only name files in dir
+	bad := bytes.Replace(out, []byte(`"imageDataRef":"`), []byte(`"imageDataRef":"../`), 1)
+	if _, err := FileFromJSONWithImages(bad, dir); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFile__JSONMetadataOnly(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := file.MarshalJSONMetadataOnly()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte(`"imageData"`)) {
+		t.Errorf("unexpected image data:\n%s", out)
+	}
+	for _, cd := range file.AllCheckDetails() {
+		for _, ivData := range cd.ImageViewData {
+			encoded := base64.StdEncoding.EncodeToString(ivData.ImageData)
+			if len(encoded) > 0 && bytes.Contains(out, []byte(encoded)) {
+				t.Errorf("unexpected image %s", encoded)
+			}
+		}
+	}
+
+	read, err := FileFromJSON(out)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if s := read.Summary(); s != file.Summary() {
+		t.Errorf("got %+v", s)
+	}
+	expected, actual := file.AllCheckDetails(), read.AllCheckDetails()
+	if len(expected) != len(actual) {
+		t.Fatalf("%d checks != %d", len(actual), len(expected))
+	}
+	for i := range expected {
+		if len(expected[i].ImageViewData) != len(actual[i].ImageViewData) {
+			t.Fatalf("check %d: %d images != %d", i, len(actual[i].ImageViewData), len(expected[i].ImageViewData))
+		}
+		for j := range expected[i].ImageViewData {
+			ivData := actual[i].ImageViewData[j]
+			if len(ivData.ImageData) != 0 {
+				t.Errorf("check %d image %d: unexpected ImageData", i, j)
+			}
+			if ivData.LengthImageData != expected[i].ImageViewData[j].LengthImageData {
+				t.Errorf("check %d image %d: LengthImageData %s", i, j, ivData.LengthImageData)
+			}
+		}
+	}
+
+	var nilFile *File
+	if _, err := nilFile.MarshalJSONMetadataOnly(); err != ErrNilFile {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestFile__FileFromJSONReader(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "icl-valid.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSONReader(bytes.NewReader(bs))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	expectedJSON, _ := json.Marshal(expected)
+	fileJSON, _ := json.Marshal(file)
+	if !bytes.Equal(expectedJSON, fileJSON) {
+		t.Errorf("FileFromJSON and FileFromJSONReader differ:\n%s\n%s", expectedJSON, fileJSON)
+	}
+
+	if _, err := FileFromJSONReader(strings.NewReader("")); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := FileFromJSONReader(strings.NewReader("{")); err == nil {
+		t.Error("expected error")
+	}
+	// the document ends within an image
+	if _, err := FileFromJSONReader(bytes.NewReader(bs[:bytes.Index(bs, []byte(`"imageData": "`))+20])); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// TestFile__FileFromJSONReaderImages validates FileFromJSONReader reads the images of many image views, each
+// decoded as it's read, as FileFromJSON does
+func TestFile__FileFromJSONReaderImages(t *testing.T) {
+	for _, name := range []string{"base64-encoded-images.json", "icl-valid.json"} {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := FileFromJSON(bs)
+		if err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+		file, err := FileFromJSONReader(bytes.NewReader(bs))
+		if err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+		if !file.Equal(expected) {
+			t.Errorf("%s: %v", name, file.Diff(expected))
+		}
+	}
+
+	file := mockImagesFile(20, 4096)
+	bs, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// escaped slashes are allowed in base64 strings
+	bs = bytes.Replace(bs, []byte("/"), []byte(`\/`), -1)
+	read, err := FileFromJSONReader(bytes.NewReader(bs))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !read.Equal(file) {
+		t.Errorf("%v", read.Diff(file))
+	}
+}
+
+// mockImagesFile creates a File with a Bundle of n checks, each with an image of size bytes
+func mockImagesFile(n, size int) *File {
+	file := mockMergeFile("A1")
+	b := file.CashLetters[0].Bundles[0]
+	template := b.Checks[0]
+	b.Checks = nil
+	for i := 0; i < n; i++ {
+		cd := *template
+		image := make([]byte, size)
+		for j := range image {
+			image[j] = byte(i + j*7)
+		}
+		cd.ImageViewData = []ImageViewData{template.ImageViewData[0]}
+		cd.ImageViewData[0].ImageData = image
+		cd.ImageViewData[0].LengthImageData = strconv.Itoa(size)
+		b.Checks = append(b.Checks, &cd)
+	}
+	if err := file.Recalculate(); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+func BenchmarkFileFromJSON(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "icl-valid.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSON(bs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFileFromJSONLarge reads a document of 100 images, about 26MB, with FileFromJSON, which holds the document
+// and decodes it whole, for comparison with BenchmarkFileFromJSONReaderLarge
+func BenchmarkFileFromJSONLarge(b *testing.B) {
+	bs, err := json.Marshal(mockImagesFile(100, 192*1024))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(bs)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSON(bs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFileFromJSONReaderLarge reads a document of 100 images, about 26MB, with FileFromJSONReader. Beyond the
+// images of the File returned only one image and record are held at a time, so the bytes allocated are
+// about twice the size of the images, compared to several times the document size for FileFromJSON.
+func BenchmarkFileFromJSONReaderLarge(b *testing.B) {
+	bs, err := json.Marshal(mockImagesFile(100, 192*1024))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(bs)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSONReader(bytes.NewReader(bs)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFileFromJSONReader(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "icl-valid.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FileFromJSONReader(bytes.NewReader(bs)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// mockManyCashLetterFile creates a File with n CashLetters
+func mockManyCashLetterFile(n int) *File {
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	for i := 0; i < n; i++ {
+		clh := mockCashLetterHeader()
+		clh.CashLetterID = "A" + strconv.Itoa(i)
+		cl := NewCashLetter(clh)
+		cl.AddBundle(mockBundleChecks())
+		cl.AddBundle(mockBundleReturns())
+		if err := cl.Create(); err != nil {
+			panic(err)
+		}
+		file.AddCashLetter(cl)
+	}
+	if err := file.Create(); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+func TestFile__ValidateConcurrent(t *testing.T) {
+	file := mockManyCashLetterFile(20)
+	for _, workers := range []int{0, 1, 4, 50} {
+		if err := file.ValidateConcurrent(workers); err != nil {
+			t.Errorf("workers=%d: %T: %s", workers, err, err)
+		}
+	}
+
+	file.CashLetters[13].CashLetterHeader.RecordTypeIndicator = "Z"
+	file.CashLetters[3].CashLetterHeader.CollectionTypeIndicator = "50"
+	file.CashLetters[17].Bundles[0].Checks[0].DocumentationTypeIndicator = "Z"
+	for i := 0; i < 10; i++ {
+		err := file.ValidateConcurrent(4)
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "CollectionTypeIndicator" {
+			t.Fatalf("%T: %s", err, err)
+		}
+	}
+}
+
+func benchmarkValidateConcurrent(b *testing.B, workers int) {
+	file := mockManyCashLetterFile(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := file.ValidateConcurrent(workers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateConcurrent__1(b *testing.B) {
+	benchmarkValidateConcurrent(b, 1)
+}
+
+func BenchmarkValidateConcurrent__NumCPU(b *testing.B) {
+	benchmarkValidateConcurrent(b, 0)
+}
+
+func TestFile__TotalAmount(t *testing.T) {
+	file := mockManyCashLetterFile(3)
+	file.CashLetters[1].Bundles[0].Checks[0].ItemAmount = 1
+	file.CashLetters[2].Bundles[1].Returns[0].ItemAmount = 10000000000 // $100,000,000.00
+	credit := mockCreditItem()
+	credit.ItemAmount = 2500
+	file.CashLetters[2].AddCreditItem(credit)
+	for i := range file.CashLetters {
+		if err := file.CashLetters[i].Create(); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+	}
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	for _, cl := range file.CashLetters {
+		for _, b := range cl.Bundles {
+			if b.TotalAmount() != int64(b.BundleControl.BundleTotalAmount) {
+				t.Errorf("Bundle TotalAmount %d, BundleTotalAmount %d", b.TotalAmount(), b.BundleControl.BundleTotalAmount)
+			}
+		}
+		if cl.TotalAmount() != int64(cl.CashLetterControl.CashLetterTotalAmount) {
+			t.Errorf("CashLetter TotalAmount %d, CashLetterTotalAmount %d", cl.TotalAmount(), cl.CashLetterControl.CashLetterTotalAmount)
+		}
+	}
+	if file.TotalAmount() != int64(file.Control.FileTotalAmount) {
+		t.Errorf("File TotalAmount %d, FileTotalAmount %d", file.TotalAmount(), file.Control.FileTotalAmount)
+	}
+
+	check := int64(mockCheckDetail().ItemAmount)
+	ret := int64(mockReturnDetail().ItemAmount)
+	if want := check + 1 + ret*2 + 10000000000 + 2500 + check; file.TotalAmount() != want {
+		t.Errorf("TotalAmount %d, expected %d", file.TotalAmount(), want)
+	}
+	if (*File)(nil).TotalAmount() != 0 || (*Bundle)(nil).TotalAmount() != 0 {
+		t.Error("expected 0 for nil")
+	}
+}
+
+func TestFile__ReservedFieldsRoundTrip(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1-based inclusive positions of the reserved fields of each record type in the file
+	reserved := map[string][][2]int{
+		cashLetterHeaderPos:     {{80, 80}},
+		bundleHeaderPos:         {{69, 80}},
+		checkDetailAddendumAPos: {{78, 80}},
+		checkDetailAddendumCPos: {{61, 80}},
+		returnDetailPos:         {{73, 80}},
+		returnAddendumAPos:      {{78, 80}},
+		returnAddendumDPos:      {{61, 80}},
+		imageViewDetailPos:      {{66, 66}, {68, 80}},
+		imageViewAnalysisPos:    {{13, 25}, {40, 45}, {66, 80}},
+		bundleControlPos:        {{57, 80}},
+		cashLetterControlPos:    {{67, 80}},
+		routingNumberSummaryPos: {{56, 80}},
+		fileControlPos:          {{66, 80}},
+	}
+	lines := strings.Split(string(bs), "\n")
+	for i, line := range lines {
+		for _, pos := range reserved[line[:2]] {
+			lines[i] = line[:pos[0]-1] + strings.Repeat("V", pos[1]-pos[0]+1) + line[pos[1]:]
+			line = lines[i]
+		}
+	}
+	modified := []byte(strings.Join(lines, "\n"))
+	if bytes.Equal(modified, bs) {
+		t.Fatal("expected reserved fields to be modified")
+	}
+	if err := VerifyRoundTrip(modified); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	file, err := NewReader(bytes.NewReader(modified)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if s := file.CashLetters[0].CashLetterHeader.String(); s[79:] != "V" {
+		t.Errorf("CashLetterHeader reserved %q", s[79:])
+	}
+	if s := NewCashLetterHeader().String(); s[79:] != " " {
+		t.Errorf("new CashLetterHeader reserved %q", s[79:])
+	}
+}
+
+// TestFile__WriteToReadFrom validates WriteTo matches Writer.Write and ReadFrom reads it back
+func TestFile__WriteToReadFrom(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+
+	var want bytes.Buffer
+	if err := NewWriter(&want).Write(&file); err != nil {
+		t.Fatal(err)
+	}
+	var _ io.WriterTo = &file
+	var _ io.ReaderFrom = &file
+
+	var buf bytes.Buffer
+	n, err := file.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(buf.Bytes(), want.Bytes()) {
+		t.Error("WriteTo output does not match Writer.Write")
+	}
+	if n != int64(want.Len()) {
+		t.Errorf("expected %d bytes written, got %d", want.Len(), n)
+	}
+
+	var read File
+	n, err = read.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n != int64(want.Len()) {
+		t.Errorf("expected %d bytes read, got %d", want.Len(), n)
+	}
+	if !read.Equal(&file) {
+		t.Errorf("%v", read.Diff(&file))
+	}
+
+	if _, err := read.ReadFrom(strings.NewReader("invalid")); err == nil {
+		t.Error("expected an error")
+	}
+	if len(read.CashLetters) != len(file.CashLetters) {
+		t.Error("File changed by a failed ReadFrom")
+	}
+}
+
+// TestFile__ItemKinds classifies forward presentment, return and mixed Files
+func TestFile__ItemKinds(t *testing.T) {
+	check := func(name string, file *File, forward, returns, mixed bool) {
+		t.Helper()
+		if file.IsForward() != forward || file.IsReturns() != returns || file.ContainsMixed() != mixed {
+			t.Errorf("%s: IsForward=%v IsReturns=%v ContainsMixed=%v", name, file.IsForward(), file.IsReturns(), file.ContainsMixed())
+		}
+	}
+
+	forward, err := ReadFile(filepath.Join("test", "testdata", "BNK20181010121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	check("forward", forward, true, false, false)
+
+	// the return Bundles of each CashLetter follow its check Bundles
+	mixed, err := ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	check("mixed", mixed, false, false, true)
+	for i := range mixed.CashLetters {
+		mixed.CashLetters[i].CashLetterHeader.CollectionTypeIndicator = "99"
+		mixed.CashLetters[i].Bundles[1].BundleHeader.CollectionTypeIndicator = "03"
+	}
+	if err := mixed.ValidateWith(WithCollectionTypes()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	check("mixed collection types", mixed, false, false, true)
+
+	returns, err := ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for i := range returns.CashLetters {
+		cl := &returns.CashLetters[i]
+		cl.Bundles = cl.Bundles[1:]
+		cl.CashLetterControl.CashLetterImagesCount = cl.imagesCount()
+		cl.CashLetterHeader.CollectionTypeIndicator = "03"
+		cl.Bundles[0].BundleHeader.CollectionTypeIndicator = "03"
+	}
+	if err := returns.ValidateWith(WithCollectionTypes()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	check("returns", returns, false, true, false)
+
+	// returns under a forward presentment CashLetter
+	returns.CashLetters[0].CashLetterHeader.CollectionTypeIndicator = "01"
+	check("returns in forward CashLetter", returns, false, false, true)
+
+	check("empty", NewFile(), false, false, false)
+	var f *File
+	check("nil", f, false, false, false)
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+)
+
+// Errors specific to framed files
+var (
+	msgFrameTruncated = "ended after %d of %d bytes"
+	msgFrameLength    = "%d bytes exceeds the maximum of %d"
+)
+
+// frameLengthSize is the size of the big-endian length preceding each framed file
+const frameLengthSize = 4
+
+// FrameReader reads a stream of files which are each preceded by their length in bytes as a 4 byte
+// big-endian value, as written by FrameWriter. Each frame is read by a Reader, so a frame holds one
+// complete file in any format the Reader accepts.
+type FrameReader struct {
+	r      io.Reader
+	reader *Reader
+}
+
+// NewFrameReader returns a FrameReader reading framed files from r. Each file is read with the
+// ReaderOptions opts.
+func NewFrameReader(r io.Reader, opts ...ReaderOption) *FrameReader {
+	return &FrameReader{
+		r:      r,
+		reader: NewReader(bytes.NewReader(nil), opts...),
+	}
+}
+
+// Read reads the next framed file from the stream. At the end of the stream Read returns io.EOF, and
+// a FileError is returned if the stream ends within a frame. A File which fails to parse is returned
+// with the error as Reader.Read returns it, and the rest of its frame is skipped so the following file
+// can be read.
+func (fr *FrameReader) Read() (File, error) {
+	var prefix [frameLengthSize]byte
+	n, err := io.ReadFull(fr.r, prefix[:])
+	if err == io.EOF {
+		return File{}, io.EOF
+	}
+	if err != nil {
+		msg := fmt.Sprintf(msgFrameTruncated, n, frameLengthSize)
+		return File{}, &FileError{FieldName: "FrameLength", Msg: msg}
+	}
+	length := int64(binary.BigEndian.Uint32(prefix[:]))
+
+	frame := &io.LimitedReader{R: fr.r, N: length}
+	fr.reader.Reset(frame)
+	file, err := fr.reader.Read()
+
+	// skip whatever the Reader left unread so the stream is positioned at the next frame
+	if _, copyErr := io.Copy(ioutil.Discard, frame); copyErr != nil && err == nil {
+		err = copyErr
+	}
+	if frame.N > 0 {
+		// the stream ended within the frame, which is reported rather than the parse error it causes
+		msg := fmt.Sprintf(msgFrameTruncated, length-frame.N, length)
+		err = &FileError{FieldName: "Frame", Value: strconv.FormatInt(length, 10), Msg: msg}
+	}
+	return file, err
+}
+
+// FrameWriter writes files to a stream which are each preceded by their length in bytes as a 4 byte
+// big-endian value, to be read by FrameReader.
+type FrameWriter struct {
+	w    io.Writer
+	opts []WriterOption
+	buf  bytes.Buffer
+}
+
+// NewFrameWriter returns a FrameWriter writing framed files to w. Each file is written with the
+// WriterOptions opts.
+func NewFrameWriter(w io.Writer, opts ...WriterOption) *FrameWriter {
+	return &FrameWriter{
+		w:    w,
+		opts: opts,
+	}
+}
+
+// Write writes file to the stream preceded by its length. A file is only written once it has been
+// formatted in full, so a File which fails to write leaves the stream unchanged.
+func (fw *FrameWriter) Write(file *File) error {
+	fw.buf.Reset()
+	if err := NewWriter(&fw.buf, fw.opts...).Write(file); err != nil {
+		return err
+	}
+	if int64(fw.buf.Len()) > math.MaxUint32 {
+		msg := fmt.Sprintf(msgFrameLength, fw.buf.Len(), uint64(math.MaxUint32))
+		return &FileError{FieldName: "FrameLength", Value: strconv.Itoa(fw.buf.Len()), Msg: msg}
+	}
+	var prefix [frameLengthSize]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(fw.buf.Len()))
+	if _, err := fw.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := fw.w.Write(fw.buf.Bytes())
+	return err
+}
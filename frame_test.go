@@ -0,0 +1,95 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestFrameRoundTrip writes two framed files to one stream and reads them back
+func TestFrameRoundTrip(t *testing.T) {
+	var files []*File
+	for _, name := range []string{"BNK20180905121042882-A.icl", "credit-items.icl"} {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := NewReader(bytes.NewReader(bs)).Read()
+		if err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+		files = append(files, &file)
+	}
+
+	var stream bytes.Buffer
+	fw := NewFrameWriter(&stream)
+	for _, file := range files {
+		if err := fw.Write(file); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+	}
+
+	// the first frame holds the first file as Writer writes it
+	var expected bytes.Buffer
+	if err := NewWriter(&expected).Write(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	if n := binary.BigEndian.Uint32(stream.Bytes()[:4]); int(n) != expected.Len() {
+		t.Errorf("frame length %d != %d", n, expected.Len())
+	}
+
+	fr := NewFrameReader(bytes.NewReader(stream.Bytes()))
+	for i, file := range files {
+		read, err := fr.Read()
+		if err != nil {
+			t.Fatalf("file %d: %T: %s", i, err, err)
+		}
+		if s := read.Summary(); s != file.Summary() {
+			t.Errorf("file %d: got %+v", i, s)
+		}
+		if read.Control != file.Control {
+			t.Errorf("file %d: FileControl %+v", i, read.Control)
+		}
+	}
+	if _, err := fr.Read(); err != io.EOF {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestFrameReaderErrors reads a truncated stream and an invalid frame followed by a valid one
+func TestFrameReaderErrors(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "credit-items.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(len(bs)))
+	framed := append(prefix[:], bs...)
+
+	// the stream ends within the length and within the file
+	for _, truncated := range [][]byte{framed[:2], framed[:len(framed)-10]} {
+		_, err := NewFrameReader(bytes.NewReader(truncated)).Read()
+		if _, ok := err.(*FileError); !ok {
+			t.Errorf("%T: %s", err, err)
+		}
+	}
+
+	// the rest of an invalid frame is skipped
+	invalid := []byte("10 not a file header\n")
+	binary.BigEndian.PutUint32(prefix[:], uint32(len(invalid)))
+	stream := append(append(prefix[:], invalid...), framed...)
+	fr := NewFrameReader(bytes.NewReader(stream))
+	if _, err := fr.Read(); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := fr.Read(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"crypto/sha256"
+)
+
+// ImageKey identifies an image view of an item in a File
+type ImageKey struct {
+	// CashLetterID is the CashLetterID of the CashLetter holding the item
+	CashLetterID string `json:"cashLetterID"`
+	// BundleSequenceNumber is the BundleSequenceNumber of the Bundle holding the item without padding
+	// or leading zeros
+	BundleSequenceNumber string `json:"bundleSequenceNumber"`
+	// ItemSequenceNumber is the EceInstitutionItemSequenceNumber of the CheckDetail or ReturnDetail
+	// without padding or leading zeros
+	ItemSequenceNumber string `json:"itemSequenceNumber"`
+	// ViewSideIndicator is the ViewSideIndicator of the ImageViewDetail describing the image view, 0 for
+	// the front and 1 for the back
+	ViewSideIndicator int `json:"viewSideIndicator"`
+	// View is the index of the ImageViewData among the image views of the item
+	View int `json:"view"`
+}
+
+// ImageHash returns the SHA-256 hash of ImageData as it is stored, without decoding it, or the zero
+// value when ImageData is empty.
+func (ivData *ImageViewData) ImageHash() [sha256.Size]byte {
+	if ivData == nil || len(ivData.ImageData) == 0 {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(ivData.ImageData)
+}
+
+// ImageHashes returns the ImageHash of every ImageViewData with ImageData of each CheckDetail and
+// ReturnDetail in the File. Image views without ImageData, such as those of a File read with
+// ReadSkipImageDataOption, are left out. Identical images have the same hash, so duplicates are found by
+// comparing the values of the map.
+func (f *File) ImageHashes() map[ImageKey][sha256.Size]byte {
+	hashes := make(map[ImageKey][sha256.Size]byte)
+	if f == nil {
+		return hashes
+	}
+	add := func(cl *CashLetter, b *Bundle, seq string, details []ImageViewDetail, data []ImageViewData) {
+		for i := range data {
+			if len(data[i].ImageData) == 0 {
+				continue
+			}
+			key := ImageKey{ItemSequenceNumber: normalizeSequenceNumber(seq), View: i}
+			if cl.CashLetterHeader != nil {
+				key.CashLetterID = cl.CashLetterHeader.CashLetterID
+			}
+			if b.BundleHeader != nil {
+				key.BundleSequenceNumber = normalizeSequenceNumber(b.BundleHeader.BundleSequenceNumber)
+			}
+			if i < len(details) {
+				key.ViewSideIndicator = details[i].ViewSideIndicator
+			}
+			hashes[key] = data[i].ImageHash()
+		}
+	}
+	f.ForEachItem(func(cl *CashLetter, b *Bundle, cd *CheckDetail) error {
+		add(cl, b, cd.EceInstitutionItemSequenceNumber, cd.ImageViewDetail, cd.ImageViewData)
+		return nil
+	})
+	f.ForEachReturnItem(func(cl *CashLetter, b *Bundle, rd *ReturnDetail) error {
+		add(cl, b, rd.EceInstitutionItemSequenceNumber, rd.ImageViewDetail, rd.ImageViewData)
+		return nil
+	})
+	return hashes
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestImageViewDataImageHash hashes ImageData
+func TestImageViewDataImageHash(t *testing.T) {
+	ivData := mockImageViewData()
+	ivData.ImageData = []byte("II*\x00 image")
+	if h := ivData.ImageHash(); h != sha256.Sum256(ivData.ImageData) {
+		t.Errorf("ImageHash=%x", h)
+	}
+	ivData.ImageData = nil
+	if h := ivData.ImageHash(); h != [sha256.Size]byte{} {
+		t.Errorf("ImageHash=%x", h)
+	}
+	var nilData *ImageViewData
+	if h := nilData.ImageHash(); h != [sha256.Size]byte{} {
+		t.Errorf("ImageHash=%x", h)
+	}
+}
+
+// TestFileImageHashes hashes the images of a File read twice
+func TestFileImageHashes(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	hashes := file.ImageHashes()
+	if s := file.Summary(); len(hashes) != s.ImageCount {
+		t.Errorf("%d hashes for %d images", len(hashes), s.ImageCount)
+	}
+
+	key := ImageKey{CashLetterID: "A1", BundleSequenceNumber: "1", ItemSequenceNumber: "1", View: 0}
+	h, ok := hashes[key]
+	if !ok {
+		t.Fatalf("missing %+v", key)
+	}
+	if hex.EncodeToString(h[:]) != "36a9e7f1c95b82ffb99743e0c5c4ce95d83c9a430aac59f84ef3cbfab6145068" {
+		t.Errorf("%+v: %s", key, hex.EncodeToString(h[:]))
+	}
+
+	// hashes are stable across reads
+	again, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for k, v := range again.ImageHashes() {
+		if hashes[k] != v {
+			t.Errorf("%+v: %x != %x", k, v, hashes[k])
+		}
+	}
+
+	// image views without ImageData are left out
+	skipped, err := NewReader(bytes.NewReader(bs), ReadSkipImageDataOption()).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := len(skipped.ImageHashes()); n != 0 {
+		t.Errorf("unexpected %d hashes", n)
+	}
+	var nilFile *File
+	if n := len(nilFile.ImageHashes()); n != 0 {
+		t.Errorf("unexpected %d hashes", n)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Errors specific to decoding ImageViewData.ImageData
+var (
+	msgImageFormat  = "is not a TIFF image"
+	msgImageCorrupt = "is a corrupt TIFF image: %s"
+)
+
+// TIFF tags read by ImageInfo
+const (
+	tiffImageWidth      = 256
+	tiffImageLength     = 257
+	tiffBitsPerSample   = 258
+	tiffCompression     = 259
+	tiffSamplesPerPixel = 277
+)
+
+// TIFF field types read by ImageInfo
+const (
+	tiffShort = 3
+	tiffLong  = 4
+)
+
+// TIFF Compression values
+const (
+	// TIFFCompressionNone is uncompressed image data
+	TIFFCompressionNone = 1
+	// TIFFCompressionGroup3 is CCITT Group 3 fax compression
+	TIFFCompressionGroup3 = 3
+	// TIFFCompressionGroup4 is CCITT Group 4 fax compression, the common case for bi-tonal check images
+	TIFFCompressionGroup4 = 4
+	// TIFFCompressionJPEG is JPEG compression
+	TIFFCompressionJPEG = 7
+)
+
+// ImageInfo describes an image view decoded from the header of ImageViewData.ImageData
+type ImageInfo struct {
+	// Format is the detected image format, which is TIFF
+	Format string `json:"format"`
+	// Width is the number of pixels in each row of the image
+	Width int `json:"width"`
+	// Height is the number of rows of pixels in the image
+	Height int `json:"height"`
+	// BitsPerPixel is the number of bits for each pixel, which is 1 for a bi-tonal image
+	BitsPerPixel int `json:"bitsPerPixel"`
+	// Compression is the TIFF Compression value, such as TIFFCompressionGroup4
+	Compression int `json:"compression"`
+}
+
+// ImageInfo returns the dimensions, bit depth and compression of the TIFF image in ImageData by reading
+// the TIFF header and first image file directory. The image raster is not decoded so any compression,
+// including Group 4, is supported. An error is returned if ImageData is not a TIFF image or its header
+// is corrupt.
+func (ivData *ImageViewData) ImageInfo() (ImageInfo, error) {
+	info, err := decodeTIFFInfo(ivData.ImageData)
+	if err != nil {
+		return ImageInfo{}, &FieldError{FieldName: "ImageData", Value: ivData.LengthImageData, Msg: err.Error()}
+	}
+	return info, nil
+}
+
+// decodeTIFFInfo reads the ImageInfo from the first image file directory of a TIFF image
+func decodeTIFFInfo(data []byte) (ImageInfo, error) {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, []byte("II*\x00")):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, []byte("MM\x00*")):
+		order = binary.BigEndian
+	default:
+		return ImageInfo{}, errors.New(msgImageFormat)
+	}
+	if len(data) < 8 {
+		return ImageInfo{}, fmt.Errorf(msgImageCorrupt, "short header")
+	}
+	offset := int64(order.Uint32(data[4:8]))
+	if offset+2 > int64(len(data)) {
+		return ImageInfo{}, fmt.Errorf(msgImageCorrupt, "image file directory is outside the image data")
+	}
+	entries := int64(order.Uint16(data[offset:]))
+	if offset+2+entries*12 > int64(len(data)) {
+		return ImageInfo{}, fmt.Errorf(msgImageCorrupt, "image file directory is truncated")
+	}
+
+	info := ImageInfo{Format: "TIFF", Compression: TIFFCompressionNone}
+	samplesPerPixel := 1
+	bitsPerSample := []int{1}
+	for i := int64(0); i < entries; i++ {
+		entry := data[offset+2+i*12 : offset+2+(i+1)*12]
+		values, err := tiffValues(data, order, entry)
+		if err != nil {
+			return ImageInfo{}, err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		switch order.Uint16(entry[0:2]) {
+		case tiffImageWidth:
+			info.Width = values[0]
+		case tiffImageLength:
+			info.Height = values[0]
+		case tiffBitsPerSample:
+			bitsPerSample = values
+		case tiffCompression:
+			info.Compression = values[0]
+		case tiffSamplesPerPixel:
+			samplesPerPixel = values[0]
+		}
+	}
+	if info.Width == 0 || info.Height == 0 {
+		return ImageInfo{}, fmt.Errorf(msgImageCorrupt, "missing image width or length")
+	}
+	if len(bitsPerSample) == 1 {
+		info.BitsPerPixel = bitsPerSample[0] * samplesPerPixel
+	} else {
+		for _, bits := range bitsPerSample {
+			info.BitsPerPixel += bits
+		}
+	}
+	return info, nil
+}
+
+// tiffValues returns the SHORT or LONG values of a 12 byte image file directory entry. Values of
+// other field types are not needed by ImageInfo and are skipped.
+func tiffValues(data []byte, order binary.ByteOrder, entry []byte) ([]int, error) {
+	size := int64(0)
+	switch order.Uint16(entry[2:4]) {
+	case tiffShort:
+		size = 2
+	case tiffLong:
+		size = 4
+	default:
+		return nil, nil
+	}
+	count := int64(order.Uint32(entry[4:8]))
+	value := entry[8:12]
+	if count*size > 4 {
+		offset := int64(order.Uint32(entry[8:12]))
+		if offset+count*size > int64(len(data)) {
+			return nil, fmt.Errorf(msgImageCorrupt, "tag value is outside the image data")
+		}
+		value = data[offset : offset+count*size]
+	}
+	values := make([]int, count)
+	for i := range values {
+		if size == 2 {
+			values[i] = int(order.Uint16(value[int64(i)*2:]))
+		} else {
+			values[i] = int(order.Uint32(value[int64(i)*4:]))
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// mockTIFFImage returns the header and image file directory of a TIFF image with the given entries,
+// each a tag, field type and values. Values which don't fit in an entry follow the directory.
+func mockTIFFImage(order binary.ByteOrder, entries [][]uint32) []byte {
+	data := make([]byte, 8)
+	if order == binary.LittleEndian {
+		copy(data, "II")
+	} else {
+		copy(data, "MM")
+	}
+	order.PutUint16(data[2:], 42)
+	order.PutUint32(data[4:], 8)
+
+	data = append(data, make([]byte, 2+len(entries)*12+4)...)
+	order.PutUint16(data[8:], uint16(len(entries)))
+	for i, e := range entries {
+		entry := data[10+i*12:]
+		order.PutUint16(entry[0:], uint16(e[0]))
+		order.PutUint16(entry[2:], uint16(e[1]))
+		order.PutUint32(entry[4:], uint32(len(e)-2))
+		size := 4
+		if e[1] == tiffShort {
+			size = 2
+		}
+		value := make([]byte, size*(len(e)-2))
+		for j, v := range e[2:] {
+			if size == 2 {
+				order.PutUint16(value[j*2:], uint16(v))
+			} else {
+				order.PutUint32(value[j*4:], v)
+			}
+		}
+		if len(value) > 4 {
+			order.PutUint32(entry[8:], uint32(len(data)))
+			data = append(data, value...)
+		} else {
+			copy(entry[8:12], value)
+		}
+	}
+	// Group 4 compressed raster, which ImageInfo doesn't decode
+	return append(data, 0x26, 0xa0, 0x00, 0x10)
+}
+
+// TestImageInfo__Group4 validates a bi-tonal Group 4 TIFF image
+func TestImageInfo__Group4(t *testing.T) {
+	ivData := mockImageViewData()
+	ivData.ImageData = mockTIFFImage(binary.LittleEndian, [][]uint32{
+		{tiffImageWidth, tiffShort, 1728},
+		{tiffImageLength, tiffLong, 768},
+		{tiffCompression, tiffShort, TIFFCompressionGroup4},
+	})
+	info, err := ivData.ImageInfo()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if info != (ImageInfo{Format: "TIFF", Width: 1728, Height: 768, BitsPerPixel: 1, Compression: TIFFCompressionGroup4}) {
+		t.Errorf("unexpected ImageInfo: %#v", info)
+	}
+}
+
+// TestImageInfo__BigEndian validates a big endian TIFF image with several samples per pixel
+func TestImageInfo__BigEndian(t *testing.T) {
+	ivData := mockImageViewData()
+	ivData.ImageData = mockTIFFImage(binary.BigEndian, [][]uint32{
+		{tiffImageWidth, tiffLong, 1200},
+		{tiffImageLength, tiffShort, 550},
+		{tiffBitsPerSample, tiffShort, 8, 8, 8},
+		{tiffCompression, tiffShort, TIFFCompressionJPEG},
+		{tiffSamplesPerPixel, tiffShort, 3},
+	})
+	info, err := ivData.ImageInfo()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if info != (ImageInfo{Format: "TIFF", Width: 1200, Height: 550, BitsPerPixel: 24, Compression: TIFFCompressionJPEG}) {
+		t.Errorf("unexpected ImageInfo: %#v", info)
+	}
+}
+
+// TestImageInfo__Corrupt validates errors for TIFF images with a corrupt header
+func TestImageInfo__Corrupt(t *testing.T) {
+	image := mockTIFFImage(binary.LittleEndian, [][]uint32{
+		{tiffImageWidth, tiffShort, 1728},
+		{tiffImageLength, tiffShort, 768},
+	})
+	noLength := mockTIFFImage(binary.LittleEndian, [][]uint32{
+		{tiffImageWidth, tiffShort, 1728},
+	})
+	for _, data := range [][]byte{image[:6], image[:20], noLength} {
+		ivData := mockImageViewData()
+		ivData.ImageData = data
+		_, err := ivData.ImageInfo()
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "ImageData" {
+			t.Errorf("%T: %s", err, err)
+		}
+	}
+}
+
+// TestImageInfo__NotTIFF validates the images of the base64-encoded-images.json fixture, which aren't TIFF
+func TestImageInfo__NotTIFF(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ivData := file.CashLetters[0].Bundles[0].Checks[0].ImageViewData[0]
+	if string(ivData.ImageData) != "hello, world" {
+		t.Fatalf("unexpected ImageData: %q", ivData.ImageData)
+	}
+	_, err = ivData.ImageInfo()
+	if e, ok := err.(*FieldError); !ok || e.Msg != msgImageFormat {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -6,7 +6,7 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
+	"strconv"
 	"unicode/utf8"
 )
 
@@ -213,6 +213,8 @@ type ImageViewAnalysis struct {
 	UserField string `json:"userField"`
 	// reservedThree is a field reserved for future use.  Reserved should be blank.
 	reservedThree string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for ImageCashLetter data validation
 	validator
 	// converters is composed for ImageCashLetter to golang Converters
@@ -230,7 +232,12 @@ func (ivAnalysis *ImageViewAnalysis) setRecordType() {
 	if ivAnalysis == nil {
 		return
 	}
-	ivAnalysis.recordType = "54"
+	ivAnalysis.recordType = RecordTypeImageViewAnalysis
+}
+
+// RecordType returns the record type of the ImageViewAnalysis, which is RecordTypeImageViewAnalysis once set by NewImageViewAnalysis or Parse
+func (ivAnalysis *ImageViewAnalysis) RecordType() string {
+	return ivAnalysis.recordType
 }
 
 // Parse takes the input record string and parses the ImageViewAnalysis values
@@ -262,7 +269,7 @@ func (ivAnalysis *ImageViewAnalysis) Parse(record string) {
 	// 12-12
 	ivAnalysis.ExceedsMaximumImageSize = ivAnalysis.parseNumField(record[11:12])
 	// 13-25
-	ivAnalysis.reserved = "             "
+	ivAnalysis.reserved = ivAnalysis.parseReservedField(record, 12, 25)
 	// 26-26
 	ivAnalysis.ImageEnabledPOD = ivAnalysis.parseNumField(record[25:26])
 	// 27-27
@@ -292,47 +299,50 @@ func (ivAnalysis *ImageViewAnalysis) Parse(record string) {
 	// 39-39
 	ivAnalysis.TransitEndorsementUsability = ivAnalysis.parseNumField(record[38:39])
 	// 40-45
-	ivAnalysis.reservedTwo = "      "
+	ivAnalysis.reservedTwo = ivAnalysis.parseReservedField(record, 39, 45)
 	// 46-65
 	ivAnalysis.UserField = ivAnalysis.parseStringField(record[45:65])
 	// 66-80
-	ivAnalysis.reservedThree = "               "
+	ivAnalysis.reservedThree = ivAnalysis.parseReservedField(record, 65, 80)
 }
 
 // String writes the ImageViewAnalysis struct to a string.
 func (ivAnalysis *ImageViewAnalysis) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(ivAnalysis.recordType)
-	buf.WriteString(ivAnalysis.GlobalImageQualityField())
-	buf.WriteString(ivAnalysis.GlobalImageUsabilityField())
-	buf.WriteString(ivAnalysis.ImagingBankSpecificTestField())
-	buf.WriteString(ivAnalysis.PartialImageField())
-	buf.WriteString(ivAnalysis.ExcessiveImageSkewField())
-	buf.WriteString(ivAnalysis.PiggybackImageField())
-	buf.WriteString(ivAnalysis.TooLightOrTooDarkField())
-	buf.WriteString(ivAnalysis.StreaksAndOrBandsField())
-	buf.WriteString(ivAnalysis.BelowMinimumImageSizeField())
-	buf.WriteString(ivAnalysis.ExceedsMaximumImageSizeField())
-	buf.WriteString(ivAnalysis.reservedField())
-	buf.WriteString(ivAnalysis.ImageEnabledPODField())
-	buf.WriteString(ivAnalysis.SourceDocumentBadField())
-	buf.WriteString(ivAnalysis.DateUsabilityField())
-	buf.WriteString(ivAnalysis.PayeeUsabilityField())
-	buf.WriteString(ivAnalysis.ConvenienceAmountUsabilityField())
-	buf.WriteString(ivAnalysis.AmountInWordsUsabilityField())
-	buf.WriteString(ivAnalysis.SignatureUsabilityField())
-	buf.WriteString(ivAnalysis.PayorNameAddressUsabilityField())
-	buf.WriteString(ivAnalysis.MICRLineUsabilityField())
-	buf.WriteString(ivAnalysis.MemoLineUsabilityField())
-	buf.WriteString(ivAnalysis.PayorBankNameAddressUsabilityField())
-	buf.WriteString(ivAnalysis.PayeeEndorsementUsabilityField())
-	buf.WriteString(ivAnalysis.BOFDEndorsementUsabilityField())
-	buf.WriteString(ivAnalysis.TransitEndorsementUsabilityField())
-	buf.WriteString(ivAnalysis.reservedTwoField())
-	buf.WriteString(ivAnalysis.UserFieldField())
-	buf.WriteString(ivAnalysis.reservedThreeField())
-	return buf.String()
+	return string(ivAnalysis.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ImageViewAnalysis record, as String returns it, to b and returns the extended buffer.
+func (ivAnalysis *ImageViewAnalysis) AppendTo(b []byte) []byte {
+	b = append(b, ivAnalysis.recordType...)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.GlobalImageQuality, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.GlobalImageUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ImagingBankSpecificTest, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PartialImage, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ExcessiveImageSkew, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PiggybackImage, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.TooLightOrTooDark, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.StreaksAndOrBands, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.BelowMinimumImageSize, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ExceedsMaximumImageSize, 1)
+	b = ivAnalysis.appendAlphaField(b, ivAnalysis.reserved, 13)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ImageEnabledPOD, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.SourceDocumentBad, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.DateUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PayeeUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ConvenienceAmountUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.ConvenienceAmountUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.SignatureUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PayorNameAddressUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.MICRLineUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.MemoLineUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PayorBankNameAddressUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.PayeeEndorsementUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.BOFDEndorsementUsability, 1)
+	b = ivAnalysis.appendNumericField(b, ivAnalysis.TransitEndorsementUsability, 1)
+	b = ivAnalysis.appendAlphaField(b, ivAnalysis.reservedTwo, 6)
+	b = ivAnalysis.appendAlphaField(b, ivAnalysis.UserField, 20)
+	b = ivAnalysis.appendAlphaField(b, ivAnalysis.reservedThree, 15)
+	return b
 }
 
 // Validate performs ImageCashLetterformat rule checks on the record and returns an error if not Validated
@@ -341,22 +351,22 @@ func (ivAnalysis *ImageViewAnalysis) Validate() error {
 	if err := ivAnalysis.fieldInclusion(); err != nil {
 		return err
 	}
-	if ivAnalysis.recordType != "54" {
+	if ivAnalysis.recordType != RecordTypeImageViewAnalysis {
 		msg := fmt.Sprintf(msgRecordType, 54)
-		return &FieldError{FieldName: "recordType", Value: ivAnalysis.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ivAnalysis.recordType, Msg: msg, Err: ErrRecordType}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.GlobalImageQualityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.GlobalImageQuality); err != nil {
 		return &FieldError{FieldName: "GlobalImageQuality",
-			Value: ivAnalysis.GlobalImageQualityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.GlobalImageQuality), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.GlobalImageUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.GlobalImageUsability); err != nil {
 		return &FieldError{FieldName: "GlobalImageUsability",
-			Value: ivAnalysis.GlobalImageUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.GlobalImageUsability), Msg: err.Error()}
 	}
 
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.ImagingBankSpecificTestField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.ImagingBankSpecificTest); err != nil {
 		return &FieldError{FieldName: "ImagingBankSpecificTest",
-			Value: ivAnalysis.ImagingBankSpecificTestField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.ImagingBankSpecificTest), Msg: err.Error()}
 	}
 	if err := ivAnalysis.validateConditionalFields(); err != nil {
 		return err
@@ -369,42 +379,42 @@ func (ivAnalysis *ImageViewAnalysis) Validate() error {
 
 // validateConditionalFields makes calls to validate Image View Analysis conditional fields
 func (ivAnalysis *ImageViewAnalysis) validateConditionalFields() error {
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PartialImageField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PartialImage); err != nil {
 		return &FieldError{FieldName: "PartialImage",
-			Value: ivAnalysis.PartialImageField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PartialImage), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.ExcessiveImageSkewField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.ExcessiveImageSkew); err != nil {
 		return &FieldError{FieldName: "ExcessiveImageSkew",
-			Value: ivAnalysis.ExcessiveImageSkewField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.ExcessiveImageSkew), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PiggybackImageField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PiggybackImage); err != nil {
 		return &FieldError{FieldName: "PiggybackImage",
-			Value: ivAnalysis.PiggybackImageField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PiggybackImage), Msg: err.Error()}
 
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.TooLightOrTooDarkField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.TooLightOrTooDark); err != nil {
 		return &FieldError{FieldName: "TooLightOrTooDark",
-			Value: ivAnalysis.TooLightOrTooDarkField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.TooLightOrTooDark), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.StreaksAndOrBandsField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.StreaksAndOrBands); err != nil {
 		return &FieldError{FieldName: "StreaksAndOrBands",
-			Value: ivAnalysis.StreaksAndOrBandsField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.StreaksAndOrBands), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.BelowMinimumImageSizeField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.BelowMinimumImageSize); err != nil {
 		return &FieldError{FieldName: "BelowMinimumImageSize",
-			Value: ivAnalysis.BelowMinimumImageSizeField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.BelowMinimumImageSize), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.ExceedsMaximumImageSizeField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.ExceedsMaximumImageSize); err != nil {
 		return &FieldError{FieldName: "ExceedsMaximumImageSize",
-			Value: ivAnalysis.ExceedsMaximumImageSizeField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.ExceedsMaximumImageSize), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.ImageEnabledPODField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.ImageEnabledPOD); err != nil {
 		return &FieldError{FieldName: "ImageEnabledPOD",
-			Value: ivAnalysis.ImageEnabledPODField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.ImageEnabledPOD), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.SourceDocumentBadField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.SourceDocumentBad); err != nil {
 		return &FieldError{FieldName: "SourceDocumentBad",
-			Value: ivAnalysis.SourceDocumentBadField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.SourceDocumentBad), Msg: err.Error()}
 	}
 	if err := ivAnalysis.validateUsabilityFields(); err != nil {
 		return err
@@ -414,53 +424,53 @@ func (ivAnalysis *ImageViewAnalysis) validateConditionalFields() error {
 
 // validateUsabilityFields makes calls to validate Image View Analysis usability fields
 func (ivAnalysis *ImageViewAnalysis) validateUsabilityFields() error {
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.DateUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.DateUsability); err != nil {
 		return &FieldError{FieldName: "DateUsability",
-			Value: ivAnalysis.DateUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.DateUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PayeeUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PayeeUsability); err != nil {
 		return &FieldError{FieldName: "PayeeUsability",
-			Value: ivAnalysis.PayeeUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PayeeUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.ConvenienceAmountUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.ConvenienceAmountUsability); err != nil {
 		return &FieldError{FieldName: "ConvenienceAmountUsability",
-			Value: ivAnalysis.ConvenienceAmountUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.ConvenienceAmountUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.AmountInWordsUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.AmountInWordsUsability); err != nil {
 		return &FieldError{FieldName: "AmountInWordsUsability",
-			Value: ivAnalysis.AmountInWordsUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.AmountInWordsUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.SignatureUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.SignatureUsability); err != nil {
 		return &FieldError{FieldName: "SignatureUsability",
-			Value: ivAnalysis.SignatureUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.SignatureUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PayorNameAddressUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PayorNameAddressUsability); err != nil {
 		return &FieldError{FieldName: "PayorNameAddressUsability",
-			Value: ivAnalysis.PayorNameAddressUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PayorNameAddressUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.MICRLineUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.MICRLineUsability); err != nil {
 		return &FieldError{FieldName: "MICRLineUsability",
-			Value: ivAnalysis.MICRLineUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.MICRLineUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.MemoLineUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.MemoLineUsability); err != nil {
 		return &FieldError{FieldName: "MemoLineUsability",
-			Value: ivAnalysis.MemoLineUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.MemoLineUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PayorBankNameAddressUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PayorBankNameAddressUsability); err != nil {
 		return &FieldError{FieldName: "PayorBankNameAddressUsability",
-			Value: ivAnalysis.PayorBankNameAddressUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PayorBankNameAddressUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.PayeeEndorsementUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.PayeeEndorsementUsability); err != nil {
 		return &FieldError{FieldName: "PayeeEndorsementUsability",
-			Value: ivAnalysis.PayeeEndorsementUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.PayeeEndorsementUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.BOFDEndorsementUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.BOFDEndorsementUsability); err != nil {
 		return &FieldError{FieldName: "BOFDEndorsementUsability",
-			Value: ivAnalysis.BOFDEndorsementUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.BOFDEndorsementUsability), Msg: err.Error()}
 	}
-	if err := ivAnalysis.isImageViewAnalysisValid(ivAnalysis.TransitEndorsementUsabilityField()); err != nil {
+	if err := ivAnalysis.isImageViewAnalysisIndicator(ivAnalysis.TransitEndorsementUsability); err != nil {
 		return &FieldError{FieldName: "TransitEndorsementUsability",
-			Value: ivAnalysis.TransitEndorsementUsabilityField(), Msg: err.Error()}
+			Value: strconv.Itoa(ivAnalysis.TransitEndorsementUsability), Msg: err.Error()}
 	}
 	return nil
 }
@@ -471,7 +481,7 @@ func (ivAnalysis *ImageViewAnalysis) fieldInclusion() error {
 	if ivAnalysis.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: ivAnalysis.recordType,
-			Msg:   msgFieldInclusion + ", did you use ImageViewAnalysis()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewAnalysis()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
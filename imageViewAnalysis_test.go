@@ -504,6 +504,57 @@ func TestIVAnalysisUserField(t *testing.T) {
 	}
 }
 
+// TestIVAnalysisIndicatorRanges validates each indicator is 0, 1 or 2, including values which don't fit in the
+// 1 digit field
+func TestIVAnalysisIndicatorRanges(t *testing.T) {
+	indicators := []struct {
+		name  string
+		field func(ivAnalysis *ImageViewAnalysis) *int
+	}{
+		{"GlobalImageQuality", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.GlobalImageQuality }},
+		{"GlobalImageUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.GlobalImageUsability }},
+		{"ImagingBankSpecificTest", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.ImagingBankSpecificTest }},
+		{"PartialImage", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PartialImage }},
+		{"ExcessiveImageSkew", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.ExcessiveImageSkew }},
+		{"PiggybackImage", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PiggybackImage }},
+		{"TooLightOrTooDark", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.TooLightOrTooDark }},
+		{"StreaksAndOrBands", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.StreaksAndOrBands }},
+		{"BelowMinimumImageSize", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.BelowMinimumImageSize }},
+		{"ExceedsMaximumImageSize", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.ExceedsMaximumImageSize }},
+		{"ImageEnabledPOD", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.ImageEnabledPOD }},
+		{"SourceDocumentBad", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.SourceDocumentBad }},
+		{"DateUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.DateUsability }},
+		{"PayeeUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PayeeUsability }},
+		{"ConvenienceAmountUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.ConvenienceAmountUsability }},
+		{"AmountInWordsUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.AmountInWordsUsability }},
+		{"SignatureUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.SignatureUsability }},
+		{"PayorNameAddressUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PayorNameAddressUsability }},
+		{"MICRLineUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.MICRLineUsability }},
+		{"MemoLineUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.MemoLineUsability }},
+		{"PayorBankNameAddressUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PayorBankNameAddressUsability }},
+		{"PayeeEndorsementUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.PayeeEndorsementUsability }},
+		{"BOFDEndorsementUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.BOFDEndorsementUsability }},
+		{"TransitEndorsementUsability", func(ivAnalysis *ImageViewAnalysis) *int { return &ivAnalysis.TransitEndorsementUsability }},
+	}
+	for _, indicator := range indicators {
+		for _, value := range []int{0, 1, 2} {
+			ivAnalysis := mockImageViewAnalysis()
+			*indicator.field(&ivAnalysis) = value
+			if err := ivAnalysis.Validate(); err != nil {
+				t.Errorf("%s %d: %T: %s", indicator.name, value, err, err)
+			}
+		}
+		for _, value := range []int{3, 9, 10, 12, -1} {
+			ivAnalysis := mockImageViewAnalysis()
+			*indicator.field(&ivAnalysis) = value
+			err := ivAnalysis.Validate()
+			if e, ok := err.(*FieldError); !ok || e.FieldName != indicator.name || e.Msg != msgInvalid {
+				t.Errorf("%s %d: %T: %s", indicator.name, value, err, err)
+			}
+		}
+	}
+}
+
 // Field Inclusion
 
 // TestIVAnalysisFIRecordType validation
@@ -6,13 +6,24 @@ package imagecashletter
 
 import (
 	"encoding/base64"
+	"encoding/xml"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 )
 
 // Errors specific to a ImageViewData Record
+var (
+	msgImageViewDataLength   = "is %d characters but %s is %s"
+	msgImageViewDataSegment  = "of %d exceeds the %d bytes remaining in the record"
+	msgImageViewDataTrailing = "leaves %d bytes after the end of the ImageData"
+)
+
+// imageViewDataFixedLength is the length of the fixed fields of an ImageViewData record, which are
+// followed by the ImageReferenceKey
+const imageViewDataFixedLength = 105
 
 // ImageViewData Record
 type ImageViewData struct {
@@ -123,6 +134,7 @@ type ImageViewData struct {
 	// LengthImageData is the number of bytes in the ImageViewData.ImageData.
 	// Shall be present when ImageViewDetail.ImageIndicator is NOT 0
 	// Values: 0000001–99999999
+	// A LengthImageData of 0, for an image view carrying only metadata, is read and written without ImageData.
 	LengthImageData string `json:"lengthImageData"`
 	// ImageData contains the image view. The Image Data generally consists of an image header and the image raster
 	// data. The image header provides information that is required to interpret the image raster data. The image
@@ -137,6 +149,8 @@ type ImageViewData struct {
 	// Shall be present when ImageViewDetail.ImageIndicator Record is NOT 0.
 	// Size: 0-9999999
 	ImageData []byte `json:"imageData"`
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -154,7 +168,12 @@ func (ivData *ImageViewData) setRecordType() {
 	if ivData == nil {
 		return
 	}
-	ivData.recordType = "52"
+	ivData.recordType = RecordTypeImageViewData
+}
+
+// RecordType returns the record type of the ImageViewData, which is RecordTypeImageViewData once set by NewImageViewData or Parse
+func (ivData *ImageViewData) RecordType() string {
+	return ivData.recordType
 }
 
 // Parse takes the input record string and parses the ImageViewData values
@@ -192,7 +211,7 @@ func (ivData *ImageViewData) Parse(record string) {
 	ivData.LengthImageReferenceKey = ivData.parseStringField(record[101:105])
 
 	lirk := ivData.parseNumField(ivData.LengthImageReferenceKey)
-	if lirk < 0 || utf8.RuneCountInString(record) < 110+lirk {
+	if lirk < 0 || len(record) < 110+lirk {
 		return // line too short
 	}
 
@@ -202,7 +221,7 @@ func (ivData *ImageViewData) Parse(record string) {
 	ivData.LengthDigitalSignature = ivData.parseStringField(record[105+lirk : 110+lirk])
 
 	lds := ivData.parseNumField(ivData.LengthDigitalSignature)
-	if lds < 0 || utf8.RuneCountInString(record) < 117+lirk+lds {
+	if lds < 0 || len(record) < 117+lirk+lds {
 		return // line too short
 	}
 	// (111 + lirk) – (110 + lirk + lds)
@@ -211,7 +230,7 @@ func (ivData *ImageViewData) Parse(record string) {
 	ivData.LengthImageData = ivData.parseStringField(record[110+lirk+lds : 117+lirk+lds])
 
 	lid := ivData.parseNumField(ivData.LengthImageData)
-	if lid < 0 || utf8.RuneCountInString(record) < 117+lirk+lds+lid {
+	if lid < 0 || len(record) < 117+lirk+lds+lid {
 		return // line too short
 	}
 	// (118 + lirk + lds) – (117+lirk + lds + lid)
@@ -220,31 +239,32 @@ func (ivData *ImageViewData) Parse(record string) {
 
 // String writes the ImageViewData struct to a string.
 func (ivData *ImageViewData) String() string {
-	var buf strings.Builder
-	buf.Grow(105)
-	buf.WriteString(ivData.recordType)
-	buf.WriteString(ivData.EceInstitutionRoutingNumberField())
-	buf.WriteString(ivData.BundleBusinessDateField())
-	buf.WriteString(ivData.CycleNumberField())
-	buf.WriteString(ivData.EceInstitutionItemSequenceNumberField())
-	buf.WriteString(ivData.SecurityOriginatorNameField())
-	buf.WriteString(ivData.SecurityAuthenticatorNameField())
-	buf.WriteString(ivData.SecurityKeyNameField())
-	buf.WriteString(ivData.ClippingOriginField())
-	buf.WriteString(ivData.ClippingCoordinateH1Field())
-	buf.WriteString(ivData.ClippingCoordinateH2Field())
-	buf.WriteString(ivData.ClippingCoordinateV1Field())
-	buf.WriteString(ivData.ClippingCoordinateV2Field())
-	buf.WriteString(ivData.LengthImageReferenceKeyField())
-	buf.Grow(ivData.parseNumField(ivData.LengthImageReferenceKey))
-	buf.WriteString(ivData.ImageReferenceKeyField())
-	buf.WriteString(ivData.LengthDigitalSignatureField())
-	buf.Grow(ivData.parseNumField(ivData.LengthDigitalSignature))
-	buf.WriteString(ivData.DigitalSignatureField())
-	buf.WriteString(ivData.LengthImageDataField())
-	buf.Grow(ivData.parseNumField(ivData.LengthImageData))
-	buf.WriteString(ivData.ImageDataField())
-	return buf.String()
+	size := 105 + ivData.parseNumField(ivData.LengthImageReferenceKey) +
+		ivData.parseNumField(ivData.LengthDigitalSignature) + ivData.parseNumField(ivData.LengthImageData)
+	return string(ivData.AppendTo(make([]byte, 0, size)))
+}
+
+// AppendTo appends the ImageViewData record, as String returns it, to b and returns the extended buffer.
+func (ivData *ImageViewData) AppendTo(b []byte) []byte {
+	b = append(b, ivData.recordType...)
+	b = ivData.appendStringField(b, ivData.EceInstitutionRoutingNumber, 9)
+	b = ivData.appendYYYYMMDDDate(b, ivData.BundleBusinessDate)
+	b = ivData.appendAlphaField(b, ivData.CycleNumber, 2)
+	b = ivData.appendAlphaField(b, ivData.EceInstitutionItemSequenceNumber, 15)
+	b = ivData.appendAlphaField(b, ivData.SecurityOriginatorName, 16)
+	b = ivData.appendAlphaField(b, ivData.SecurityAuthenticatorName, 16)
+	b = ivData.appendAlphaField(b, ivData.SecurityKeyName, 16)
+	b = ivData.appendNumericField(b, ivData.ClippingOrigin, 1)
+	b = ivData.appendAlphaField(b, ivData.ClippingCoordinateH1, 4)
+	b = ivData.appendAlphaField(b, ivData.ClippingCoordinateH2, 4)
+	b = ivData.appendAlphaField(b, ivData.ClippingCoordinateV1, 4)
+	b = ivData.appendAlphaField(b, ivData.ClippingCoordinateV2, 4)
+	b = ivData.appendStringField(b, ivData.LengthImageReferenceKey, 4)
+	b = ivData.appendAlphaField(b, ivData.ImageReferenceKey, uint(ivData.parseNumField(ivData.LengthImageReferenceKey)))
+	b = ivData.appendAlphaField(b, ivData.LengthDigitalSignature, 5)
+	b = ivData.appendBytesField(b, ivData.DigitalSignature, uint(ivData.parseNumField(ivData.LengthDigitalSignature)))
+	b = ivData.appendAlphaField(b, ivData.LengthImageData, 7)
+	return ivData.appendImageData(b)
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -254,9 +274,9 @@ func (ivData *ImageViewData) Validate() error {
 		return err
 	}
 	// Mandatory
-	if ivData.recordType != "52" {
+	if ivData.recordType != RecordTypeImageViewData {
 		msg := fmt.Sprintf(msgRecordType, 52)
-		return &FieldError{FieldName: "recordType", Value: ivData.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ivData.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := ivData.isAlphanumeric(ivData.CycleNumber); err != nil {
 		return &FieldError{FieldName: "CycleNumber", Value: ivData.CycleNumber, Msg: err.Error()}
@@ -273,6 +293,103 @@ func (ivData *ImageViewData) Validate() error {
 	if err := ivData.isAlphanumericSpecial(ivData.ImageReferenceKey); err != nil {
 		return &FieldError{FieldName: "ImageReferenceKey", Value: ivData.ImageReferenceKey, Msg: err.Error()}
 	}
+	if err := ivData.validateSecurity(); err != nil {
+		return err
+	}
+	if err := ivData.isDateInRange(ivData.BundleBusinessDate); err != nil {
+		return &FieldError{FieldName: "BundleBusinessDate", Value: ivData.BundleBusinessDateField(), Msg: err.Error()}
+	}
+	return nil
+}
+
+// validateSecurity checks the security names, ImageReferenceKey and DigitalSignature fit the fields they are
+// written to, so they are written exactly as they were read. The security names are 16 characters, and the
+// ImageReferenceKey and DigitalSignature are the length given by LengthImageReferenceKey and LengthDigitalSignature.
+// A DigitalSignature can't be authenticated without the SecurityOriginatorName, SecurityAuthenticatorName and
+// SecurityKeyName, so they are mandatory when a DigitalSignature is present.
+func (ivData *ImageViewData) validateSecurity() error {
+	names := []struct {
+		fieldName, value string
+	}{
+		{"SecurityOriginatorName", ivData.SecurityOriginatorName},
+		{"SecurityAuthenticatorName", ivData.SecurityAuthenticatorName},
+		{"SecurityKeyName", ivData.SecurityKeyName},
+	}
+	for _, name := range names {
+		if len(name.value) > 16 {
+			return &FieldError{FieldName: name.fieldName, Value: name.value, Msg: fmt.Sprintf(msgFieldLength, 16)}
+		}
+	}
+	if err := ivData.isNumeric(ivData.LengthImageReferenceKey); err != nil {
+		return &FieldError{FieldName: "LengthImageReferenceKey", Value: ivData.LengthImageReferenceKey, Msg: err.Error()}
+	}
+	if n := len(ivData.ImageReferenceKey); n > ivData.parseNumField(ivData.LengthImageReferenceKey) {
+		msg := fmt.Sprintf(msgImageViewDataLength, n, "LengthImageReferenceKey", ivData.LengthImageReferenceKey)
+		return &FieldError{FieldName: "ImageReferenceKey", Value: ivData.ImageReferenceKey, Msg: msg}
+	}
+	if err := ivData.isNumeric(ivData.LengthDigitalSignature); err != nil {
+		return &FieldError{FieldName: "LengthDigitalSignature", Value: ivData.LengthDigitalSignature, Msg: err.Error()}
+	}
+	if err := ivData.isNumeric(ivData.LengthImageData); err != nil {
+		return &FieldError{FieldName: "LengthImageData", Value: ivData.LengthImageData, Msg: err.Error()}
+	}
+	if n := len(ivData.DigitalSignature); n != ivData.parseNumField(ivData.LengthDigitalSignature) {
+		msg := fmt.Sprintf(msgImageViewDataLength, n, "LengthDigitalSignature", ivData.LengthDigitalSignature)
+		return &FieldError{FieldName: "DigitalSignature", Value: strconv.Itoa(n), Msg: msg}
+	}
+	if len(ivData.DigitalSignature) == 0 {
+		return nil
+	}
+	for _, name := range names {
+		if name.value == "" {
+			return &FieldError{FieldName: name.fieldName, Value: name.value, Msg: msgFieldInclusion, Err: ErrFieldInclusion}
+		}
+	}
+	return nil
+}
+
+// validateSegments checks the length fields preceding the ImageReferenceKey, DigitalSignature and ImageData
+// of the ImageViewData record are numeric and that the fields they describe end with the record, so Parse
+// reads every field at its declared position. When imageData is false the record was read without ImageData,
+// as with ReadSkipImageDataOption, and must end with LengthImageData.
+func (ivData *ImageViewData) validateSegments(record string, imageData bool) error {
+	if len(record) < imageViewDataFixedLength {
+		return nil // checkRecordLength reports the record as too short
+	}
+	segments := []struct {
+		fieldName string
+		width     int
+	}{
+		{"LengthImageReferenceKey", 4},
+		{"LengthDigitalSignature", 5},
+		{"LengthImageData", 7},
+	}
+	offset := imageViewDataFixedLength - 4
+	var value string
+	for i, segment := range segments {
+		if remaining := len(record) - offset; remaining < segment.width {
+			msg := fmt.Sprintf(msgImageViewDataSegment, segment.width, remaining)
+			return &FieldError{FieldName: segment.fieldName, Value: record[offset:], Msg: msg}
+		}
+		value = record[offset : offset+segment.width]
+		if err := ivData.isNumeric(strings.TrimSpace(value)); err != nil {
+			return &FieldError{FieldName: segment.fieldName, Value: value, Msg: err.Error()}
+		}
+		offset = offset + segment.width
+		if i == len(segments)-1 && !imageData {
+			break
+		}
+		n := ivData.parseNumField(value)
+		if remaining := len(record) - offset; n > remaining {
+			msg := fmt.Sprintf(msgImageViewDataSegment, n, remaining)
+			return &FieldError{FieldName: segment.fieldName, Value: value, Msg: msg}
+		}
+		offset = offset + n
+	}
+	if remaining := len(record) - offset; remaining > 0 {
+		msg := fmt.Sprintf(msgImageViewDataTrailing, remaining)
+		return &FieldError{FieldName: "LengthImageData", Value: value, Msg: msg}
+	}
 	return nil
 }
 
@@ -282,22 +399,22 @@ func (ivData *ImageViewData) fieldInclusion() error {
 	if ivData.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: ivData.recordType,
-			Msg:   msgFieldInclusion + ", did you use ImageViewData()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewData()?", Err: ErrFieldInclusion}
 	}
 	if ivData.EceInstitutionRoutingNumber == "" {
 		return &FieldError{FieldName: "EceInstitutionRoutingNumber",
 			Value: ivData.EceInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ImageViewData()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewData()?", Err: ErrFieldInclusion}
 	}
-	if ivData.EceInstitutionRoutingNumberField() == "000000000" {
+	if ivData.isZeroRoutingNumber(ivData.EceInstitutionRoutingNumber) {
 		return &FieldError{FieldName: "EceInstitutionRoutingNumber",
 			Value: ivData.EceInstitutionRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ImageViewData()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewData()?", Err: ErrFieldInclusion}
 	}
 	if ivData.BundleBusinessDate.IsZero() {
 		return &FieldError{FieldName: "BundleBusinessDate",
 			Value: ivData.BundleBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use ImageViewData()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewData()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -354,10 +471,10 @@ func (ivData *ImageViewData) ClippingCoordinateH2Field() string {
 
 // ClippingCoordinateV1Field gets the ClippingCoordinateV1 field
 func (ivData *ImageViewData) ClippingCoordinateV1Field() string {
-	return ivData.alphaField(ivData.ClippingCoordinateH1, 4)
+	return ivData.alphaField(ivData.ClippingCoordinateV1, 4)
 }
 
-// ClippingCoordinateV2Field gets the ClippingCoordinateH2 field
+// ClippingCoordinateV2Field gets the ClippingCoordinateV2 field
 func (ivData *ImageViewData) ClippingCoordinateV2Field() string {
 	return ivData.alphaField(ivData.ClippingCoordinateV2, 4)
 }
@@ -400,6 +517,52 @@ func (ivData *ImageViewData) ImageDataField() string {
 	return ivData.alphaField(s, uint(ivData.parseNumField(ivData.LengthImageData)))
 }
 
+// appendImageData appends the ImageData field to b as ImageDataField returns it
+func (ivData *ImageViewData) appendImageData(b []byte) []byte {
+	if decoded, err := ivData.DecodeImageData(); len(decoded) > 0 && err == nil {
+		return append(b, decoded...)
+	}
+	return ivData.appendBytesField(b, ivData.ImageData, uint(ivData.parseNumField(ivData.LengthImageData)))
+}
+
+// imageViewDataXML is the XML encoding of ImageViewData, where the byte fields are base64 encoded
+type imageViewDataXML struct {
+	imageViewDataFields
+	DigitalSignature string
+	ImageData        string
+}
+
+type imageViewDataFields ImageViewData
+
+// MarshalXML encodes ImageViewData with DigitalSignature and ImageData as base64
+func (ivData ImageViewData) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(imageViewDataXML{
+		imageViewDataFields: imageViewDataFields(ivData),
+		DigitalSignature:    base64.StdEncoding.EncodeToString(ivData.DigitalSignature),
+		ImageData:           base64.StdEncoding.EncodeToString(ivData.ImageData),
+	}, start)
+}
+
+// UnmarshalXML decodes ImageViewData encoded by MarshalXML
+func (ivData *ImageViewData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v imageViewDataXML
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	digitalSignature, err := base64.StdEncoding.DecodeString(v.DigitalSignature)
+	if err != nil {
+		return fmt.Errorf("DigitalSignature: %v", err)
+	}
+	imageData, err := base64.StdEncoding.DecodeString(v.ImageData)
+	if err != nil {
+		return fmt.Errorf("ImageData: %v", err)
+	}
+	*ivData = ImageViewData(v.imageViewDataFields)
+	ivData.DigitalSignature = digitalSignature
+	ivData.ImageData = imageData
+	return nil
+}
+
 // DecodeImageData attempts to read ImageData as a base64 blob. Other formats may be
 // supported in the future.
 func (ivData *ImageViewData) DecodeImageData() ([]byte, error) {
@@ -417,3 +580,17 @@ func (ivData *ImageViewData) DecodeImageData() ([]byte, error) {
 	}
 	return out, nil
 }
+
+// copyImageViewData returns a copy of images whose DigitalSignature and ImageData aren't shared with it
+func copyImageViewData(images []ImageViewData) []ImageViewData {
+	c := append([]ImageViewData(nil), images...)
+	for i := range c {
+		if c[i].DigitalSignature != nil {
+			c[i].DigitalSignature = append([]byte(nil), c[i].DigitalSignature...)
+		}
+		if c[i].ImageData != nil {
+			c[i].ImageData = append([]byte(nil), c[i].ImageData...)
+		}
+	}
+	return c
+}
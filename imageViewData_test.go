@@ -7,7 +7,9 @@ package imagecashletter
 import (
 	"bytes"
 	"encoding/base64"
+	"io/ioutil"
 	"log"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -151,6 +153,122 @@ func TestIVDParseCrash(t *testing.T) {
 	}
 }
 
+// TestIVDataSegmentLengths validates a length field which does not match the variable length fields of the
+// record is returned as a FieldError by the reader
+func TestIVDataSegmentLengths(t *testing.T) {
+	prefix := "5212104288220180915  1                                                              0                "
+	tests := []struct {
+		// segments follow the fixed fields of the record
+		segments  string
+		fieldName string
+	}{
+		{"0100" + "00000" + "0000001" + " ", "LengthImageReferenceKey"},
+		{"00A0" + "00000" + "0000001" + " ", "LengthImageReferenceKey"},
+		{"0000" + "00A00" + "0000001" + " ", "LengthDigitalSignature"},
+		{"0000" + "00009" + "0000001" + " ", "LengthDigitalSignature"},
+		{"0000" + "00000" + "0000009" + " ", "LengthImageData"},
+		{"0000" + "00000" + "0000000" + " ", "LengthImageData"},
+		{"0000" + "00000" + "000", "LengthImageData"},
+	}
+	for _, test := range tests {
+		line := prefix + test.segments
+		r := NewReader(strings.NewReader(line))
+		r.line = line
+		r.addCurrentCashLetter(NewCashLetter(mockCashLetterHeader()))
+		b := NewBundle(mockBundleHeader())
+		r.currentCashLetter.AddBundle(b)
+		r.addCurrentBundle(b)
+		r.currentCashLetter.currentBundle.AddCheckDetail(mockCheckDetail())
+
+		err := r.parseImageViewData()
+		p, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%q: %T: %v", test.segments, err, err)
+			continue
+		}
+		if e, ok := p.Err.(*FieldError); !ok || e.FieldName != test.fieldName {
+			t.Errorf("%q: %T: %s", test.segments, p.Err, p.Err)
+		}
+		if n := len(r.currentCashLetter.currentBundle.GetChecks()[0].ImageViewData); n != 0 {
+			t.Errorf("%q: %d ImageViewData added", test.segments, n)
+		}
+	}
+
+	// a record read with ReadSkipImageDataOption ends before ImageData
+	var ivData ImageViewData
+	if err := ivData.validateSegments(prefix+"0000"+"00000"+"0000001", false); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := ivData.validateSegments(prefix+"0000"+"00000"+"0000001"+" ", false); err == nil {
+		t.Error("expected error for ImageData in a record read without it")
+	}
+	if err := ivData.validateSegments(prefix+"0000"+"00000"+"0000001"+" ", true); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIVDataLengthImageData validates LengthImageData is numeric
+func TestIVDataLengthImageData(t *testing.T) {
+	ivData := mockImageViewData()
+	ivData.LengthImageData = "00000A1"
+	err := ivData.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "LengthImageData" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIVDataMetadataOnly validates image views with a LengthImageData of zero and no ImageData are read,
+// validated and written unchanged
+func TestIVDataMetadataOnly(t *testing.T) {
+	path := filepath.Join("test", "testdata", "image-view-metadata-only.icl")
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	views := 0
+	for _, cd := range file.AllCheckDetails() {
+		for _, ivData := range cd.ImageViewData {
+			if ivData.LengthImageData != "0000000" || len(ivData.ImageData) != 0 {
+				t.Errorf("LengthImageData %q with %d bytes of ImageData", ivData.LengthImageData, len(ivData.ImageData))
+			}
+			if err := ivData.Validate(); err != nil {
+				t.Errorf("%T: %s", err, err)
+			}
+			views++
+		}
+	}
+	if views == 0 {
+		t.Fatal("no ImageViewData read")
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(buf.Bytes(), bs) {
+		t.Error("file was not written unchanged")
+	}
+
+	// a record without ImageData ends after LengthImageData and is written unchanged
+	line := "5212104288220180915  1                                                              0                " +
+		"0000" + "00000" + "0000000"
+	var ivData ImageViewData
+	ivData.Parse(line)
+	if err := ivData.validateSegments(line, true); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(ivData.ImageData) != 0 || ivData.String() != line {
+		t.Errorf("%q", ivData.String())
+	}
+}
+
 // TestIVDataString tests validating that a known parsed ImageViewData an return to a string of the
 // same value
 func TestIVDataString(t *testing.T) {
@@ -244,6 +362,92 @@ func TestIVImageReferenceKey(t *testing.T) {
 	}
 }
 
+// TestIVDataSecurityLengths validates the lengths and presence of the security fields
+func TestIVDataSecurityLengths(t *testing.T) {
+	tests := []struct {
+		fieldName string
+		update    func(ivData *ImageViewData)
+	}{
+		{"SecurityOriginatorName", func(ivData *ImageViewData) { ivData.SecurityOriginatorName = "Security Originator" }},
+		{"SecurityAuthenticatorName", func(ivData *ImageViewData) { ivData.SecurityAuthenticatorName = "Security Authenticator" }},
+		{"SecurityKeyName", func(ivData *ImageViewData) { ivData.SecurityKeyName = "Security Key Name" }},
+		{"LengthImageReferenceKey", func(ivData *ImageViewData) { ivData.LengthImageReferenceKey = "00A1" }},
+		{"ImageReferenceKey", func(ivData *ImageViewData) {
+			ivData.LengthImageReferenceKey = "0004"
+			ivData.ImageReferenceKey = "ARCHIVE"
+		}},
+		{"LengthDigitalSignature", func(ivData *ImageViewData) { ivData.LengthDigitalSignature = "0000A" }},
+		{"DigitalSignature", func(ivData *ImageViewData) { ivData.DigitalSignature = []byte("signature") }},
+		{"DigitalSignature", func(ivData *ImageViewData) { ivData.LengthDigitalSignature = "00009" }},
+		{"SecurityKeyName", func(ivData *ImageViewData) {
+			ivData.SecurityKeyName = ""
+			ivData.LengthDigitalSignature = "00009"
+			ivData.DigitalSignature = []byte("signature")
+		}},
+	}
+	for _, tc := range tests {
+		ivData := mockImageViewData()
+		tc.update(&ivData)
+		err := ivData.Validate()
+		if e, ok := err.(*FieldError); !ok || e.FieldName != tc.fieldName {
+			t.Errorf("%s: %T: %v", tc.fieldName, err, err)
+		}
+	}
+
+	ivData := mockImageViewData()
+	ivData.LengthImageReferenceKey = "0010"
+	ivData.ImageReferenceKey = "ARCHIVE"
+	ivData.LengthDigitalSignature = "00009"
+	ivData.DigitalSignature = []byte("signature")
+	if err := ivData.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIVDataSecurityRoundTrip reads a File whose ImageViewData have security names, clipping coordinates,
+// image reference keys and digital signatures and writes it back unchanged
+func TestIVDataSecurityRoundTrip(t *testing.T) {
+	fixture, err := ioutil.ReadFile(filepath.Join("test", "testdata", "image-view-security.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(fixture)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.Validate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	ivData := file.CashLetters[0].Bundles[0].Checks[0].ImageViewData[0]
+	if ivData.SecurityOriginatorName != "MOOV BANK ORIG" {
+		t.Errorf("SecurityOriginatorName=%q", ivData.SecurityOriginatorName)
+	}
+	if ivData.SecurityAuthenticatorName != "FED AUTH SVC" {
+		t.Errorf("SecurityAuthenticatorName=%q", ivData.SecurityAuthenticatorName)
+	}
+	if ivData.SecurityKeyName != "KEY-2018-09" {
+		t.Errorf("SecurityKeyName=%q", ivData.SecurityKeyName)
+	}
+	if ivData.ClippingCoordinateV1 != "0020" {
+		t.Errorf("ClippingCoordinateV1=%q", ivData.ClippingCoordinateV1)
+	}
+	if ivData.LengthImageReferenceKey != "0022" || ivData.ImageReferenceKey != "ARCHIVE/2018/09/05/1-A" {
+		t.Errorf("LengthImageReferenceKey=%q ImageReferenceKey=%q", ivData.LengthImageReferenceKey, ivData.ImageReferenceKey)
+	}
+	if ivData.LengthDigitalSignature != "00032" || string(ivData.DigitalSignature) != "SIG1234567890ABCDEF+/=SIGNATURE0" {
+		t.Errorf("LengthDigitalSignature=%q DigitalSignature=%q", ivData.LengthDigitalSignature, ivData.DigitalSignature)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(buf.Bytes(), fixture) {
+		t.Error("ImageViewData security fields changed when the File was written")
+	}
+}
+
 // Field Inclusion
 
 // TestIVDataFIRecordType validation
@@ -6,13 +6,108 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
+	"strconv"
 	"time"
 	"unicode/utf8"
 )
 
 // Errors specific to a ImageViewDetail Record
 
+// ImageSide identifies the side of the item an image view shows, as conveyed by the ViewSideIndicator of
+// ImageViewDetail.
+type ImageSide int
+
+const (
+	// ImageSideFront is the front of the item
+	ImageSideFront ImageSide = 0
+	// ImageSideBack is the rear of the item
+	ImageSideBack ImageSide = 1
+)
+
+func (s ImageSide) String() string {
+	switch s {
+	case ImageSideFront:
+		return "Front"
+	case ImageSideBack:
+		return "Back"
+	}
+	return fmt.Sprintf("ImageSide(%d)", int(s))
+}
+
+// ImageViewDescriptor identifies whether an image view is the full view of the item or a partial view of an
+// area of interest, as conveyed by the ViewDescriptor of ImageViewDetail.
+type ImageViewDescriptor int
+
+const (
+	// ViewDescriptorFull is the full view of the item
+	ViewDescriptorFull ImageViewDescriptor = iota
+	// ViewDescriptorPartial is a partial view of an unspecified area of interest
+	ViewDescriptorPartial
+	// ViewDescriptorDate is a partial view of the date
+	ViewDescriptorDate
+	// ViewDescriptorPayee is a partial view of the payee
+	ViewDescriptorPayee
+	// ViewDescriptorConvenienceAmount is a partial view of the convenience amount
+	ViewDescriptorConvenienceAmount
+	// ViewDescriptorLegalAmount is a partial view of the amount in words (legal amount)
+	ViewDescriptorLegalAmount
+	// ViewDescriptorSignature is a partial view of the signature(s)
+	ViewDescriptorSignature
+	// ViewDescriptorPayorNameAddress is a partial view of the payor name and address
+	ViewDescriptorPayorNameAddress
+	// ViewDescriptorMICRLine is a partial view of the MICR line
+	ViewDescriptorMICRLine
+	// ViewDescriptorMemoLine is a partial view of the memo line
+	ViewDescriptorMemoLine
+	// ViewDescriptorPayorBank is a partial view of the payor bank name and address
+	ViewDescriptorPayorBank
+	// ViewDescriptorPayeeEndorsement is a partial view of the payee endorsement
+	ViewDescriptorPayeeEndorsement
+	// ViewDescriptorBOFDEndorsement is a partial view of the Bank Of First Deposit (BOFD) endorsement
+	ViewDescriptorBOFDEndorsement
+	// ViewDescriptorTransitEndorsement is a partial view of the transit endorsement
+	ViewDescriptorTransitEndorsement
+)
+
+// IsFull reports whether d is the full view of the item rather than a partial view
+func (d ImageViewDescriptor) IsFull() bool {
+	return d == ViewDescriptorFull
+}
+
+func (d ImageViewDescriptor) String() string {
+	switch d {
+	case ViewDescriptorFull:
+		return "Full"
+	case ViewDescriptorPartial:
+		return "Partial"
+	case ViewDescriptorDate:
+		return "Date"
+	case ViewDescriptorPayee:
+		return "Payee"
+	case ViewDescriptorConvenienceAmount:
+		return "Convenience Amount"
+	case ViewDescriptorLegalAmount:
+		return "Legal Amount"
+	case ViewDescriptorSignature:
+		return "Signature"
+	case ViewDescriptorPayorNameAddress:
+		return "Payor Name and Address"
+	case ViewDescriptorMICRLine:
+		return "MICR Line"
+	case ViewDescriptorMemoLine:
+		return "Memo Line"
+	case ViewDescriptorPayorBank:
+		return "Payor Bank"
+	case ViewDescriptorPayeeEndorsement:
+		return "Payee Endorsement"
+	case ViewDescriptorBOFDEndorsement:
+		return "BOFD Endorsement"
+	case ViewDescriptorTransitEndorsement:
+		return "Transit Endorsement"
+	}
+	return fmt.Sprintf("ImageViewDescriptor(%d)", int(d))
+}
+
 // ImageViewDetail Record
 type ImageViewDetail struct {
 	// ID is a client defined string used as a reference to this record.
@@ -165,6 +260,8 @@ type ImageViewDetail struct {
 	OverrideIndicator string `json:"overrideIndicator"`
 	// reservedTwo is a field reserved for future use.  Reserved should be blank.
 	reservedTwo string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for ImageCashLetter data validation
 	validator
 	// converters is composed for ImageCashLetter to golang Converters
@@ -182,7 +279,12 @@ func (ivDetail *ImageViewDetail) setRecordType() {
 	if ivDetail == nil {
 		return
 	}
-	ivDetail.recordType = "50"
+	ivDetail.recordType = RecordTypeImageViewDetail
+}
+
+// RecordType returns the record type of the ImageViewDetail, which is RecordTypeImageViewDetail once set by NewImageViewDetail or Parse
+func (ivDetail *ImageViewDetail) RecordType() string {
+	return ivDetail.recordType
 }
 
 // Parse takes the input record string and parses the ImageViewDetail values
@@ -224,37 +326,40 @@ func (ivDetail *ImageViewDetail) Parse(record string) {
 	// 58-65
 	ivDetail.UserField = ivDetail.parseStringField(record[57:65])
 	// 66-66
-	ivDetail.reserved = " "
+	ivDetail.reserved = ivDetail.parseReservedField(record, 65, 66)
 	// 67-67
 	ivDetail.OverrideIndicator = ivDetail.parseStringField(record[66:67])
 	// 68-80
-	ivDetail.reservedTwo = "             "
+	ivDetail.reservedTwo = ivDetail.parseReservedField(record, 67, 80)
 }
 
 // String writes the ImageViewDetail struct to a string.
 func (ivDetail *ImageViewDetail) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(ivDetail.recordType)
-	buf.WriteString(ivDetail.ImageIndicatorField())
-	buf.WriteString(ivDetail.ImageCreatorRoutingNumberField())
-	buf.WriteString(ivDetail.ImageCreatorDateField())
-	buf.WriteString(ivDetail.ImageViewFormatIndicatorField())
-	buf.WriteString(ivDetail.ImageViewCompressionAlgorithmField())
-	buf.WriteString(ivDetail.ImageViewDataSizeField())
-	buf.WriteString(ivDetail.ViewSideIndicatorField())
-	buf.WriteString(ivDetail.ViewDescriptorField())
-	buf.WriteString(ivDetail.DigitalSignatureIndicatorField())
-	buf.WriteString(ivDetail.DigitalSignatureMethodField())
-	buf.WriteString(ivDetail.SecurityKeySizeField())
-	buf.WriteString(ivDetail.ProtectedDataStartField())
-	buf.WriteString(ivDetail.ProtectedDataLengthField())
-	buf.WriteString(ivDetail.ImageRecreateIndicatorField())
-	buf.WriteString(ivDetail.UserFieldField())
-	buf.WriteString(ivDetail.reservedField())
-	buf.WriteString(ivDetail.OverrideIndicatorField())
-	buf.WriteString(ivDetail.reservedTwoField())
-	return buf.String()
+	return string(ivDetail.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ImageViewDetail record, as String returns it, to b and returns the extended buffer.
+func (ivDetail *ImageViewDetail) AppendTo(b []byte) []byte {
+	b = append(b, ivDetail.recordType...)
+	b = ivDetail.appendNumericField(b, ivDetail.ImageIndicator, 1)
+	b = ivDetail.appendStringField(b, ivDetail.ImageCreatorRoutingNumber, 9)
+	b = ivDetail.appendYYYYMMDDDate(b, ivDetail.ImageCreatorDate)
+	b = ivDetail.appendAlphaField(b, ivDetail.ImageViewFormatIndicator, 2)
+	b = ivDetail.appendAlphaField(b, ivDetail.ImageViewCompressionAlgorithm, 2)
+	b = ivDetail.appendAlphaField(b, ivDetail.ImageViewDataSize, 7)
+	b = ivDetail.appendNumericField(b, ivDetail.ViewSideIndicator, 1)
+	b = ivDetail.appendAlphaField(b, ivDetail.ViewDescriptor, 2)
+	b = ivDetail.appendNumericField(b, ivDetail.DigitalSignatureIndicator, 1)
+	b = ivDetail.appendAlphaField(b, ivDetail.DigitalSignatureMethod, 2)
+	b = ivDetail.appendNumericField(b, ivDetail.SecurityKeySize, 5)
+	b = ivDetail.appendNumericField(b, ivDetail.ProtectedDataStart, 7)
+	b = ivDetail.appendNumericField(b, ivDetail.ProtectedDataLength, 7)
+	b = ivDetail.appendNumericField(b, ivDetail.ImageRecreateIndicator, 1)
+	b = ivDetail.appendAlphaField(b, ivDetail.UserField, 8)
+	b = ivDetail.appendAlphaField(b, ivDetail.reserved, 1)
+	b = ivDetail.appendAlphaField(b, ivDetail.OverrideIndicator, 1)
+	b = ivDetail.appendAlphaField(b, ivDetail.reservedTwo, 13)
+	return b
 }
 
 // Validate performs ImageCashLetter format rule checks on the record and returns an error if not Validated
@@ -264,9 +369,9 @@ func (ivDetail *ImageViewDetail) Validate() error {
 		return err
 	}
 	// Mandatory
-	if ivDetail.recordType != "50" {
+	if ivDetail.recordType != RecordTypeImageViewDetail {
 		msg := fmt.Sprintf(msgRecordType, 50)
-		return &FieldError{FieldName: "recordType", Value: ivDetail.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ivDetail.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	// Mandatory
 	if err := ivDetail.isImageIndicator(ivDetail.ImageIndicator); err != nil {
@@ -328,6 +433,9 @@ func (ivDetail *ImageViewDetail) Validate() error {
 	if err := ivDetail.isAlphanumericSpecial(ivDetail.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: ivDetail.UserField, Msg: err.Error()}
 	}
+	if err := ivDetail.isDateInRange(ivDetail.ImageCreatorDate); err != nil {
+		return &FieldError{FieldName: "ImageCreatorDate", Value: ivDetail.ImageCreatorDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -337,27 +445,27 @@ func (ivDetail *ImageViewDetail) fieldInclusion() error {
 	if ivDetail.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: ivDetail.recordType,
-			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?", Err: ErrFieldInclusion}
 	}
 	if ivDetail.ImageCreatorRoutingNumber == "" {
 		return &FieldError{FieldName: "ImageCreatorRoutingNumber",
 			Value: ivDetail.ImageCreatorRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?", Err: ErrFieldInclusion}
 	}
-	if ivDetail.ImageCreatorRoutingNumberField() == "000000000" {
+	if ivDetail.isZeroRoutingNumber(ivDetail.ImageCreatorRoutingNumber) {
 		return &FieldError{FieldName: "ImageCreatorRoutingNumber",
 			Value: ivDetail.ImageCreatorRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?", Err: ErrFieldInclusion}
 	}
 	if ivDetail.ImageCreatorDate.IsZero() {
 		return &FieldError{FieldName: "ImageCreatorDate",
 			Value: ivDetail.ImageCreatorDate.String(),
-			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?", Err: ErrFieldInclusion}
 	}
 	if ivDetail.ViewDescriptor == "" {
 		return &FieldError{FieldName: "ViewDescriptor",
 			Value: ivDetail.ViewDescriptor,
-			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ImageViewDetail()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -402,6 +510,25 @@ func (ivDetail *ImageViewDetail) ViewDescriptorField() string {
 	return ivDetail.alphaField(ivDetail.ViewDescriptor, 2)
 }
 
+// Side returns the ViewSideIndicator as an ImageSide. An error is returned if ViewSideIndicator is not
+// a defined value.
+func (ivDetail *ImageViewDetail) Side() (ImageSide, error) {
+	if err := ivDetail.isViewSideIndicator(ivDetail.ViewSideIndicator); err != nil {
+		return 0, &FieldError{FieldName: "ViewSideIndicator", Value: ivDetail.ViewSideIndicatorField(), Msg: err.Error()}
+	}
+	return ImageSide(ivDetail.ViewSideIndicator), nil
+}
+
+// Descriptor returns the ViewDescriptor as an ImageViewDescriptor. An error is returned if ViewDescriptor
+// is not a defined value.
+func (ivDetail *ImageViewDetail) Descriptor() (ImageViewDescriptor, error) {
+	if err := ivDetail.isViewDescriptor(ivDetail.ViewDescriptor); err != nil {
+		return 0, &FieldError{FieldName: "ViewDescriptor", Value: ivDetail.ViewDescriptor, Msg: err.Error()}
+	}
+	n, _ := strconv.Atoi(ivDetail.ViewDescriptor)
+	return ImageViewDescriptor(n), nil
+}
+
 // DigitalSignatureIndicatorField gets a string of the DigitalSignatureIndicator field
 func (ivDetail *ImageViewDetail) DigitalSignatureIndicatorField() string {
 	return ivDetail.numericField(ivDetail.DigitalSignatureIndicator, 1)
@@ -264,6 +264,35 @@ func TestIVDetailImageViewCompressionAlgorithm(t *testing.T) {
 	}
 }
 
+// TestIVDetailImageFormatCodes validates the ImageViewFormatIndicator and ImageViewCompressionAlgorithm codes
+func TestIVDetailImageFormatCodes(t *testing.T) {
+	tests := []struct {
+		format, compression string
+		fieldName           string
+	}{
+		{"00", "00", ""}, // TIFF 6 with Group 4 compression
+		{"21", "01", ""}, // JFIF with JPEG Baseline compression
+		{"24", "23", ""}, // JPEG 2000
+		{"25", "00", "ImageViewFormatIndicator"},
+		{"00", "03", "ImageViewCompressionAlgorithm"},
+	}
+	for _, test := range tests {
+		ivDetail := mockImageViewDetail()
+		ivDetail.ImageViewFormatIndicator = test.format
+		ivDetail.ImageViewCompressionAlgorithm = test.compression
+		err := ivDetail.Validate()
+		if test.fieldName == "" {
+			if err != nil {
+				t.Errorf("%T: %s", err, err)
+			}
+			continue
+		}
+		if e, ok := err.(*FieldError); !ok || e.FieldName != test.fieldName {
+			t.Errorf("%s/%s: %T: %s", test.format, test.compression, err, err)
+		}
+	}
+}
+
 // TestIVDetailViewSideIndicator validation
 func TestIVDetailViewSideIndicator(t *testing.T) {
 	ivDetail := mockImageViewDetail()
@@ -432,3 +461,50 @@ func TestIVDetailRuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString")
 	}
 }
+
+// TestIVDetailSide validates the typed ViewSideIndicator and ViewDescriptor
+func TestIVDetailSide(t *testing.T) {
+	ivDetail := mockImageViewDetail()
+	side, err := ivDetail.Side()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if side != ImageSideFront || side.String() != "Front" {
+		t.Errorf("unexpected ImageSide: %v", side)
+	}
+	descriptor, err := ivDetail.Descriptor()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !descriptor.IsFull() || descriptor.String() != "Full" {
+		t.Errorf("unexpected ImageViewDescriptor: %v", descriptor)
+	}
+
+	ivDetail.ViewSideIndicator = 1
+	ivDetail.ViewDescriptor = "08"
+	if side, err := ivDetail.Side(); err != nil || side != ImageSideBack {
+		t.Errorf("unexpected ImageSide %v: %v", side, err)
+	}
+	if descriptor, err := ivDetail.Descriptor(); err != nil || descriptor != ViewDescriptorMICRLine || descriptor.IsFull() {
+		t.Errorf("unexpected ImageViewDescriptor %v: %v", descriptor, err)
+	}
+
+	ivDetail.ViewSideIndicator = 2
+	if _, err := ivDetail.Side(); err != nil {
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "ViewSideIndicator" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+	for _, code := range []string{"", "1", "99", "AB"} {
+		ivDetail.ViewDescriptor = code
+		if _, err := ivDetail.Descriptor(); err != nil {
+			if e, ok := err.(*FieldError); !ok || e.FieldName != "ViewDescriptor" {
+				t.Errorf("%T: %s", err, err)
+			}
+		} else {
+			t.Errorf("ViewDescriptor %q: expected error", code)
+		}
+	}
+}
@@ -5,10 +5,14 @@
 package imagecashletter
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -38,3 +42,62 @@ func TestImageCashLetter__ReadCrashers(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestImageCashLetter__Concurrent reads, validates and writes files from many goroutines, each with its own
+// Reader and Writer, along with validating and writing one shared File. Run with -race to detect shared
+// mutable state.
+func TestImageCashLetter__Concurrent(t *testing.T) {
+	names := []string{"BNK20180905121042882-A.icl", "BNK20181010121042882-A.icl", "image-view-security.icl", "credit-items.icl"}
+	fixtures := make([][]byte, len(names))
+	for i := range names {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", names[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixtures[i] = bs
+	}
+	shared, err := NewReader(bytes.NewReader(fixtures[0])).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			bs := fixtures[g%len(fixtures)]
+			file, err := NewReader(bytes.NewReader(bs), ReadNormalizeOption()).Read()
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, f := range []*File{&file, &shared} {
+				if err := f.ValidateWith(WithStrict(), ProfileDSTU(), WithMaxBundleItems(500)); err != nil {
+					errs <- err
+					return
+				}
+				if err := f.ValidateConcurrent(2); err != nil {
+					errs <- err
+					return
+				}
+				var buf bytes.Buffer
+				if err := NewWriter(&buf, WriteFillOption(0, 0), WriteStrictFieldWidthsOption()).Write(f); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := json.Marshal(f); err != nil {
+					errs <- err
+					return
+				}
+				f.Summary()
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("%T: %s", err, err)
+	}
+}
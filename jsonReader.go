@@ -0,0 +1,383 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// jsonImageKey is the key of ImageViewData.ImageData in JSON
+const jsonImageKey = "imageData"
+
+// jsonImageMarker begins the placeholder jsonImageFilter writes in place of each ImageData, which is
+// followed by the index of the image as a 4 byte big-endian value
+var jsonImageMarker = []byte("\x00imagecashletter-image\x00")
+
+// jsonImageFilter is an io.Reader which copies the JSON document read from br, replacing the base64 string
+// of each "imageData" with a placeholder. Each image is decoded from base64 as it's read and kept in images,
+// so neither the document nor the base64 text of an image is held in memory.
+type jsonImageFilter struct {
+	br *bufio.Reader
+	// images holds the ImageData decoded from the document by the index in its placeholder
+	images [][]byte
+	// out holds the bytes to be returned by the following Reads
+	out []byte
+	// inString is set while a string other than an ImageData is being copied
+	inString bool
+	// escaped is set when the previous byte of the string is a backslash
+	escaped bool
+	// key holds the start of the current or last string, enough to compare it with jsonImageKey
+	key []byte
+	// keyState is 1 after a string matching jsonImageKey and 2 after the colon which follows it
+	keyState int
+}
+
+func (f *jsonImageFilter) Read(p []byte) (int, error) {
+	// fill p from the buffered input, blocking only until the first byte is available
+	for len(f.out) < len(p) && (len(f.out) == 0 || f.br.Buffered() > 0) {
+		b, err := f.br.ReadByte()
+		if err != nil {
+			if len(f.out) > 0 {
+				break
+			}
+			return 0, err
+		}
+		if err := f.copyByte(b); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, f.out)
+	if n == len(f.out) {
+		f.out = f.out[:0]
+	} else {
+		f.out = f.out[n:]
+	}
+	return n, nil
+}
+
+// copyByte appends b to out, or replaces the ImageData string b begins with its placeholder
+func (f *jsonImageFilter) copyByte(b byte) error {
+	if f.inString {
+		switch {
+		case f.escaped:
+			f.escaped = false
+			// a key with an escape isn't compared
+			if len(f.key) <= len(jsonImageKey) {
+				f.key = append(f.key, 0)
+			}
+		case b == '\\':
+			f.escaped = true
+		case b == '"':
+			f.inString = false
+			f.keyState = 0
+			if strings.EqualFold(string(f.key), jsonImageKey) {
+				f.keyState = 1
+			}
+		case len(f.key) <= len(jsonImageKey):
+			f.key = append(f.key, b)
+		}
+		f.out = append(f.out, b)
+		return nil
+	}
+	switch {
+	case b == ' ' || b == '\t' || b == '\r' || b == '\n':
+	case b == ':' && f.keyState == 1:
+		f.keyState = 2
+	case b == '"' && f.keyState == 2:
+		f.keyState = 0
+		return f.copyImage()
+	case b == '"':
+		f.inString = true
+		f.key = f.key[:0]
+		f.keyState = 0
+	default:
+		f.keyState = 0
+	}
+	f.out = append(f.out, b)
+	return nil
+}
+
+// copyImage decodes the ImageData string following its opening quote and appends its placeholder to out
+func (f *jsonImageFilter) copyImage() error {
+	data, err := ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, &jsonStringReader{br: f.br}))
+	if err != nil {
+		return fmt.Errorf("problem reading %s: %v", jsonImageKey, err)
+	}
+	placeholder := make([]byte, len(jsonImageMarker)+4)
+	copy(placeholder, jsonImageMarker)
+	binary.BigEndian.PutUint32(placeholder[len(jsonImageMarker):], uint32(len(f.images)))
+	f.images = append(f.images, data)
+	f.out = append(f.out, '"')
+	f.out = append(f.out, base64.StdEncoding.EncodeToString(placeholder)...)
+	f.out = append(f.out, '"')
+	return nil
+}
+
+// image returns the ImageData a placeholder stands for, and false if data isn't a placeholder
+func (f *jsonImageFilter) image(data []byte) ([]byte, bool) {
+	if len(data) != len(jsonImageMarker)+4 || !bytes.HasPrefix(data, jsonImageMarker) {
+		return nil, false
+	}
+	i := binary.BigEndian.Uint32(data[len(jsonImageMarker):])
+	if int(i) >= len(f.images) {
+		return nil, false
+	}
+	image := f.images[i]
+	f.images[i] = nil
+	return image, true
+}
+
+// jsonStringReader is an io.Reader of the contents of a JSON string which contains only ASCII, such as
+// base64 text, read up to and including its closing quote
+type jsonStringReader struct {
+	br   *bufio.Reader
+	done bool
+}
+
+func (r *jsonStringReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && !r.done {
+		b, err := r.br.ReadByte()
+		if err == io.EOF {
+			return n, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return n, err
+		}
+		switch b {
+		case '"':
+			r.done = true
+			continue
+		case '\\':
+			if b, err = r.unescape(); err != nil {
+				return n, err
+			}
+		}
+		p[n] = b
+		n++
+	}
+	if n == 0 && r.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// unescape reads the escape sequence following a backslash and returns the ASCII byte it stands for
+func (r *jsonStringReader) unescape() (byte, error) {
+	b, err := r.br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case '/', '\\', '"':
+		return b, nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 'u':
+		hex := make([]byte, 4)
+		if _, err := io.ReadFull(r.br, hex); err != nil {
+			return 0, err
+		}
+		if v, err := strconv.ParseUint(string(hex), 16, 8); err == nil && v < 0x80 {
+			return byte(v), nil
+		}
+	}
+	return 0, errors.New("invalid escape in base64 string")
+}
+
+// jsonFileDecoder decodes a File from JSON a record at a time with the tokens of a json.Decoder, reading
+// from a jsonImageFilter so the ImageData of each ImageViewData is decoded without buffering the document
+type jsonFileDecoder struct {
+	dec    *json.Decoder
+	filter *jsonImageFilter
+}
+
+func newJSONFileDecoder(r io.Reader) *jsonFileDecoder {
+	filter := &jsonImageFilter{br: bufio.NewReader(r)}
+	return &jsonFileDecoder{dec: json.NewDecoder(filter), filter: filter}
+}
+
+// decodeFile decodes the JSON object of a File into file, streaming its CashLetters
+func (d *jsonFileDecoder) decodeFile(file *File) error {
+	_, err := d.decodeObject(file, map[string]func() error{
+		"cashLetters": func() error {
+			return d.decodeArray(func() error {
+				var cl CashLetter
+				if err := d.decodeCashLetter(&cl); err != nil {
+					return err
+				}
+				file.CashLetters = append(file.CashLetters, cl)
+				return nil
+			})
+		},
+	})
+	return err
+}
+
+// decodeCashLetter decodes the JSON object of a CashLetter into cl, streaming its Bundles. null leaves cl unchanged.
+func (d *jsonFileDecoder) decodeCashLetter(cl *CashLetter) error {
+	_, err := d.decodeObject(cl, map[string]func() error{
+		"bundles": func() error {
+			return d.decodeArray(func() error {
+				var b *Bundle
+				if err := d.decodeBundle(&b); err != nil {
+					return err
+				}
+				cl.Bundles = append(cl.Bundles, b)
+				return nil
+			})
+		},
+	})
+	return err
+}
+
+// decodeBundle decodes the JSON object of a Bundle into a new Bundle set to *b, streaming its items.
+// null leaves *b nil.
+func (d *jsonFileDecoder) decodeBundle(b **Bundle) error {
+	bundle := &Bundle{}
+	ok, err := d.decodeObject(bundle, map[string]func() error{
+		"checks": func() error {
+			return d.decodeArray(func() error {
+				var cd *CheckDetail
+				if err := d.dec.Decode(&cd); err != nil {
+					return err
+				}
+				bundle.Checks = append(bundle.Checks, cd)
+				return nil
+			})
+		},
+		"returns": func() error {
+			return d.decodeArray(func() error {
+				var rd *ReturnDetail
+				if err := d.dec.Decode(&rd); err != nil {
+					return err
+				}
+				bundle.Returns = append(bundle.Returns, rd)
+				return nil
+			})
+		},
+	})
+	if ok {
+		*b = bundle
+	}
+	return err
+}
+
+// decodeObject decodes a JSON object into v. The value of each key in streamed is decoded by its function,
+// and the value of every other key is decoded into v as encoding/json would. Keys are matched without
+// regard to case, as encoding/json matches them. false is returned when the object is null.
+func (d *jsonFileDecoder) decodeObject(v interface{}, streamed map[string]func() error) (bool, error) {
+	tok, err := d.dec.Token()
+	if err != nil || tok == nil {
+		return false, err
+	}
+	if tok != json.Delim('{') {
+		return false, fmt.Errorf("expected an object but found %v", tok)
+	}
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return true, err
+		}
+		key, _ := tok.(string)
+		if fn := streamedFunc(streamed, key); fn != nil {
+			if err := fn(); err != nil {
+				return true, err
+			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := d.dec.Decode(&raw); err != nil {
+			return true, err
+		}
+		// decode the single field into v so its struct tags are matched as encoding/json matches them
+		field, err := json.Marshal(map[string]json.RawMessage{key: raw})
+		if err != nil {
+			return true, err
+		}
+		if err := json.Unmarshal(field, v); err != nil {
+			return true, err
+		}
+	}
+	_, err = d.dec.Token()
+	return true, err
+}
+
+// streamedFunc returns the function of streamed for key, compared without regard to case
+func streamedFunc(streamed map[string]func() error, key string) func() error {
+	for k, fn := range streamed {
+		if strings.EqualFold(k, key) {
+			return fn
+		}
+	}
+	return nil
+}
+
+// decodeArray calls fn to decode each element of a JSON array. null is an empty array.
+func (d *jsonFileDecoder) decodeArray(fn func() error) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected an array but found %v", tok)
+	}
+	for d.dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	_, err = d.dec.Token()
+	return err
+}
+
+// setImages replaces the placeholder ImageData of every ImageViewData in file with the image it stands for
+func (d *jsonFileDecoder) setImages(file *File) {
+	setImages := func(images []ImageViewData) {
+		for i := range images {
+			if image, ok := d.filter.image(images[i].ImageData); ok {
+				images[i].ImageData = image
+			}
+		}
+	}
+	setBundleImages := func(b *Bundle) {
+		if b == nil {
+			return
+		}
+		for _, cd := range b.Checks {
+			if cd != nil {
+				setImages(cd.ImageViewData)
+			}
+		}
+		for _, rd := range b.Returns {
+			if rd != nil {
+				setImages(rd.ImageViewData)
+			}
+		}
+	}
+	for i := range file.CashLetters {
+		for _, b := range file.CashLetters[i].Bundles {
+			setBundleImages(b)
+		}
+	}
+	for i := range file.Bundles {
+		setBundleImages(&file.Bundles[i])
+	}
+}
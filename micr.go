@@ -0,0 +1,54 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// E-13B MICR symbols as Unicode OCR characters
+const (
+	// MICRTransitSymbol delimits the routing number
+	MICRTransitSymbol = "⑆"
+	// MICRAmountSymbol delimits the amount
+	MICRAmountSymbol = "⑇"
+	// MICROnUsSymbol delimits the On-Us and Auxiliary On-Us fields
+	MICROnUsSymbol = "⑈"
+	// MICRDashSymbol separates digits within a field
+	MICRDashSymbol = "⑉"
+)
+
+// micrSymbols replaces the characters representing MICR symbols in the On-Us fields of a record,
+// where / is the On-Us symbol and - is the dash symbol
+var micrSymbols = strings.NewReplacer("/", MICROnUsSymbol, "-", MICRDashSymbol)
+
+// MICRLine returns the MICR line of the check assembled from its fields in the order they are printed,
+// separated by blanks:
+//
+//	⑈AuxiliaryOnUs⑈ ExternalProcessingCode ⑆RoutingNumber⑆ OnUs⑈ ⑇ItemAmount⑇
+//
+// RoutingNumber is the PayorBankRoutingNumber followed by the PayorBankCheckDigit. The Auxiliary On-Us, External Processing Code and On-Us fields are left out when they are blank. The
+// MICR line is derived from the CheckDetail and isn't written to a File.
+func (cd *CheckDetail) MICRLine() string {
+	var fields []string
+	if aux := strings.TrimSpace(cd.AuxiliaryOnUs); aux != "" {
+		fields = append(fields, MICROnUsSymbol+micrSymbols.Replace(aux)+MICROnUsSymbol)
+	}
+	if epc := strings.TrimSpace(cd.ExternalProcessingCode); epc != "" {
+		fields = append(fields, epc)
+	}
+	routing := strings.TrimSpace(cd.PayorBankRoutingNumber) + strings.TrimSpace(cd.PayorBankCheckDigit)
+	fields = append(fields, MICRTransitSymbol+micrSymbols.Replace(routing)+MICRTransitSymbol)
+	if onUs := strings.TrimSpace(cd.OnUs); onUs != "" {
+		onUs = micrSymbols.Replace(onUs)
+		if !strings.HasSuffix(onUs, MICROnUsSymbol) {
+			onUs = onUs + MICROnUsSymbol
+		}
+		fields = append(fields, onUs)
+	}
+	fields = append(fields, fmt.Sprintf("%s%010d%s", MICRAmountSymbol, cd.ItemAmount, MICRAmountSymbol))
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"strings"
+)
+
+// codeRecord is a record with alphabetic code fields which Reader normalizes when ReadNormalizeOption
+// is used
+type codeRecord interface {
+	// normalizeCodes upper-cases the code fields of the record and removes their padding
+	normalizeCodes()
+}
+
+// normalizeCode returns code upper-cased without leading or trailing blanks
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (fh *FileHeader) normalizeCodes() {
+	fh.TestFileIndicator = normalizeCode(fh.TestFileIndicator)
+	fh.ResendIndicator = normalizeCode(fh.ResendIndicator)
+	fh.CountryCode = normalizeCode(fh.CountryCode)
+	fh.CompanionDocumentIndicator = normalizeCode(fh.CompanionDocumentIndicator)
+}
+
+func (clh *CashLetterHeader) normalizeCodes() {
+	clh.RecordTypeIndicator = normalizeCode(clh.RecordTypeIndicator)
+	clh.DocumentationTypeIndicator = normalizeCode(clh.DocumentationTypeIndicator)
+	clh.FedWorkType = normalizeCode(clh.FedWorkType)
+	clh.ReturnsIndicator = normalizeCode(clh.ReturnsIndicator)
+}
+
+func (cd *CheckDetail) normalizeCodes() {
+	cd.DocumentationTypeIndicator = normalizeCode(cd.DocumentationTypeIndicator)
+	cd.ReturnAcceptanceIndicator = normalizeCode(cd.ReturnAcceptanceIndicator)
+	cd.BOFDIndicator = normalizeCode(cd.BOFDIndicator)
+	cd.ArchiveTypeIndicator = normalizeCode(cd.ArchiveTypeIndicator)
+}
+
+func (cdAddendumA *CheckDetailAddendumA) normalizeCodes() {
+	cdAddendumA.TruncationIndicator = normalizeCode(cdAddendumA.TruncationIndicator)
+	cdAddendumA.BOFDConversionIndicator = normalizeCode(cdAddendumA.BOFDConversionIndicator)
+}
+
+func (cdAddendumC *CheckDetailAddendumC) normalizeCodes() {
+	cdAddendumC.TruncationIndicator = normalizeCode(cdAddendumC.TruncationIndicator)
+	cdAddendumC.EndorsingBankConversionIndicator = normalizeCode(cdAddendumC.EndorsingBankConversionIndicator)
+	cdAddendumC.ReturnReason = normalizeCode(cdAddendumC.ReturnReason)
+}
+
+func (rd *ReturnDetail) normalizeCodes() {
+	rd.ReturnReason = normalizeCode(rd.ReturnReason)
+	rd.DocumentationTypeIndicator = normalizeCode(rd.DocumentationTypeIndicator)
+	rd.ArchiveTypeIndicator = normalizeCode(rd.ArchiveTypeIndicator)
+}
+
+func (rdAddendumA *ReturnDetailAddendumA) normalizeCodes() {
+	rdAddendumA.TruncationIndicator = normalizeCode(rdAddendumA.TruncationIndicator)
+	rdAddendumA.BOFDConversionIndicator = normalizeCode(rdAddendumA.BOFDConversionIndicator)
+}
+
+func (rdAddendumD *ReturnDetailAddendumD) normalizeCodes() {
+	rdAddendumD.TruncationIndicator = normalizeCode(rdAddendumD.TruncationIndicator)
+	rdAddendumD.EndorsingBankConversionIndicator = normalizeCode(rdAddendumD.EndorsingBankConversionIndicator)
+	rdAddendumD.ReturnReason = normalizeCode(rdAddendumD.ReturnReason)
+}
+
+func (ivDetail *ImageViewDetail) normalizeCodes() {
+	ivDetail.OverrideIndicator = normalizeCode(ivDetail.OverrideIndicator)
+}
+
+func (ci *CreditItem) normalizeCodes() {
+	ci.DocumentationTypeIndicator = normalizeCode(ci.DocumentationTypeIndicator)
+	ci.AccountTypeCode = normalizeCode(ci.AccountTypeCode)
+}
+
+func (cr *Credit) normalizeCodes() {
+	cr.DocumentationTypeIndicator = normalizeCode(cr.DocumentationTypeIndicator)
+	cr.AccountTypeCode = normalizeCode(cr.AccountTypeCode)
+}
@@ -6,9 +6,25 @@ package imagecashletter
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"os"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// recordLengthFieldSize is the size of the binary record length (field 0) preceding each blocked record
+	recordLengthFieldSize = 4
+	// blockFill pads the final block of blocked output
+	blockFill byte = 0x00
+	// defaultBufferSize is the initial capacity of the buffer records are read into
+	defaultBufferSize = 4096
 )
 
 // ParseError is returned for parsing reader errors.
@@ -26,6 +42,11 @@ func (e *ParseError) Error() string {
 	return fmt.Sprintf("line:%d record:%s %T %s", e.Line, e.Record, e.Err, e.Err)
 }
 
+// Unwrap returns the error of the record, so errors.As and errors.Is can be used with a ParseError
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 // Reader reads records from a ACH-encoded file.
 type Reader struct {
 	// r handles the IO.Reader sent to be parser.
@@ -40,6 +61,245 @@ type Reader struct {
 	lineNum int
 	// recordName holds the current record name being parsed.
 	recordName string
+	// skipImageData instructs the reader to leave ImageViewData.ImageData empty
+	skipImageData bool
+	// blocked instructs the reader to unblock records preceded by their length rather than reading lines
+	blocked bool
+	// bufferSize is the initial capacity of the scanner buffer
+	bufferSize int
+	// maxRecordSize is the largest record the scanner buffer can grow to hold
+	maxRecordSize int
+	// continueOnError instructs the reader to skip items with invalid fields rather than return an error
+	continueOnError bool
+	// errors holds the errors of records skipped with continueOnError
+	errors []error
+	// skipItem is set while the records of a skipped CheckDetail or ReturnDetail are being skipped
+	skipItem bool
+	// previousRecordType is the record type of the record read before the current record
+	previousRecordType string
+	// preserveRaw instructs the reader to keep the line each record is parsed from
+	preserveRaw bool
+	// location is the time.Location date and time fields are parsed in, or nil for UTC
+	location *time.Location
+	// standardLevel is the StandardLevel whose record layouts are read, or blank for the FileHeader StandardLevel
+	standardLevel string
+	// normalize instructs the reader to upper-case code fields and remove their padding before validation
+	normalize bool
+	// detector reads the input, and is kept with its buffer by Reset
+	detector *gzipDetector
+	// buf is the initial scanner buffer, which is kept by Reset
+	buf []byte
+	// terminator is the byte ending each line of input which isn't blocked, detected from the first line
+	terminator byte
+	// onRecord is called with the type and length of each record parsed, if set
+	onRecord func(recordType string, n int)
+	// onEOF is called with the summary of the records parsed once the input has been read, if set
+	onEOF func(summary ReadSummary)
+	// summary tallies the records parsed while onEOF is set
+	summary ReadSummary
+}
+
+// ReadSummary summarizes the records parsed by a Reader, and is passed to the function set with ReadOnEOFOption.
+type ReadSummary struct {
+	// Records is the number of records parsed
+	Records int
+	// Bytes is the length of the records parsed, excluding line terminators and length prefixes
+	Bytes int64
+	// RecordTypes is the number of records parsed of each record type, such as "CheckDetail"
+	RecordTypes map[string]int
+}
+
+// ReaderOption can be used to change default behavior of Reader
+type ReaderOption func(*Reader)
+
+// ReadSkipImageDataOption allows Reader to parse ImageViewData record metadata without loading
+// ImageData into memory. The returned File reports ImageDataSkipped and can not be written.
+func ReadSkipImageDataOption() ReaderOption {
+	return func(r *Reader) {
+		r.skipImageData = true
+	}
+}
+
+// ReadBlockedOption allows Reader to read files in the X9 blocked format written with
+// WriteBlockedOption, where each record is preceded by its length as a 4 byte big-endian
+// binary value and the final block is padded with fill bytes.
+func ReadBlockedOption() ReaderOption {
+	return func(r *Reader) {
+		r.blocked = true
+	}
+}
+
+// ReadBufferSizeOption sets the initial capacity in bytes of the buffer records are read into, which
+// grows as needed up to the maximum record size. The capacity is limited to the maximum record size.
+func ReadBufferSizeOption(size int) ReaderOption {
+	return func(r *Reader) {
+		r.bufferSize = size
+	}
+}
+
+// ReadMaxRecordSizeOption sets the largest record in bytes, including its line terminator or length
+// prefix, which Reader accepts. ImageViewData records holding large images may need a larger size than
+// the default of bufio.MaxScanTokenSize (64KiB). A larger record is returned as a ParseError.
+func ReadMaxRecordSizeOption(size int) ReaderOption {
+	return func(r *Reader) {
+		r.maxRecordSize = size
+	}
+}
+
+// ReadContinueOnErrorOption allows Reader to skip records with invalid fields rather than return an
+// error. When a CheckDetail or ReturnDetail, or one of its addenda or image views, has an invalid field
+// the whole item is skipped. CreditItem, Credit and RoutingNumberSummary records with invalid fields are
+// skipped alone. The error of each skipped record is available from Errors. Errors in any other record,
+// and records out of order, are still returned.
+func ReadContinueOnErrorOption() ReaderOption {
+	return func(r *Reader) {
+		r.continueOnError = true
+	}
+}
+
+// ReadPreserveRawOption allows Reader to keep the line each record is parsed from, which is returned
+// by the record's RawLine. This holds a second copy of every record, including ImageData, in memory.
+func ReadPreserveRawOption() ReaderOption {
+	return func(r *Reader) {
+		r.preserveRaw = true
+	}
+}
+
+// ReadLocationOption sets the time.Location date and time fields are parsed in, which is UTC by default.
+// A YYYYMMDD date is parsed as midnight in loc, so the date of a time.Time compared with other times in
+// loc is unchanged. Records are written with the same date and time in any location.
+func ReadLocationOption(loc *time.Location) ReaderOption {
+	return func(r *Reader) {
+		r.location = loc
+	}
+}
+
+// ReadStandardLevelOption sets the StandardLevel whose record layouts are read, such as StandardLevelDSTU2003,
+// rather than the StandardLevel of the FileHeader. The level is kept as the File Layout so the File is
+// written in the same layout.
+func ReadStandardLevelOption(level string) ReaderOption {
+	return func(r *Reader) {
+		r.standardLevel = level
+	}
+}
+
+// ReadNormalizeOption allows Reader to accept alphabetic code fields which are lowercase or padded, such as
+// a CountryCode of "us". Code fields such as indicators, CountryCode and ReturnReason are upper-cased and their
+// padding removed before each record is validated, so the File is written in canonical form. By default
+// code fields are validated as read.
+func ReadNormalizeOption() ReaderOption {
+	return func(r *Reader) {
+		r.normalize = true
+	}
+}
+
+// ReadOnRecordOption sets a function Reader calls as each record is parsed with its record type, such as
+// "CheckDetail", and its length in bytes excluding its line terminator or length prefix. This allows progress and
+// metrics to be reported while large files are read.
+func ReadOnRecordOption(fn func(recordType string, n int)) ReaderOption {
+	return func(r *Reader) {
+		r.onRecord = fn
+	}
+}
+
+// ReadOnEOFOption sets a function Reader calls with a ReadSummary of the records parsed once Read or ReadAll
+// has read all of its input. fn isn't called when reading stops at an error.
+func ReadOnEOFOption(fn func(summary ReadSummary)) ReaderOption {
+	return func(r *Reader) {
+		r.onEOF = fn
+	}
+}
+
+// layout returns the StandardLevel whose record layouts are read
+func (r *Reader) layout() string {
+	if r.standardLevel != "" {
+		return r.standardLevel
+	}
+	return r.File.Header.StandardLevel
+}
+
+// rawRecord is composed into each record to hold the line it was parsed from by a Reader using
+// ReadPreserveRawOption
+type rawRecord struct {
+	rawLine string
+}
+
+// RawLine returns the line the record was parsed from by a Reader using ReadPreserveRawOption, or an empty
+// string for records which were created or read without the option. When ImageData is skipped with
+// ReadSkipImageDataOption the line of an ImageViewData ends before ImageData.
+func (rr *rawRecord) RawLine() string {
+	return rr.rawLine
+}
+
+// setRawLine sets the line the record was parsed from
+func (rr *rawRecord) setRawLine(line string) {
+	rr.rawLine = line
+}
+
+// parsedRecord is a record parsed by Reader
+type parsedRecord interface {
+	setRawLine(line string)
+}
+
+// recordParsed applies the options of the Reader which change a record parsed from the current line
+func (r *Reader) recordParsed(record parsedRecord) {
+	if r.onRecord != nil || r.onEOF != nil {
+		r.observeRecord()
+	}
+	if r.preserveRaw {
+		record.setRawLine(r.line)
+	}
+	if r.location != nil {
+		setLocation(reflect.ValueOf(record).Elem(), r.location)
+	}
+	if record, ok := record.(codeRecord); ok && r.normalize {
+		record.normalizeCodes()
+	}
+}
+
+// observeRecord passes the record parsed from the current line to onRecord and adds it to the summary
+func (r *Reader) observeRecord() {
+	n := len(r.scanner.Bytes())
+	if r.onRecord != nil {
+		r.onRecord(r.recordName, n)
+	}
+	if r.onEOF != nil {
+		if r.summary.RecordTypes == nil {
+			r.summary.RecordTypes = make(map[string]int)
+		}
+		r.summary.Records++
+		r.summary.Bytes += int64(n)
+		r.summary.RecordTypes[r.recordName]++
+	}
+}
+
+// eof passes the summary of the records parsed to onEOF
+func (r *Reader) eof() {
+	if r.onEOF != nil {
+		r.onEOF(r.summary)
+	}
+}
+
+// setLocation sets every time.Time field of the record v which isn't the zero time to the same date and
+// time in loc
+func setLocation(v reflect.Value, loc *time.Location) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Type() != timeType || !field.CanSet() {
+			continue
+		}
+		t := field.Interface().(time.Time)
+		if t.IsZero() {
+			continue
+		}
+		field.Set(reflect.ValueOf(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)))
+	}
+}
+
+// Errors returns the errors of the records skipped by ReadContinueOnErrorOption during the last Read
+// or ReadAll.
+func (r *Reader) Errors() []error {
+	return r.errors
 }
 
 // error creates a new ParseError based on err.
@@ -69,38 +329,288 @@ func (r *Reader) addCurrentRoutingNumberSummary(rns *RoutingNumberSummary) {
 	r.currentCashLetter.currentRoutingNumberSummary = rns
 }
 
-// NewReader returns a new ACH Reader that reads from r.
-func NewReader(r io.Reader) *Reader {
-	f := NewFile()
-	f.Control = FileControl{}
-	return &Reader{
-		File:    *f,
-		scanner: bufio.NewScanner(r),
+// NewReader returns a new ACH Reader that reads from r. Input compressed with gzip is detected
+// from its magic header and decompressed transparently. A UTF-8 byte order mark and blank lines
+// preceding the FileHeader are skipped. Lines may end with LF, CR LF or a lone CR.
+func NewReader(r io.Reader, opts ...ReaderOption) *Reader {
+	reader := &Reader{
+		bufferSize:    defaultBufferSize,
+		maxRecordSize: bufio.MaxScanTokenSize,
+	}
+	for _, opt := range opts {
+		opt(reader)
+	}
+	if reader.bufferSize < 1 {
+		reader.bufferSize = defaultBufferSize
 	}
+	if reader.maxRecordSize < 1 {
+		reader.maxRecordSize = bufio.MaxScanTokenSize
+	}
+	if reader.bufferSize > reader.maxRecordSize {
+		reader.bufferSize = reader.maxRecordSize
+	}
+	reader.Reset(r)
+	return reader
+}
+
+// ReadFile opens the file at path, reads it with a Reader created with the ReaderOptions opts and
+// closes it. The File is returned along with any error from Read, as Read returns it, so the records
+// read so far are available with ReadContinueOnErrorOption.
+func ReadFile(path string, opts ...ReaderOption) (*File, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	file, err := NewReader(fd, opts...).Read()
+	return &file, err
+}
+
+// Reset discards the File and every record read so far and makes the Reader read from src, keeping the
+// options the Reader was created with. A Reader can be Reset to read successive files, which reuses its
+// buffers rather than allocating new ones as NewReader does.
+func (r *Reader) Reset(src io.Reader) {
+	if r.detector == nil {
+		r.detector = &gzipDetector{}
+	}
+	r.detector.reset(src)
+	if r.buf == nil {
+		r.buf = make([]byte, 0, r.bufferSize)
+	}
+	r.scanner = bufio.NewScanner(r.detector)
+	r.scanner.Buffer(r.buf[:0], r.maxRecordSize)
+	if r.blocked {
+		r.scanner.Split(scanBlockedRecords)
+	} else {
+		r.scanner.Split(r.scanLines)
+	}
+	r.terminator = 0
+	r.resetFile()
+	r.line = ""
+	r.lineNum = 0
+	r.recordName = ""
+	r.errors = nil
+	r.skipItem = false
+	r.previousRecordType = ""
+}
+
+// gzipMagic is the header which begins gzip compressed input
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// utf8BOM is the UTF-8 encoded byte order mark some tools write at the start of a text file
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// gzipDetector is an io.Reader which decompresses src when it begins with the gzip magic header
+// and otherwise returns src unchanged. A UTF-8 byte order mark and blank lines at the start of the
+// (decompressed) input are discarded. Detection is deferred until the first Read.
+type gzipDetector struct {
+	src io.Reader
+	r   io.Reader
+	// br buffers src, and is kept by reset
+	br *bufio.Reader
+}
+
+// reset makes the gzipDetector read from src
+func (d *gzipDetector) reset(src io.Reader) {
+	d.src = src
+	d.r = nil
+}
+
+func (d *gzipDetector) Read(p []byte) (int, error) {
+	if d.r == nil {
+		if d.br == nil {
+			d.br = bufio.NewReader(d.src)
+		} else {
+			d.br.Reset(d.src)
+		}
+		br := d.br
+		magic, err := br.Peek(len(gzipMagic))
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if bytes.Equal(magic, gzipMagic) {
+			zr, err := gzip.NewReader(br)
+			if err != nil {
+				return 0, err
+			}
+			br = bufio.NewReader(zr)
+		}
+		if err := skipLeadingBlankLines(br); err != nil {
+			return 0, err
+		}
+		d.r = br
+	}
+	return d.r.Read(p)
+}
+
+// skipLeadingBlankLines discards a UTF-8 byte order mark and any lines holding only whitespace from
+// the start of br. Input which isn't preceded by a blank line is left unchanged.
+func skipLeadingBlankLines(br *bufio.Reader) error {
+	bom, err := br.Peek(len(utf8BOM))
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if bytes.Equal(bom, utf8BOM) {
+		br.Discard(len(utf8BOM))
+	}
+	for n := 1; ; n++ {
+		data, err := br.Peek(n)
+		if len(data) < n {
+			if err == io.EOF {
+				// Only whitespace remains
+				br.Discard(len(data))
+				return nil
+			}
+			if err == bufio.ErrBufferFull {
+				return nil
+			}
+			return err
+		}
+		switch data[n-1] {
+		case '\n', '\r':
+			br.Discard(n)
+			n = 0
+		case ' ', '\t':
+		default:
+			return nil
+		}
+	}
+}
+
+// scanBlockedRecords is a bufio.SplitFunc which returns each record of blocked input without its
+// length prefix. Block fill following the last record is consumed without returning a record.
+func scanBlockedRecords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) < recordLengthFieldSize && !atEOF {
+		return 0, nil, nil
+	}
+	if len(data) < recordLengthFieldSize || binary.BigEndian.Uint32(data) == 0 {
+		if len(bytes.Trim(data, string(blockFill))) != 0 {
+			return 0, nil, &FileError{FieldName: "BlockFill", Msg: msgBlockFill}
+		}
+		return len(data), nil, nil
+	}
+	length := int(binary.BigEndian.Uint32(data))
+	if len(data) < recordLengthFieldSize+length {
+		if atEOF {
+			msg := fmt.Sprintf(msgBlockedRecordLength, length, len(data)-recordLengthFieldSize)
+			return 0, nil, &FileError{FieldName: "RecordLength", Value: strconv.Itoa(length), Msg: msg}
+		}
+		return 0, nil, nil
+	}
+	return recordLengthFieldSize + length, data[recordLengthFieldSize : recordLengthFieldSize+length], nil
+}
+
+// scanLines is a bufio.SplitFunc which returns each line of input without its terminator. Lines end
+// with LF or CR LF, or with a lone CR when the first line does, as some mainframe transfers write.
+// The length of an ImageViewData record is taken from its variable length fields, so ImageData holding
+// bytes equal to the terminator doesn't end the record.
+func (r *Reader) scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if r.terminator == 0 {
+		// a run of CRs followed by LF is a stray CR rather than a CR terminator
+		i := bytes.IndexAny(data, "\r\n")
+		for i >= 0 && i < len(data) && data[i] == '\r' {
+			i++
+		}
+		switch {
+		case i < 0 || i == len(data):
+			if !atEOF {
+				return 0, nil, nil
+			}
+			if i == len(data) {
+				r.terminator = '\r'
+			}
+		case data[i] == '\n':
+			r.terminator = '\n'
+		default:
+			r.terminator = '\r'
+		}
+	}
+	terminator := r.terminator
+	if terminator == 0 {
+		terminator = '\n'
+	}
+	start := 0
+	if len(data) >= 2 && string(data[:2]) == imageViewDataPos {
+		// ImageData may hold bytes equal to the terminator, so its declared length is consumed first
+		n, short := declaredImageViewDataLength(data)
+		switch {
+		case short && !atEOF:
+			return 0, nil, nil
+		case !short:
+			start = n
+		}
+	}
+	if i := bytes.IndexByte(data[start:], terminator); i >= 0 {
+		return start + i + 1, dropCR(data[:start+i]), nil
+	}
+	if atEOF {
+		return len(data), dropCR(data), nil
+	}
+	return 0, nil, nil
+}
+
+// declaredImageViewDataLength returns the length of the ImageViewData record at the start of data which
+// its variable length fields declare, or zero if they can not be read. short is true when data ends
+// before the record does.
+func declaredImageViewDataLength(data []byte) (n int, short bool) {
+	var c converters
+	if len(data) < 105 {
+		return 0, true
+	}
+	lirk := c.parseNumField(string(data[101:105]))
+	if lirk < 0 {
+		return 0, false
+	}
+	if len(data) < 110+lirk {
+		return 0, true
+	}
+	lds := c.parseNumField(string(data[105+lirk : 110+lirk]))
+	if lds < 0 {
+		return 0, false
+	}
+	if len(data) < 117+lirk+lds {
+		return 0, true
+	}
+	lid := c.parseNumField(string(data[110+lirk+lds : 117+lirk+lds]))
+	if lid < 0 {
+		return 0, false
+	}
+	n = 117 + lirk + lds + lid
+	return n, len(data) < n
+}
+
+// dropCR removes a CR preceding the LF which terminated b
+func dropCR(b []byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == '\r' {
+		return b[:len(b)-1]
+	}
+	return b
 }
 
 // Read reads each line of the imagecashletter file and defines which parser to use based
 // on the first character of each line. It also enforces imagecashletter formatting rules and returns
 // the appropriate error if issues are found.  It supports EBCDIC and ASCII
+//
+// When an error is returned the File holds the records parsed before the error, including every
+// CashLetter which was completed by its CashLetterControl. It is also available from PartialFile.
+// Input which ends before the FileControl, such as a truncated file, is reported with the BundleControl,
+// CashLetterControl or FileControl which was expected next as the ParseError Record.
 func (r *Reader) Read() (File, error) {
 	r.lineNum = 0
+	r.errors = nil
+	r.summary = ReadSummary{}
 	// read through the entire file
 	for r.scanner.Scan() {
-		line := r.scanner.Text()
-		r.lineNum++
-
-		lineLength := len(line)
-
-		if lineLength < 80 {
-			msg := fmt.Sprintf(msgRecordLength, lineLength)
-			err := &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg}
-			return r.File, r.error(err)
-		}
-		r.line = line
-		if err := r.parseLine(); err != nil {
+		if err := r.readRecord(); err != nil {
 			return r.File, err
 		}
 	}
+	if err := r.scanner.Err(); err != nil {
+		return r.File, r.scanError(err)
+	}
 	if (FileHeader{}) == r.File.Header {
 		// There must be at least one File Header
 		r.recordName = "FileHeader"
@@ -108,13 +618,589 @@ func (r *Reader) Read() (File, error) {
 	}
 	if (FileControl{}) == r.File.Control {
 		// There must be at least one File Control
-		r.recordName = "FileControl"
-		return r.File, r.error(&FileError{Msg: msgFileControl})
+		return r.File, r.missingControl()
 	}
+	r.eof()
 	return r.File, nil
 }
 
+// ReadAll reads a stream of one or more complete imagecashletter files, each with its own FileHeader
+// and FileControl, such as one written by Writer.WriteAll or several files concatenated together.
+// Each File is returned separately in the order read, and every File must begin with a FileHeader.
+// When an error is returned the Files completed before the error are returned and the File being
+// parsed is available from PartialFile. Read is unchanged and reads a single File.
+func (r *Reader) ReadAll() ([]*File, error) {
+	var files []*File
+	// pending is true while records of a File without its FileControl have been read
+	pending := false
+	r.lineNum = 0
+	r.errors = nil
+	r.summary = ReadSummary{}
+	for r.scanner.Scan() {
+		if !pending && !bytes.HasPrefix(r.scanner.Bytes(), []byte(fileHeaderPos)) {
+			// Every File must begin with a File Header
+			r.lineNum++
+			r.recordName = "FileHeader"
+			return files, r.error(&FileError{Msg: msgFileHeader})
+		}
+		pending = true
+		if err := r.readRecord(); err != nil {
+			return files, err
+		}
+		if r.line[:2] == fileControlPos {
+			file := r.File
+			files = append(files, &file)
+			r.resetFile()
+			pending = false
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return files, r.scanError(err)
+	}
+	if len(files) == 0 && !pending {
+		// There must be at least one File Header
+		r.recordName = "FileHeader"
+		return files, r.error(&FileError{Msg: msgFileHeader})
+	}
+	if pending {
+		// Every File Header must have a File Control
+		return files, r.missingControl()
+	}
+	r.eof()
+	return files, nil
+}
+
+// ReadInto reads a single File as Read does into f, which is reset first. The records and slices of f are
+// reused to hold the records read rather than allocated, so a File can be read into repeatedly, such as one
+// from a sync.Pool, with fewer allocations. f must be empty or have been read by a Reader. Nothing read into
+// f previously remains in it, and records taken from its previous contents must not be used once it has been
+// read into.
+//
+// When an error is returned f holds the records parsed before the error, as the File returned by Read does.
+func (r *Reader) ReadInto(f *File) error {
+	spare := f.CashLetters
+	r.resetFile()
+	r.File.CashLetters = spare[:0]
+	file, err := r.Read()
+	// clear the CashLetters which were not overwritten, and the records left in the capacity of reused slices
+	for i := len(file.CashLetters); i < len(spare); i++ {
+		spare[i] = CashLetter{}
+	}
+	for i := range file.CashLetters {
+		bundles := file.CashLetters[i].Bundles
+		for _, b := range bundles {
+			clearChecks(b.Checks[len(b.Checks):cap(b.Checks)])
+			clearReturns(b.Returns[len(b.Returns):cap(b.Returns)])
+		}
+		tail := bundles[len(bundles):cap(bundles)]
+		for j := range tail {
+			tail[j] = nil
+		}
+	}
+	*f = file
+	return err
+}
+
+// newCashLetter returns a CashLetter for clh. When the File is being read with ReadInto, the slices of the
+// CashLetter it replaces are reused.
+func (r *Reader) newCashLetter(clh *CashLetterHeader) CashLetter {
+	cl := NewCashLetter(clh)
+	if n := len(r.File.CashLetters); n < cap(r.File.CashLetters) {
+		old := r.File.CashLetters[:n+1][n]
+		// Bundles are replaced by newBundle, which reuses their slices
+		cl.Bundles = old.Bundles[:0]
+		cl.CreditItems = clearCreditItems(old.CreditItems[:cap(old.CreditItems)])
+		cl.Credits = clearCredits(old.Credits[:cap(old.Credits)])
+		cl.RoutingNumberSummary = clearRoutingNumberSummaries(old.RoutingNumberSummary[:cap(old.RoutingNumberSummary)])
+	}
+	return cl
+}
+
+// newBundle returns a Bundle for bh. When the File is being read with ReadInto, the slices of the Bundle it
+// replaces are reused.
+func (r *Reader) newBundle(bh *BundleHeader) *Bundle {
+	bundle := NewBundle(bh)
+	bundles := r.currentCashLetter.Bundles
+	if n := len(bundles); n < cap(bundles) {
+		if old := bundles[:n+1][n]; old != nil {
+			// Checks and Returns are replaced by newCheckDetail and newReturnDetail, which reuse them
+			bundle.Checks = old.Checks[:0]
+			bundle.Returns = old.Returns[:0]
+			bundle.CreditItems = clearCreditItems(old.CreditItems[:cap(old.CreditItems)])
+			bundle.Credits = clearCredits(old.Credits[:cap(old.Credits)])
+		}
+	}
+	return bundle
+}
+
+// newCheckDetail returns an empty CheckDetail. When the File is being read with ReadInto, the CheckDetail it
+// replaces is reused with the slices of its addenda and image views.
+func (r *Reader) newCheckDetail() *CheckDetail {
+	checks := r.currentCashLetter.currentBundle.Checks
+	n := len(checks)
+	if n == cap(checks) || checks[:n+1][n] == nil {
+		return new(CheckDetail)
+	}
+	cd := checks[:n+1][n]
+	*cd = CheckDetail{
+		CheckDetailAddendumA: cd.CheckDetailAddendumA[:0],
+		CheckDetailAddendumB: cd.CheckDetailAddendumB[:0],
+		CheckDetailAddendumC: cd.CheckDetailAddendumC[:0],
+		ImageViewDetail:      cd.ImageViewDetail[:0],
+		ImageViewData:        clearImageViewData(cd.ImageViewData[:cap(cd.ImageViewData)]),
+		ImageViewAnalysis:    cd.ImageViewAnalysis[:0],
+	}
+	return cd
+}
+
+// newReturnDetail returns an empty ReturnDetail. When the File is being read with ReadInto, the ReturnDetail
+// it replaces is reused with the slices of its addenda and image views.
+func (r *Reader) newReturnDetail() *ReturnDetail {
+	returns := r.currentCashLetter.currentBundle.Returns
+	n := len(returns)
+	if n == cap(returns) || returns[:n+1][n] == nil {
+		return new(ReturnDetail)
+	}
+	rd := returns[:n+1][n]
+	*rd = ReturnDetail{
+		ReturnDetailAddendumA: rd.ReturnDetailAddendumA[:0],
+		ReturnDetailAddendumB: rd.ReturnDetailAddendumB[:0],
+		ReturnDetailAddendumC: rd.ReturnDetailAddendumC[:0],
+		ReturnDetailAddendumD: rd.ReturnDetailAddendumD[:0],
+		ImageViewDetail:       rd.ImageViewDetail[:0],
+		ImageViewData:         clearImageViewData(rd.ImageViewData[:cap(rd.ImageViewData)]),
+		ImageViewAnalysis:     rd.ImageViewAnalysis[:0],
+	}
+	return rd
+}
+
+// clearChecks removes every record from s
+func clearChecks(s []*CheckDetail) {
+	for i := range s {
+		s[i] = nil
+	}
+}
+
+// clearReturns removes every record from s
+func clearReturns(s []*ReturnDetail) {
+	for i := range s {
+		s[i] = nil
+	}
+}
+
+// clearCreditItems removes every record from s and returns it empty
+func clearCreditItems(s []*CreditItem) []*CreditItem {
+	for i := range s {
+		s[i] = nil
+	}
+	return s[:0]
+}
+
+// clearCredits removes every record from s and returns it empty
+func clearCredits(s []*Credit) []*Credit {
+	for i := range s {
+		s[i] = nil
+	}
+	return s[:0]
+}
+
+// clearRoutingNumberSummaries removes every record from s and returns it empty
+func clearRoutingNumberSummaries(s []*RoutingNumberSummary) []*RoutingNumberSummary {
+	for i := range s {
+		s[i] = nil
+	}
+	return s[:0]
+}
+
+// clearImageViewData clears every record of s, so their ImageData is not kept, and returns it empty
+func clearImageViewData(s []ImageViewData) []ImageViewData {
+	for i := range s {
+		s[i] = ImageViewData{}
+	}
+	return s[:0]
+}
+
+// missingControl creates a ParseError for input which ended before the FileControl, naming the
+// innermost control record which was expected: the BundleControl of an open Bundle, the
+// CashLetterControl of an open CashLetter, or otherwise the FileControl.
+func (r *Reader) missingControl() error {
+	switch {
+	case r.currentCashLetter.currentBundle != nil && r.currentCashLetter.currentBundle.BundleHeader != nil:
+		r.recordName = "BundleControl"
+		return r.error(&FileError{FieldName: "BundleControl", Msg: msgFileBundleControlEOF})
+	case r.currentCashLetter.CashLetterHeader != nil:
+		r.recordName = "CashLetterControl"
+		return r.error(&FileError{FieldName: "CashLetterControl", Msg: msgFileCashLetterControlEOF})
+	default:
+		r.recordName = "FileControl"
+		return r.error(&FileError{Msg: msgFileControl})
+	}
+}
+
+// scanError creates a ParseError for an error returned by the scanner. A record larger than the
+// maximum record size is reported on the line following the last record read.
+func (r *Reader) scanError(err error) error {
+	if err == bufio.ErrTooLong {
+		r.lineNum++
+		r.recordName = ""
+		msg := fmt.Sprintf(msgRecordSize, r.maxRecordSize)
+		return r.error(&FileError{FieldName: "RecordLength", Value: strconv.Itoa(r.maxRecordSize), Msg: msg})
+	}
+	return r.error(err)
+}
+
+// readRecord reads the current record from the scanner and parses it
+func (r *Reader) readRecord() error {
+	line := r.scanLine()
+	r.lineNum++
+
+	if err := checkRecordLength(line); err != nil {
+		return r.error(err)
+	}
+	r.line = line
+	defer func() { r.previousRecordType = line[:2] }()
+	if r.skipItem {
+		if isItemChildRecord(r.line[:2]) {
+			// part of a CheckDetail or ReturnDetail skipped by continueOnError
+			return nil
+		}
+		r.skipItem = false
+	}
+	if err := r.checkImageViewOrder(); err != nil {
+		return err
+	}
+	if err := r.checkDates(); err != nil {
+		return r.skipRecord(err)
+	}
+	if err := r.checkAmounts(); err != nil {
+		return r.skipRecord(err)
+	}
+	if err := r.checkNumbers(); err != nil {
+		return r.skipRecord(err)
+	}
+	return r.skipRecord(r.parseLine())
+}
+
+// checkImageViewOrder returns an error if the current record is an ImageViewData which doesn't follow an
+// ImageViewDetail, or an ImageViewAnalysis which doesn't follow an ImageViewData. Image views outside of
+// an item are reported when they are parsed.
+func (r *Reader) checkImageViewOrder() error {
+	if b := r.currentCashLetter.currentBundle; b == nil || (b.GetChecks() == nil && b.GetReturns() == nil) {
+		return nil
+	}
+	switch r.line[:2] {
+	case imageViewDataPos:
+		if r.previousRecordType != imageViewDetailPos {
+			r.recordName = "ImageViewData"
+			return r.error(&FileError{FieldName: "ImageViewData", Value: r.previousRecordType, Msg: msgFileImageViewData})
+		}
+	case imageViewAnalysisPos:
+		if r.previousRecordType != imageViewDataPos {
+			r.recordName = "ImageViewAnalysis"
+			return r.error(&FileError{FieldName: "ImageViewAnalysis", Value: r.previousRecordType, Msg: msgFileImageViewAnalysis})
+		}
+	}
+	return nil
+}
+
+// isItemChildRecord returns true for the addenda and image view records following a CheckDetail or ReturnDetail
+func isItemChildRecord(recordType string) bool {
+	switch recordType {
+	case checkDetailAddendumAPos, checkDetailAddendumBPos, checkDetailAddendumCPos,
+		returnAddendumAPos, returnAddendumBPos, returnAddendumCPos, returnAddendumDPos,
+		imageViewDetailPos, imageViewDataPos, imageViewAnalysisPos:
+		return true
+	}
+	return false
+}
+
+// skipRecord returns err unless continueOnError is set and err is an invalid field of a record which can
+// be skipped, in which case err is added to the Reader errors and the record, along with the rest of its
+// item, is skipped.
+func (r *Reader) skipRecord(err error) error {
+	if err == nil || !r.continueOnError {
+		return err
+	}
+	p, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	if _, ok := p.Err.(*FieldError); !ok {
+		return err
+	}
+	switch recordType := r.line[:2]; {
+	case recordType == checkDetailPos || recordType == returnDetailPos:
+		// the item was not added to the Bundle
+		r.skipItem = true
+	case isItemChildRecord(recordType):
+		r.removeCurrentItem(recordType)
+		r.skipItem = true
+	case recordType == creditPos || recordType == creditItemPos || recordType == routingNumberSummaryPos:
+	default:
+		return err
+	}
+	r.errors = append(r.errors, err)
+	return nil
+}
+
+// removeCurrentItem removes the CheckDetail or ReturnDetail which a record of recordType belongs to from
+// the current Bundle. Image views belong to a CheckDetail if the Bundle has checks, as when they are parsed.
+func (r *Reader) removeCurrentItem(recordType string) {
+	b := r.currentCashLetter.currentBundle
+	if b == nil {
+		return
+	}
+	switch recordType {
+	case returnAddendumAPos, returnAddendumBPos, returnAddendumCPos, returnAddendumDPos:
+	default:
+		if len(b.Checks) > 0 {
+			b.Checks = b.Checks[:len(b.Checks)-1]
+			return
+		}
+	}
+	if len(b.Returns) > 0 {
+		b.Returns = b.Returns[:len(b.Returns)-1]
+	}
+}
+
+// fieldPosition is the position of a field within a record
+type fieldPosition struct {
+	name       string
+	start, end int
+}
+
+// recordDates lists the date fields of each record type by record type position
+var recordDates = map[string]struct {
+	record string
+	fields []fieldPosition
+}{
+	fileHeaderPos:           {"FileHeader", []fieldPosition{{"FileCreationDate", 23, 31}}},
+	cashLetterHeaderPos:     {"CashLetterHeader", []fieldPosition{{"CashLetterBusinessDate", 22, 30}, {"CashLetterCreationDate", 30, 38}}},
+	bundleHeaderPos:         {"BundleHeader", []fieldPosition{{"BundleBusinessDate", 22, 30}, {"BundleCreationDate", 30, 38}}},
+	checkDetailAddendumAPos: {"CheckDetailAddendumA", []fieldPosition{{"BOFDEndorsementDate", 12, 20}}},
+	checkDetailAddendumCPos: {"CheckDetailAddendumC", []fieldPosition{{"BOFDEndorsementBusinessDate", 13, 21}}},
+	imageViewDetailPos:      {"ImageViewDetail", []fieldPosition{{"ImageCreatorDate", 12, 20}}},
+	imageViewDataPos:        {"ImageViewData", []fieldPosition{{"BundleBusinessDate", 11, 19}}},
+	returnDetailPos:         {"ReturnDetail", []fieldPosition{{"ForwardBundleDate", 45, 53}}},
+	returnAddendumAPos:      {"ReturnDetailAddendumA", []fieldPosition{{"BOFDEndorsementDate", 12, 20}}},
+	returnAddendumBPos:      {"ReturnDetailAddendumB", []fieldPosition{{"PayorBankBusinessDate", 50, 58}}},
+	returnAddendumDPos:      {"ReturnDetailAddendumD", []fieldPosition{{"BOFDEndorsementBusinessDate", 13, 21}}},
+	cashLetterControlPos:    {"CashLetterControl", []fieldPosition{{"SettlementDate", 57, 65}}},
+}
+
+// checkDates returns an error for a date field of the current line which is not blank and not a
+// valid YYYYMMDD date, as parsing would otherwise leave the date unset.
+func (r *Reader) checkDates() error {
+	dates, ok := recordDates[r.line[:2]]
+	if !ok {
+		return nil
+	}
+	var v validator
+	for _, f := range dates.fields {
+		value := r.line[f.start:f.end]
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if err := v.isYYYYMMDDDate(value); err != nil {
+			r.recordName = dates.record
+			return r.error(&FieldError{FieldName: f.name, Value: value, Msg: err.Error()})
+		}
+	}
+	return nil
+}
+
+// recordAmounts lists the amount fields of each record type by record type position
+var recordAmounts = map[string]struct {
+	record string
+	fields []fieldPosition
+}{
+	checkDetailPos:          {"CheckDetail", []fieldPosition{{"ItemAmount", 47, 57}}},
+	returnDetailPos:         {"ReturnDetail", []fieldPosition{{"ItemAmount", 31, 41}}},
+	creditPos:               {"Credit", []fieldPosition{{"ItemAmount", 47, 57}}},
+	creditItemPos:           {"CreditItem", []fieldPosition{{"ItemAmount", 47, 61}}},
+	bundleControlPos:        {"BundleControl", []fieldPosition{{"BundleTotalAmount", 6, 18}, {"MICRValidTotalAmount", 18, 30}}},
+	routingNumberSummaryPos: {"RoutingNumberSummary", []fieldPosition{{"RoutingNumberTotalAmount", 11, 25}}},
+	cashLetterControlPos:    {"CashLetterControl", []fieldPosition{{"CashLetterTotalAmount", 16, 30}}},
+	fileControlPos:          {"FileControl", []fieldPosition{{"FileTotalAmount", 24, 40}}},
+}
+
+// checkAmounts returns an error for an amount field of the current line which is not numeric, as
+// parsing would otherwise leave the amount zero.
+func (r *Reader) checkAmounts() error {
+	amounts, ok := recordAmounts[r.line[:2]]
+	if !ok {
+		return nil
+	}
+	var c converters
+	for _, f := range amounts.fields {
+		value := r.line[f.start:f.end]
+		if _, err := c.parseAmount(value); err != nil {
+			r.recordName = amounts.record
+			return r.error(&FieldError{FieldName: f.name, Value: value, Msg: err.Error()})
+		}
+	}
+	return nil
+}
+
+// recordNumbers lists the numeric fields of each record type which are parsed as a number, by record type
+// position. ReturnDetail TimesReturned is not listed as its position is reserved in DSTU X9.37-2003.
+var recordNumbers = map[string]struct {
+	record string
+	fields []fieldPosition
+}{
+	checkDetailPos: {"CheckDetail", []fieldPosition{{"MICRValidIndicator", 74, 75}, {"AddendumCount", 76, 78},
+		{"CorrectionIndicator", 78, 79}}},
+	checkDetailAddendumAPos: {"CheckDetailAddendumA", []fieldPosition{{"RecordNumber", 2, 3}, {"BOFDCorrectionIndicator", 75, 76}}},
+	checkDetailAddendumBPos: {"CheckDetailAddendumB", []fieldPosition{{"ImageReferenceKeyIndicator", 2, 3},
+		{"LengthImageReferenceKey", 18, 22}}},
+	checkDetailAddendumCPos: {"CheckDetailAddendumC", []fieldPosition{{"RecordNumber", 2, 4},
+		{"EndorsingBankCorrectionIndicator", 38, 39}, {"EndorsingBankIdentifier", 59, 60}}},
+	returnDetailPos:    {"ReturnDetail", []fieldPosition{{"AddendumCount", 42, 44}, {"ReturnNotificationIndicator", 69, 70}}},
+	returnAddendumAPos: {"ReturnDetailAddendumA", []fieldPosition{{"RecordNumber", 2, 3}, {"BOFDCorrectionIndicator", 75, 76}}},
+	returnAddendumCPos: {"ReturnDetailAddendumC", []fieldPosition{{"ImageReferenceKeyIndicator", 2, 3},
+		{"LengthImageReferenceKey", 18, 22}}},
+	returnAddendumDPos: {"ReturnDetailAddendumD", []fieldPosition{{"RecordNumber", 2, 4},
+		{"EndorsingBankCorrectionIndicator", 38, 39}, {"EndorsingBankIdentifier", 59, 60}}},
+	imageViewDetailPos: {"ImageViewDetail", []fieldPosition{{"ImageIndicator", 2, 3}, {"ViewSideIndicator", 31, 32},
+		{"DigitalSignatureIndicator", 34, 35}, {"SecurityKeySize", 37, 42}, {"ProtectedDataStart", 42, 49},
+		{"ProtectedDataLength", 49, 56}, {"ImageRecreateIndicator", 56, 57}}},
+	imageViewDataPos: {"ImageViewData", []fieldPosition{{"ClippingOrigin", 84, 85}}},
+	imageViewAnalysisPos: {"ImageViewAnalysis", []fieldPosition{{"GlobalImageQuality", 2, 3}, {"GlobalImageUsability", 3, 4},
+		{"ImagingBankSpecificTest", 4, 5}, {"PartialImage", 5, 6}, {"ExcessiveImageSkew", 6, 7}, {"PiggybackImage", 7, 8},
+		{"TooLightOrTooDark", 8, 9}, {"StreaksAndOrBands", 9, 10}, {"BelowMinimumImageSize", 10, 11},
+		{"ExceedsMaximumImageSize", 11, 12}, {"ImageEnabledPOD", 25, 26}, {"SourceDocumentBad", 26, 27},
+		{"DateUsability", 27, 28}, {"PayeeUsability", 28, 29}, {"ConvenienceAmountUsability", 29, 30},
+		{"AmountInWordsUsability", 30, 31}, {"SignatureUsability", 31, 32}, {"PayorNameAddressUsability", 32, 33},
+		{"MICRLineUsability", 33, 34}, {"MemoLineUsability", 34, 35}, {"PayorBankNameAddressUsability", 35, 36},
+		{"PayeeEndorsementUsability", 36, 37}, {"BOFDEndorsementUsability", 37, 38}, {"TransitEndorsementUsability", 38, 39}}},
+	bundleControlPos: {"BundleControl", []fieldPosition{{"BundleItemsCount", 2, 6}, {"BundleImagesCount", 30, 35},
+		{"CreditTotalIndicator", 55, 56}}},
+	routingNumberSummaryPos: {"RoutingNumberSummary", []fieldPosition{{"RoutingNumberItemCount", 26, 31}}},
+	cashLetterControlPos: {"CashLetterControl", []fieldPosition{{"CashLetterBundleCount", 2, 8}, {"CashLetterItemsCount", 8, 16},
+		{"CashLetterImagesCount", 30, 39}, {"CreditTotalIndicator", 65, 66}}},
+	fileControlPos: {"FileControl", []fieldPosition{{"CashLetterCount", 2, 8}, {"TotalRecordCount", 8, 16},
+		{"TotalItemCount", 16, 24}, {"CreditTotalIndicator", 64, 65}}},
+}
+
+// checkNumbers returns an error for a numeric field of the current line which holds anything other than
+// digits with leading or trailing blanks, as parsing would otherwise leave the number zero.
+func (r *Reader) checkNumbers() error {
+	numbers, ok := recordNumbers[r.line[:2]]
+	if !ok {
+		return nil
+	}
+	var v validator
+	for _, f := range numbers.fields {
+		if f.end > len(r.line) {
+			continue
+		}
+		value := r.line[f.start:f.end]
+		if err := v.isNumeric(value); err != nil {
+			r.recordName = numbers.record
+			return r.error(&FieldError{FieldName: f.name, Value: value, Msg: err.Error()})
+		}
+	}
+	return nil
+}
+
+// resetFile replaces the File being read with an empty File
+func (r *Reader) resetFile() {
+	f := NewFile()
+	f.Control = FileControl{}
+	f.imageDataSkipped = r.skipImageData
+	r.File = *f
+	r.currentCashLetter = CashLetter{}
+}
+
+// PartialFile returns the File parsed so far. After Read returns an error it holds the FileHeader
+// and each CashLetter completed before the failing record. The CashLetter being parsed when the
+// error occurred is not included.
+func (r *Reader) PartialFile() *File {
+	return &r.File
+}
+
+// scanLine returns the current record from the scanner. When image data is being skipped
+// only the ImageViewData fields preceding ImageData are copied out of the scanner.
+// Stray CR and LF bytes are removed from the end of records, following the ImageData of ImageViewData.
+func (r *Reader) scanLine() string {
+	b := r.scanner.Bytes()
+	if len(b) >= 2 && string(b[:2]) == imageViewDataPos {
+		if r.skipImageData {
+			return string(b[:imageViewDataMetadataLength(b)])
+		}
+		if n := imageViewDataLength(b); !r.blocked && len(bytes.TrimRight(b[n:], "\r\n")) == 0 {
+			b = b[:n]
+		}
+		return string(b)
+	}
+	if !r.blocked {
+		b = bytes.TrimRight(b, "\r\n")
+	}
+	return string(b)
+}
+
+// checkRecordLength checks line is the length of its record type. Records of an unknown type must be
+// at least 80 characters.
+func checkRecordLength(line string) error {
+	lineLength := len(line)
+	if lineLength >= 2 {
+		if rl, ok := recordLengths[line[:2]]; ok {
+			switch {
+			case rl.variable && lineLength < rl.length:
+				msg := fmt.Sprintf(msgRecordTypeMinLength, line[:2], rl.length, lineLength)
+				return &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg, Err: ErrRecordType}
+			case !rl.variable && lineLength != rl.length:
+				msg := fmt.Sprintf(msgRecordTypeLength, line[:2], rl.length, lineLength)
+				return &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg, Err: ErrRecordType}
+			}
+			return nil
+		}
+	}
+	if lineLength < 80 {
+		msg := fmt.Sprintf(msgRecordLength, lineLength)
+		return &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg}
+	}
+	return nil
+}
+
+// imageViewDataMetadataLength returns the number of bytes in an ImageViewData record up to
+// the start of ImageData, or len(b) if the variable length fields can not be read.
+func imageViewDataMetadataLength(b []byte) int {
+	var c converters
+	if len(b) < 105 {
+		return len(b)
+	}
+	lirk := c.parseNumField(string(b[101:105]))
+	if lirk < 0 || len(b) < 110+lirk {
+		return len(b)
+	}
+	lds := c.parseNumField(string(b[105+lirk : 110+lirk]))
+	if lds < 0 || len(b) < 117+lirk+lds {
+		return len(b)
+	}
+	return 117 + lirk + lds
+}
+
+// imageViewDataLength returns the number of bytes in an ImageViewData record up to the end of
+// ImageData, or len(b) if the variable length fields can not be read.
+func imageViewDataLength(b []byte) int {
+	var c converters
+	n := imageViewDataMetadataLength(b)
+	if n < 117 {
+		return len(b)
+	}
+	lid := c.parseNumField(string(b[n-7 : n]))
+	if lid < 0 || len(b) < n+lid {
+		return len(b)
+	}
+	return n + lid
+}
+
 func (r *Reader) parseLine() error {
+	if len(r.line) < 2 {
+		msg := fmt.Sprintf(msgRecordLength, len(r.line))
+		return r.error(&FileError{FieldName: "RecordLength", Value: strconv.Itoa(len(r.line)), Msg: msg})
+	}
 	switch r.line[:2] {
 	case fileHeaderPos:
 		if err := r.parseFileHeader(); err != nil {
@@ -176,6 +1262,10 @@ func (r *Reader) parseLine() error {
 		if err := r.parseReturnDetailAddendumD(); err != nil {
 			return err
 		}
+	case creditPos:
+		if err := r.parseCredit(); err != nil {
+			return err
+		}
 	case creditItemPos:
 		if err := r.parseCreditItem(); err != nil {
 			return err
@@ -218,7 +1308,7 @@ func (r *Reader) parseLine() error {
 		}
 	default:
 		msg := fmt.Sprintf(msgUnknownRecordType, r.line[:2])
-		return r.error(&FileError{FieldName: "recordType", Value: r.line[:2], Msg: msg})
+		return r.error(&FileError{FieldName: "recordType", Value: r.line[:2], Msg: msg, Err: ErrRecordType})
 	}
 	return nil
 }
@@ -230,7 +1320,9 @@ func (r *Reader) parseFileHeader() error {
 		// There can only be one File Header per File
 		r.error(&FileError{Msg: msgFileHeader})
 	}
-	r.File.Header.Parse(r.line)
+	r.File.Header.parseLayout(r.line, r.standardLevel)
+	r.File.Layout = r.standardLevel
+	r.recordParsed(&r.File.Header)
 	// Ensure valid FileHeader
 	if err := r.File.Header.Validate(); err != nil {
 		return r.error(err)
@@ -246,13 +1338,14 @@ func (r *Reader) parseCashLetterHeader() error {
 		return r.error(&FileError{Msg: msgFileCashLetterInside})
 	}
 	clh := NewCashLetterHeader()
-	clh.Parse(r.line)
+	clh.parseLayout(r.line, r.layout())
+	r.recordParsed(clh)
 	// Ensure we have a valid CashLetterHeader
 	if err := clh.Validate(); err != nil {
 		return r.error(err)
 	}
-	// Passing CashLetterHeader into NewCashLetter creates a CashLetter
-	cl := NewCashLetter(clh)
+	// Passing CashLetterHeader into newCashLetter creates a CashLetter
+	cl := r.newCashLetter(clh)
 	r.addCurrentCashLetter(cl)
 	return nil
 }
@@ -260,6 +1353,10 @@ func (r *Reader) parseCashLetterHeader() error {
 // parseBundleHeader takes the input record string and parses the BundleHeader values
 func (r *Reader) parseBundleHeader() error {
 	r.recordName = "BundleHeader"
+	if r.currentCashLetter.CashLetterHeader == nil {
+		// BundleHeader without a current CashLetter
+		return r.error(&FileError{Msg: msgFileBundleHeader})
+	}
 	if r.currentCashLetter.currentBundle != nil {
 		// BundleHeader inside of current Bundle
 		if r.currentCashLetter.currentBundle.BundleHeader != nil {
@@ -269,11 +1366,12 @@ func (r *Reader) parseBundleHeader() error {
 	// Ensure we have a valid bundle header before building a bundle.
 	bh := NewBundleHeader()
 	bh.Parse(r.line)
+	r.recordParsed(bh)
 	if err := bh.Validate(); err != nil {
 		return r.error(err)
 	}
-	// Passing BundleHeader into NewBundle creates a Bundle
-	bundle := NewBundle(bh)
+	// Passing BundleHeader into newBundle creates a Bundle
+	bundle := r.newBundle(bh)
 	r.addCurrentBundle(bundle)
 	return nil
 
@@ -282,19 +1380,18 @@ func (r *Reader) parseBundleHeader() error {
 // parseCheckDetail takes the input record string and parses the CheckDetail values
 func (r *Reader) parseCheckDetail() error {
 	r.recordName = "CheckDetail"
-	if r.currentCashLetter.currentBundle == nil {
+	if r.currentCashLetter.currentBundle == nil || r.currentCashLetter.currentBundle.BundleHeader == nil {
 		return r.error(&FileError{Msg: msgFileBundleOutside})
 	}
-	cd := new(CheckDetail)
+	cd := r.newCheckDetail()
 	cd.Parse(r.line)
+	r.recordParsed(cd)
 	// Ensure valid CheckDetail
 	if err := cd.Validate(); err != nil {
 		return r.error(err)
 	}
 	// Add CheckDetail
-	if r.currentCashLetter.currentBundle.BundleHeader != nil {
-		r.currentCashLetter.currentBundle.AddCheckDetail(cd)
-	}
+	r.currentCashLetter.currentBundle.AddCheckDetail(cd)
 	return nil
 }
 
@@ -307,12 +1404,15 @@ func (r *Reader) parseCheckDetailAddendumA() error {
 	}
 	cdAddendumA := NewCheckDetailAddendumA()
 	cdAddendumA.Parse(r.line)
+	r.recordParsed(&cdAddendumA)
 	if err := cdAddendumA.Validate(); err != nil {
 		return r.error(err)
 	}
 	entryIndex := len(r.currentCashLetter.currentBundle.GetChecks()) - 1
 	//r.currentCashLetter.currentBundle.Checks[entryIndex].CheckDetailAddendumA = cdAddendumA
-	r.currentCashLetter.currentBundle.Checks[entryIndex].AddCheckDetailAddendumA(cdAddendumA)
+	if err := r.currentCashLetter.currentBundle.Checks[entryIndex].AddCheckDetailAddendumA(cdAddendumA); err != nil {
+		return r.error(err)
+	}
 	return nil
 }
 
@@ -325,6 +1425,7 @@ func (r *Reader) parseCheckDetailAddendumB() error {
 	}
 	cdAddendumB := NewCheckDetailAddendumB()
 	cdAddendumB.Parse(r.line)
+	r.recordParsed(&cdAddendumB)
 	if err := cdAddendumB.Validate(); err != nil {
 		return r.error(err)
 	}
@@ -342,28 +1443,30 @@ func (r *Reader) parseCheckDetailAddendumC() error {
 	}
 	cdAddendumC := NewCheckDetailAddendumC()
 	cdAddendumC.Parse(r.line)
+	r.recordParsed(&cdAddendumC)
 	if err := cdAddendumC.Validate(); err != nil {
 		return r.error(err)
 	}
 	entryIndex := len(r.currentCashLetter.currentBundle.GetChecks()) - 1
-	r.currentCashLetter.currentBundle.Checks[entryIndex].AddCheckDetailAddendumC(cdAddendumC)
+	if err := r.currentCashLetter.currentBundle.Checks[entryIndex].AddCheckDetailAddendumC(cdAddendumC); err != nil {
+		return r.error(err)
+	}
 	return nil
 }
 
 // parseReturnDetail takes the input record string and parses the ReturnDetail values
 func (r *Reader) parseReturnDetail() error {
 	r.recordName = "ReturnDetail"
-	if r.currentCashLetter.currentBundle == nil {
+	if r.currentCashLetter.currentBundle == nil || r.currentCashLetter.currentBundle.BundleHeader == nil {
 		return r.error(&FileError{Msg: msgFileBundleOutside})
 	}
-	rd := new(ReturnDetail)
-	rd.Parse(r.line)
+	rd := r.newReturnDetail()
+	rd.parseLayout(r.line, r.layout())
+	r.recordParsed(rd)
 	if err := rd.Validate(); err != nil {
 		return r.error(err)
 	}
-	if r.currentCashLetter.currentBundle.BundleHeader != nil {
-		r.currentCashLetter.currentBundle.AddReturnDetail(rd)
-	}
+	r.currentCashLetter.currentBundle.AddReturnDetail(rd)
 	return nil
 }
 
@@ -376,12 +1479,15 @@ func (r *Reader) parseReturnDetailAddendumA() error {
 	}
 	rdAddendumA := NewReturnDetailAddendumA()
 	rdAddendumA.Parse(r.line)
+	r.recordParsed(&rdAddendumA)
 	if err := rdAddendumA.Validate(); err != nil {
 		return r.error(err)
 	}
 	entryIndex := len(r.currentCashLetter.currentBundle.GetReturns()) - 1
 	//r.currentCashLetter.currentBundle.Returns[entryIndex].ReturnDetailAddendumA = rdAddendumA
-	r.currentCashLetter.currentBundle.Returns[entryIndex].AddReturnDetailAddendumA(rdAddendumA)
+	if err := r.currentCashLetter.currentBundle.Returns[entryIndex].AddReturnDetailAddendumA(rdAddendumA); err != nil {
+		return r.error(err)
+	}
 	return nil
 }
 
@@ -394,6 +1500,7 @@ func (r *Reader) parseReturnDetailAddendumB() error {
 	}
 	rdAddendumB := NewReturnDetailAddendumB()
 	rdAddendumB.Parse(r.line)
+	r.recordParsed(&rdAddendumB)
 	if err := rdAddendumB.Validate(); err != nil {
 		return r.error(err)
 	}
@@ -411,6 +1518,7 @@ func (r *Reader) parseReturnDetailAddendumC() error {
 	}
 	rdAddendumC := NewReturnDetailAddendumC()
 	rdAddendumC.Parse(r.line)
+	r.recordParsed(&rdAddendumC)
 	if err := rdAddendumC.Validate(); err != nil {
 		return r.error(err)
 	}
@@ -429,11 +1537,14 @@ func (r *Reader) parseReturnDetailAddendumD() error {
 	}
 	rdAddendumD := NewReturnDetailAddendumD()
 	rdAddendumD.Parse(r.line)
+	r.recordParsed(&rdAddendumD)
 	if err := rdAddendumD.Validate(); err != nil {
 		return r.error(err)
 	}
 	entryIndex := len(r.currentCashLetter.currentBundle.GetReturns()) - 1
-	r.currentCashLetter.currentBundle.Returns[entryIndex].AddReturnDetailAddendumD(rdAddendumD)
+	if err := r.currentCashLetter.currentBundle.Returns[entryIndex].AddReturnDetailAddendumD(rdAddendumD); err != nil {
+		return r.error(err)
+	}
 	return nil
 }
 
@@ -451,6 +1562,7 @@ func (r *Reader) ImageViewDetail() error {
 	if r.currentCashLetter.currentBundle.GetChecks() != nil {
 		ivDetail := NewImageViewDetail()
 		ivDetail.Parse(r.line)
+		r.recordParsed(&ivDetail)
 		if err := ivDetail.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -460,6 +1572,7 @@ func (r *Reader) ImageViewDetail() error {
 	} else if r.currentCashLetter.currentBundle.GetReturns() != nil {
 		ivDetail := NewImageViewDetail()
 		ivDetail.Parse(r.line)
+		r.recordParsed(&ivDetail)
 		if err := ivDetail.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -484,9 +1597,13 @@ func (r *Reader) parseImageViewData() error {
 
 // ImageViewData takes the input record string and parses ImageViewData for a check
 func (r *Reader) ImageViewData() error {
+	ivData := NewImageViewData()
+	if err := ivData.validateSegments(r.line, !r.skipImageData); err != nil {
+		return r.error(err)
+	}
 	if r.currentCashLetter.currentBundle.GetChecks() != nil {
-		ivData := NewImageViewData()
 		ivData.Parse(r.line)
+		r.recordParsed(&ivData)
 		if err := ivData.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -494,8 +1611,8 @@ func (r *Reader) ImageViewData() error {
 		r.currentCashLetter.currentBundle.Checks[entryIndex].AddImageViewData(ivData)
 
 	} else if r.currentCashLetter.currentBundle.GetReturns() != nil {
-		ivData := NewImageViewData()
 		ivData.Parse(r.line)
+		r.recordParsed(&ivData)
 		if err := ivData.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -523,6 +1640,7 @@ func (r *Reader) ImageViewAnalysis() error {
 	if r.currentCashLetter.currentBundle.GetChecks() != nil {
 		ivAnalysis := NewImageViewAnalysis()
 		ivAnalysis.Parse(r.line)
+		r.recordParsed(&ivAnalysis)
 		if err := ivAnalysis.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -532,6 +1650,7 @@ func (r *Reader) ImageViewAnalysis() error {
 	} else if r.currentCashLetter.currentBundle.GetReturns() != nil {
 		ivAnalysis := NewImageViewAnalysis()
 		ivAnalysis.Parse(r.line)
+		r.recordParsed(&ivAnalysis)
 		if err := ivAnalysis.Validate(); err != nil {
 			return r.error(err)
 		}
@@ -547,20 +1666,45 @@ func (r *Reader) ImageViewAnalysis() error {
 
 // parseCreditItem takes the input record string and parses the CreditItem values
 func (r *Reader) parseCreditItem() error {
-	// Current implementation has the credit letter outside the bundle but within the cash letter
+	// A CreditItem within an open bundle is kept with the Bundle, otherwise with the CashLetter
 	r.recordName = "CreditItem"
 	if r.currentCashLetter.CashLetterHeader == nil {
 		return r.error(&FileError{Msg: msgFileCreditItem})
 	}
 	ci := new(CreditItem)
 	ci.Parse(r.line)
+	r.recordParsed(ci)
 	if err := ci.Validate(); err != nil {
 		return r.error(err)
 	}
+	if b := r.currentCashLetter.currentBundle; b != nil && b.BundleHeader != nil {
+		b.AddCreditItem(ci)
+		return nil
+	}
 	r.currentCashLetter.AddCreditItem(ci)
 	return nil
 }
 
+// parseCredit takes the input record string and parses the Credit values
+func (r *Reader) parseCredit() error {
+	r.recordName = "Credit"
+	if r.currentCashLetter.CashLetterHeader == nil {
+		return r.error(&FileError{Msg: msgFileCredit})
+	}
+	cr := new(Credit)
+	cr.Parse(r.line)
+	r.recordParsed(cr)
+	if err := cr.Validate(); err != nil {
+		return r.error(err)
+	}
+	if b := r.currentCashLetter.currentBundle; b != nil && b.BundleHeader != nil {
+		b.AddCredit(cr)
+		return nil
+	}
+	r.currentCashLetter.AddCredit(cr)
+	return nil
+}
+
 // parseBundleControl takes the input record string and parses the BundleControl values
 func (r *Reader) parseBundleControl() error {
 	r.recordName = "BundleControl"
@@ -569,6 +1713,7 @@ func (r *Reader) parseBundleControl() error {
 		return r.error(&FileError{Msg: msgFileBundleControl})
 	}
 	r.currentCashLetter.currentBundle.GetControl().Parse(r.line)
+	r.recordParsed(r.currentCashLetter.currentBundle.GetControl())
 	if err := r.currentCashLetter.currentBundle.GetControl().Validate(); err != nil {
 		return r.error(err)
 	}
@@ -584,9 +1729,11 @@ func (r *Reader) parseRoutingNumberSummary() error {
 
 	rns := NewRoutingNumberSummary()
 	rns.Parse(r.line)
+	r.recordParsed(rns)
 	if err := rns.Validate(); err != nil {
 		return r.error(err)
 	}
+	r.addCurrentRoutingNumberSummary(rns)
 	return nil
 }
 
@@ -598,6 +1745,7 @@ func (r *Reader) parseCashLetterControl() error {
 		return r.error(&FileError{Msg: msgFileCashLetterControl})
 	}
 	r.currentCashLetter.GetControl().Parse(r.line)
+	r.recordParsed(r.currentCashLetter.GetControl())
 	// Ensure valid CashLetterControl
 	if err := r.currentCashLetter.GetControl().Validate(); err != nil {
 		return r.error(err)
@@ -613,6 +1761,7 @@ func (r *Reader) parseFileControl() error {
 		return r.error(&FileError{Msg: msgFileControl})
 	}
 	r.File.Control.Parse(r.line)
+	r.recordParsed(&r.File.Control)
 	// Ensure valid FileControl
 	if err := r.File.Control.Validate(); err != nil {
 		return r.error(err)
@@ -0,0 +1,47 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package imagecashletter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzReader validates Read and ReadAll return errors rather than panic for arbitrary input.
+// The corpus is seeded with the testdata fixtures and run with: go test -run=^$ -fuzz=FuzzReader
+func FuzzReader(f *testing.F) {
+	for _, dir := range []string{
+		filepath.Join("test", "testdata"),
+		filepath.Join("test", "testdata", "crashers"),
+		filepath.Join("test", "fuzz-reader", "corpus"),
+	} {
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, info := range infos {
+			if info.IsDir() {
+				continue
+			}
+			bs, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+			if err != nil && !os.IsNotExist(err) {
+				f.Fatal(err)
+			}
+			f.Add(bs)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		NewReader(bytes.NewReader(data)).Read()
+		NewReader(bytes.NewReader(data)).ReadAll()
+		NewReader(bytes.NewReader(data), ReadBlockedOption()).ReadAll()
+	})
+}
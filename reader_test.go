@@ -5,12 +5,20 @@
 package imagecashletter
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestICLFileRead validates reading an ICL file
@@ -85,7 +93,7 @@ func TestRecordTypeUnknown(t *testing.T) {
 	}
 }
 
-//TestFileLineShort validates file line is short
+// TestFileLineShort validates file line is short
 func TestFileLineShort(t *testing.T) {
 	line := "1 line is only 70 characters ........................................!"
 	r := NewReader(strings.NewReader(line))
@@ -102,6 +110,38 @@ func TestFileLineShort(t *testing.T) {
 	}
 }
 
+// TestRecordLengthByType validates each record is the length of its record type
+func TestRecordLengthByType(t *testing.T) {
+	cases := []struct {
+		line string
+		msg  string
+	}{
+		{"25" + strings.Repeat(" ", 60), "record type 25 expected 80 bytes, got 62"},
+		{"25" + strings.Repeat(" ", 79), "record type 25 expected 80 bytes, got 81"},
+		{"01" + strings.Repeat(" ", 77), "record type 01 expected 80 bytes, got 79"},
+		{"62" + strings.Repeat(" ", 78), "record type 62 expected 100 bytes, got 80"},
+		{"62" + strings.Repeat(" ", 120), "record type 62 expected 100 bytes, got 122"},
+		{"52" + strings.Repeat(" ", 88), "record type 52 expected at least 117 bytes, got 90"},
+		{"40" + strings.Repeat(" ", 70), fmt.Sprintf(msgRecordLength, 72)},
+	}
+	for _, c := range cases {
+		_, err := NewReader(strings.NewReader(c.line)).Read()
+		p, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%T: %s", err, err)
+			continue
+		}
+		if e, ok := p.Err.(*FileError); !ok || e.FieldName != "RecordLength" || e.Msg != c.msg {
+			t.Errorf("%T: %s", p.Err, p.Err)
+		}
+	}
+
+	// variable length records may be longer than their minimum
+	if err := checkRecordLength("52" + strings.Repeat(" ", 200)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 func TestReaderCrash__parseBundleControl(t *testing.T) {
 	r := &Reader{}
 	if err := r.parseBundleControl(); err == nil {
@@ -109,6 +149,68 @@ func TestReaderCrash__parseBundleControl(t *testing.T) {
 	}
 }
 
+// TestReaderCrash__parseLine validates a Reader without a line returns an error
+func TestReaderCrash__parseLine(t *testing.T) {
+	r := &Reader{}
+	if err := r.parseLine(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// TestReaderCrash__outOfOrder validates each record type without its enclosing records returns a FileError
+func TestReaderCrash__outOfOrder(t *testing.T) {
+	fileHeader := mockFileHeader()
+	fh := fileHeader.String()
+	clh := mockCashLetterHeader().String()
+	bh := mockBundleHeader().String()
+	bc := mockBundleControl().String()
+	cd := mockCheckDetail().String()
+	rd := mockReturnDetail().String()
+	cdAddendumA, cdAddendumB, cdAddendumC := mockCheckDetailAddendumA(), mockCheckDetailAddendumB(), mockCheckDetailAddendumC()
+	rdAddendumA, rdAddendumB, rdAddendumC, rdAddendumD := mockReturnDetailAddendumA(), mockReturnDetailAddendumB(), mockReturnDetailAddendumC(), mockReturnDetailAddendumD()
+	ivDetail, ivData, ivAnalysis := mockImageViewDetail(), mockImageViewData(), mockImageViewAnalysis()
+	tests := []struct {
+		records []string
+		record  string
+	}{
+		{[]string{fh, bh}, "BundleHeader"},
+		{[]string{fh, cd}, "CheckDetail"},
+		{[]string{fh, clh, cd}, "CheckDetail"},
+		{[]string{fh, clh, bh, cd, cdAddendumA.String(), cdAddendumB.String(), cdAddendumC.String(), bc, cd}, "CheckDetail"},
+		{[]string{fh, cdAddendumA.String()}, "CheckDetailAddendumA"},
+		{[]string{fh, cdAddendumB.String()}, "CheckDetailAddendumB"},
+		{[]string{fh, cdAddendumC.String()}, "CheckDetailAddendumC"},
+		{[]string{fh, clh, bh, cd, cdAddendumA.String(), cdAddendumB.String(), cdAddendumC.String(), bc, cdAddendumA.String()}, "CheckDetailAddendumA"},
+		{[]string{fh, rd}, "ReturnDetail"},
+		{[]string{fh, clh, bh, rd, rdAddendumA.String(), rdAddendumB.String(), rdAddendumC.String(), rdAddendumD.String(), bc, rd}, "ReturnDetail"},
+		{[]string{fh, rdAddendumA.String()}, "ReturnDetailAddendumA"},
+		{[]string{fh, rdAddendumB.String()}, "ReturnDetailAddendumB"},
+		{[]string{fh, rdAddendumC.String()}, "ReturnDetailAddendumC"},
+		{[]string{fh, rdAddendumD.String()}, "ReturnDetailAddendumD"},
+		{[]string{fh, ivDetail.String()}, "ImageViewDetail"},
+		{[]string{fh, ivData.String()}, "ImageViewData"},
+		{[]string{fh, ivAnalysis.String()}, "ImageViewAnalysis"},
+		{[]string{fh, clh, bh, ivDetail.String()}, "ImageViewDetail"},
+		{[]string{fh, mockCreditItem().String()}, "CreditItem"},
+		{[]string{fh, mockCredit().String()}, "Credit"},
+		{[]string{fh, bc}, "BundleControl"},
+		{[]string{fh, clh, bc}, "BundleControl"},
+		{[]string{fh, mockRoutingNumberSummary().String()}, "RoutingNumberSummary"},
+		{[]string{fh, mockCashLetterControl().String()}, "CashLetterControl"},
+	}
+	for _, test := range tests {
+		_, err := NewReader(strings.NewReader(strings.Join(test.records, "\n"))).Read()
+		p, ok := err.(*ParseError)
+		if !ok || p.Record != test.record {
+			t.Errorf("%s: %T: %s", test.record, err, err)
+			continue
+		}
+		if _, ok := p.Err.(*FileError); !ok {
+			t.Errorf("%s: %T: %s", test.record, p.Err, p.Err)
+		}
+	}
+}
+
 // TestFileFileHeaderErr validates error flows back from the parser
 func TestFileFileHeaderErr(t *testing.T) {
 	fh := mockFileHeader()
@@ -128,6 +230,100 @@ func TestFileFileHeaderErr(t *testing.T) {
 	}
 }
 
+// TestReadInvalidDates validates date fields which are not calendar dates are reported
+func TestReadInvalidDates(t *testing.T) {
+	var line = "0135T231380104121042882201809051523NCitadel           Wells Fargo        US     "
+	for _, date := range []string{"20180230", "20181301", "20180931", "2018AB05", " 2018090", "00000000"} {
+		r := NewReader(strings.NewReader(line[:23] + date + line[31:]))
+		_, err := r.Read()
+		p, ok := err.(*ParseError)
+		if !ok || p.Record != "FileHeader" {
+			t.Errorf("%s: %T: %s", date, err, err)
+			continue
+		}
+		if e, ok := p.Err.(*FieldError); !ok || e.FieldName != "FileCreationDate" || e.Value != date || e.Msg != msgDate {
+			t.Errorf("%s: %T: %s", date, p.Err, p.Err)
+		}
+	}
+
+	// a blank optional date is not reported
+	clc := mockCashLetterControl()
+	clc.SettlementDate = time.Time{}
+	r := NewReader(strings.NewReader(clc.String()))
+	_, err := r.Read()
+	if p, ok := err.(*ParseError); !ok || p.Record != "CashLetterControl" {
+		t.Errorf("%T: %s", err, err)
+	} else if e, ok := p.Err.(*FieldError); ok && e.FieldName == "SettlementDate" {
+		t.Errorf("%T: %s", e, e)
+	}
+}
+
+// TestReadNonNumericAmount validates amount fields which are not numeric are reported
+func TestReadNonNumericAmount(t *testing.T) {
+	line := mockCheckDetail().String()
+	line = line[:47] + "00001234.5" + line[57:]
+	_, err := NewReader(strings.NewReader(line)).Read()
+	p, ok := err.(*ParseError)
+	if !ok || p.Record != "CheckDetail" {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if e, ok := p.Err.(*FieldError); !ok || e.FieldName != "ItemAmount" || e.Msg != msgAmountNumeric {
+		t.Errorf("%T: %s", p.Err, p.Err)
+	}
+}
+
+// TestReadEmbeddedBlanks validates numeric fields with blanks between digits are reported
+func TestReadEmbeddedBlanks(t *testing.T) {
+	tests := []struct {
+		record, line, fieldName, msg string
+	}{
+		{"CheckDetail", func() string {
+			line := mockCheckDetail().String()
+			return line[:47] + "000012 345" + line[57:]
+		}(), "ItemAmount", msgAmountNumeric},
+		{"ImageViewDetail", func() string {
+			ivDetail := mockImageViewDetail()
+			line := ivDetail.String()
+			return line[:37] + "00 12" + line[42:]
+		}(), "SecurityKeySize", msgNumeric},
+		{"BundleControl", func() string {
+			line := mockBundleControl().String()
+			return line[:2] + "0 01" + line[6:]
+		}(), "BundleItemsCount", msgNumeric},
+		{"FileControl", func() string {
+			fc := mockFileControl()
+			line := fc.String()
+			return line[:8] + "0000 012" + line[16:]
+		}(), "TotalRecordCount", msgNumeric},
+	}
+	for _, tc := range tests {
+		_, err := NewReader(strings.NewReader(tc.line)).Read()
+		p, ok := err.(*ParseError)
+		if !ok || p.Record != tc.record {
+			t.Errorf("%s: %T: %s", tc.fieldName, err, err)
+			continue
+		}
+		if e, ok := p.Err.(*FieldError); !ok || e.FieldName != tc.fieldName || e.Msg != tc.msg {
+			t.Errorf("%s: %T: %s", tc.fieldName, p.Err, p.Err)
+		}
+	}
+}
+
+// TestIsNumericBlanks validates blanks are accepted before or after digits but not between them
+func TestIsNumericBlanks(t *testing.T) {
+	v := validator{}
+	for _, s := range []string{"", "12345", " 12345", "12345 ", "  123  "} {
+		if err := v.isNumeric(s); err != nil {
+			t.Errorf("%q: %s", s, err)
+		}
+	}
+	for _, s := range []string{"123 45", " 1 2 ", "1  2"} {
+		if err := v.isNumeric(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
 // TestTwoFileHeaders validates one file header
 func TestTwoFileHeaders(t *testing.T) {
 	var line = "0135T231380104121042882201809051523NCitadel           Wells Fargo        US     "
@@ -145,6 +341,194 @@ func TestTwoFileHeaders(t *testing.T) {
 	}
 }
 
+// TestICLReadMaxRecordSize validates records larger than the maximum record size are reported
+func TestICLReadMaxRecordSize(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ivData := &file.CashLetters[0].Bundles[0].Checks[0].ImageViewData[0]
+	ivData.ImageData = bytes.Repeat([]byte{0xff}, 100000)
+	ivData.LengthImageData = strconv.Itoa(len(ivData.ImageData))
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		t.Fatal(err)
+	}
+
+	// the default maximum record size is smaller than the ImageViewData record
+	_, err = NewReader(bytes.NewReader(buf.Bytes())).Read()
+	p, ok := err.(*ParseError)
+	if !ok || p.Line != 9 {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if e, ok := p.Err.(*FileError); !ok || e.Msg != fmt.Sprintf(msgRecordSize, bufio.MaxScanTokenSize) {
+		t.Errorf("%T: %s", p.Err, p.Err)
+	}
+
+	_, err = NewReader(bytes.NewReader(buf.Bytes()), ReadMaxRecordSizeOption(200)).Read()
+	if p, ok := err.(*ParseError); !ok || p.Line != 9 || !strings.Contains(p.Error(), "maximum record size of 200 bytes") {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	r := NewReader(bytes.NewReader(buf.Bytes()), ReadBufferSizeOption(1<<16), ReadMaxRecordSizeOption(1<<20))
+	read, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := len(read.CashLetters[0].Bundles[0].Checks[0].ImageViewData[0].ImageData); n != 100000 {
+		t.Errorf("unexpected ImageData length %d", n)
+	}
+}
+
+// TestICLReadContinueOnError validates items with invalid fields are skipped and their errors recorded
+func TestICLReadContinueOnError(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	// non-numeric ItemAmount of the first CheckDetail
+	lines[3] = lines[3][:47] + "ABCDEFGHIJ" + lines[3][57:]
+	// invalid ImageIndicator of the second ReturnDetail's ImageViewDetail
+	lines[32] = lines[32][:2] + "9" + lines[32][3:]
+	corrupt := strings.Join(lines, "\n")
+
+	// the default is to stop at the first error
+	r := NewReader(strings.NewReader(corrupt))
+	if _, err := r.Read(); err == nil {
+		t.Error("expected error")
+	}
+	if len(r.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", r.Errors())
+	}
+
+	r = NewReader(strings.NewReader(corrupt), ReadContinueOnErrorOption())
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	errs := r.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for i, want := range []struct {
+		line   int
+		record string
+	}{{4, "CheckDetail"}, {33, "ImageViewDetail"}} {
+		if p, ok := errs[i].(*ParseError); !ok || p.Line != want.line || p.Record != want.record {
+			t.Errorf("%T: %s", errs[i], errs[i])
+		}
+	}
+
+	bundles := file.CashLetters[0].Bundles
+	if n := len(bundles[0].Checks); n != 1 {
+		t.Errorf("expected 1 CheckDetail, got %d", n)
+	}
+	if n := len(bundles[0].Checks[0].CheckDetailAddendumA); n != 1 {
+		t.Errorf("expected 1 CheckDetailAddendumA, got %d", n)
+	}
+	if n := len(bundles[1].Returns); n != 1 {
+		t.Errorf("expected 1 ReturnDetail, got %d", n)
+	}
+	if n := len(file.CashLetters[1].Bundles[0].Checks); n != 2 {
+		t.Errorf("expected 2 CheckDetail, got %d", n)
+	}
+
+	// errors which are not in an item are returned
+	lines = strings.Split(string(bs), "\n")
+	lines[2] = lines[2][:22] + "20181301" + lines[2][30:]
+	r = NewReader(strings.NewReader(strings.Join(lines, "\n")), ReadContinueOnErrorOption())
+	_, err = r.Read()
+	if p, ok := err.(*ParseError); !ok || p.Record != "BundleHeader" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReadAllConcatenated validates reading two complete files from one stream
+func TestReadAllConcatenated(t *testing.T) {
+	first, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20181010121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := append(append(append([]byte(nil), first...), '\n'), second...)
+
+	files, err := NewReader(bytes.NewReader(stream)).ReadAll()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+	for i, bs := range [][]byte{first, second} {
+		want, err := NewReader(bytes.NewReader(bs)).Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files[i].CashLetters) != len(want.CashLetters) || files[i].Control != want.Control {
+			t.Errorf("file %d does not match reading it alone", i)
+		}
+		if err := files[i].Validate(); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+	}
+	if len(files[1].CashLetters[0].GetCreditItems()) != 1 {
+		t.Error("expected CreditItem in second file")
+	}
+
+	// Read is unchanged and does not accept a second file
+	if _, err := NewReader(bytes.NewReader(stream)).Read(); err == nil {
+		t.Error("expected Read error")
+	}
+
+	// records following a FileControl must begin a new file
+	lines := strings.Split(string(second), "\n")
+	stream = append(append(append([]byte(nil), first...), '\n'), strings.Join(lines[1:], "\n")...)
+	files, err = NewReader(bytes.NewReader(stream)).ReadAll()
+	if p, ok := err.(*ParseError); !ok || p.Record != "FileHeader" || p.Line != 75 {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected 1 file, got %d", len(files))
+	}
+}
+
+// TestReadAllIncomplete validates ReadAll errors on a FileHeader without a FileControl
+func TestReadAllIncomplete(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := bs[:bytes.IndexByte(bs, '\n')]
+	stream := append(append(append([]byte(nil), bs...), '\n'), header...)
+
+	r := NewReader(bytes.NewReader(stream))
+	files, err := r.ReadAll()
+	if p, ok := err.(*ParseError); !ok || p.Err.(*FileError).Msg != msgFileControl {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected 1 file, got %d", len(files))
+	}
+	if r.PartialFile().Header.ImmediateOrigin == "" {
+		t.Error("expected partial FileHeader")
+	}
+
+	_, err = NewReader(strings.NewReader("")).ReadAll()
+	if p, ok := err.(*ParseError); !ok || p.Err.(*FileError).Msg != msgFileHeader {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestCashLetterHeaderErr validates error flows back from the parser
 func TestCashLetterHeaderErr(t *testing.T) {
 	clh := mockCashLetterHeader()
@@ -980,6 +1364,206 @@ func TestIVAnalysisBundleError(t *testing.T) {
 	}
 }
 
+// TestICLReadPartialFile validates the cash letters read before an error are kept
+func TestICLReadPartialFile(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	// corrupt the first record following the second CashLetterHeader
+	if !strings.HasPrefix(lines[37], cashLetterHeaderPos) {
+		t.Fatalf("unexpected record: %s", lines[37][:2])
+	}
+	lines[38] = "AA" + lines[38][2:]
+
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	file, err := r.Read()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if e, ok := err.(*ParseError); !ok || e.Line != 39 {
+		t.Errorf("%T: %s", err, err)
+	}
+	partial := r.PartialFile()
+	if len(partial.CashLetters) != 1 || len(file.CashLetters) != 1 {
+		t.Fatalf("expected 1 cash letter, got %d", len(partial.CashLetters))
+	}
+	if id := partial.CashLetters[0].CashLetterHeader.CashLetterID; id != "A1" {
+		t.Errorf("CashLetterID %q", id)
+	}
+	if len(partial.CashLetters[0].Bundles) != 2 {
+		t.Errorf("expected 2 bundles, got %d", len(partial.CashLetters[0].Bundles))
+	}
+	if partial.Header.ImmediateOrigin == "" {
+		t.Error("expected FileHeader")
+	}
+}
+
+// TestICLReadImageViewDataCount validates reading a file with a missing ImageViewData record
+func TestICLReadImageViewDataCount(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// drop the first ImageViewData along with the ImageViewAnalysis which follows it
+	var lines []string
+	dropped := 0
+	for _, line := range strings.Split(string(bs), "\n") {
+		if dropped == 0 && strings.HasPrefix(line, imageViewDataPos) || dropped == 1 && strings.HasPrefix(line, imageViewAnalysisPos) {
+			dropped++
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	if e, ok := err.(*ParseError); ok {
+		if fe, ok := e.Err.(*FieldError); !ok || fe.FieldName != "ImageViewData" {
+			t.Errorf("%T: %s", e.Err, e.Err)
+		}
+	} else {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestICLReadImageViewOrder validates image view records out of order are returned as a FileError
+func TestICLReadImageViewOrder(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		drop      []string
+		fieldName string
+	}{
+		// ImageViewAnalysis with no ImageViewDetail or ImageViewData
+		{[]string{imageViewDetailPos, imageViewDataPos}, "ImageViewAnalysis"},
+		// ImageViewData with no ImageViewDetail
+		{[]string{imageViewDetailPos}, "ImageViewData"},
+	}
+	for _, test := range tests {
+		// drop the first records of each type, which belong to the first CheckDetail
+		var lines []string
+		dropped := map[string]bool{}
+		for _, line := range strings.Split(string(bs), "\n") {
+			if recordType := line[:2]; !dropped[recordType] {
+				drop := false
+				for _, d := range test.drop {
+					drop = drop || recordType == d
+				}
+				if drop {
+					dropped[recordType] = true
+					continue
+				}
+			}
+			lines = append(lines, line)
+		}
+
+		_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+		p, ok := err.(*ParseError)
+		if !ok {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if e, ok := p.Err.(*FileError); !ok || e.FieldName != test.fieldName {
+			t.Errorf("%T: %s", p.Err, p.Err)
+		}
+		// the record following the first CheckDetailAddendumC
+		if p.Line != 8 {
+			t.Errorf("%s: expected line 8, got %d", test.fieldName, p.Line)
+		}
+	}
+}
+
+// TestICLReadGzip validates reading a gzip compressed file matches reading the uncompressed file
+func TestICLReadGzip(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(bs); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	unzipped, err := NewReader(&compressed).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var want, got bytes.Buffer
+	if err := NewWriter(&want).Write(&raw); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := NewWriter(&got).Write(&unzipped); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(want.Bytes(), got.Bytes()) {
+		t.Error("gzip compressed file does not read identically to the uncompressed file")
+	}
+
+	// a truncated gzip stream
+	_, err = NewReader(bytes.NewReader(gzipMagic)).Read()
+	if err == nil {
+		t.Error("expected error reading truncated gzip stream")
+	}
+}
+
+// TestReadFile validates ReadFile reads a file by name, detecting gzip compression
+func TestReadFile(t *testing.T) {
+	path := filepath.Join("test", "testdata", "BNK20180905121042882-A.icl")
+	file, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(file.CashLetters) != 2 {
+		t.Errorf("unexpected %d CashLetters", len(file.CashLetters))
+	}
+
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "icl-readfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	compressed, err := os.Create(filepath.Join(dir, "file.icl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(compressed)
+	if _, err := zw.Write(bs); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := compressed.Close(); err != nil {
+		t.Fatal(err)
+	}
+	unzipped, err := ReadFile(compressed.Name(), ReadSkipImageDataOption())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(unzipped.CashLetters) != 2 || !unzipped.ImageDataSkipped() {
+		t.Error("gzip compressed file was not read with the ReaderOptions")
+	}
+
+	if _, err := ReadFile(filepath.Join(dir, "missing.icl")); !os.IsNotExist(err) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestICLCreditItemFile validates reading an ICL file with a CreditItem
 func TestICLCreditItemFile(t *testing.T) {
 	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20181010121042882-A.icl"))
@@ -998,23 +1582,876 @@ func TestICLCreditItemFile(t *testing.T) {
 	}
 }
 
-func TestICLBase64ImageData(t *testing.T) {
-	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+// TestICLMultipleCreditItemsFile validates reading and writing an ICL file with three CreditItems
+func TestICLMultipleCreditItemsFile(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "credit-items.icl"))
 	if err != nil {
 		t.Fatal(err)
 	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("Issue reading file: %+v \n", err)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
 
-	file, err := FileFromJSON(bs)
+	items := file.CashLetters[0].GetCreditItems()
+	if len(items) != 3 {
+		t.Fatalf("expected 3 CreditItems, got %d", len(items))
+	}
+	amounts := []int{100000, 25050, 7525}
+	for i, ci := range items {
+		if seq := strconv.Itoa(i + 1); ci.CreditItemSequenceNumber != seq {
+			t.Errorf("CreditItem %d: CreditItemSequenceNumber %q, expected %q", i, ci.CreditItemSequenceNumber, seq)
+		}
+		if ci.ItemAmount != amounts[i] {
+			t.Errorf("CreditItem %d: ItemAmount %d, expected %d", i, ci.ItemAmount, amounts[i])
+		}
+	}
+	if n := file.CashLetters[0].CashLetterControl.CashLetterItemsCount; n != 10 {
+		t.Errorf("CashLetterItemsCount %d, expected 10", n)
+	}
+
+	if err := VerifyRoundTrip(bs); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestICLCreditItemsInBundle validates reading and writing an ICL file with CreditItems following both the
+// CashLetterHeader and the BundleHeader
+func TestICLCreditItemsInBundle(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "credit-in-bundle.icl"))
 	if err != nil {
 		t.Fatal(err)
 	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("Issue reading file: %+v \n", err)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
 
-	var buf bytes.Buffer
-	if err := NewWriter(&buf).Write(file); err != nil {
-		t.Fatal(err)
+	cl := file.CashLetters[0]
+	if n := len(cl.GetCreditItems()); n != 1 {
+		t.Errorf("expected 1 CashLetter CreditItem, got %d", n)
+	}
+	if n := len(cl.GetBundles()[0].CreditItems); n != 2 {
+		t.Fatalf("expected 2 Bundle CreditItems, got %d", n)
+	}
+	if seq := cl.GetBundles()[0].CreditItems[1].CreditItemSequenceNumber; seq != "3" {
+		t.Errorf("CreditItemSequenceNumber %q, expected 3", seq)
+	}
+	if summary := file.Summary(); summary.CreditItemCount != 3 {
+		t.Errorf("Summary CreditItemCount %d, expected 3", summary.CreditItemCount)
 	}
 
-	if !bytes.Contains(buf.Bytes(), []byte("hello, world")) {
-		t.Fatalf("unexpected ICL file:\n%s", buf.String())
+	if err := VerifyRoundTrip(bs); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// the Bundle credits are kept through JSON and control records built from them match the file
+	js, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := FileFromJSON(js)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
 	}
+	if n := len(read.CashLetters[0].GetBundles()[0].CreditItems); n != 2 {
+		t.Errorf("expected 2 Bundle CreditItems from JSON, got %d", n)
+	}
+	control := *file.CashLetters[0].CashLetterControl
+	if err := file.CashLetters[0].build(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if got := *file.CashLetters[0].CashLetterControl; got.CashLetterItemsCount != control.CashLetterItemsCount ||
+		got.CashLetterTotalAmount != control.CashLetterTotalAmount {
+		t.Errorf("built CashLetterControl %#v, expected %#v", got, control)
+	}
+}
+
+func TestICLBase64ImageData(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "base64-encoded-images.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := FileFromJSON(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("hello, world")) {
+		t.Fatalf("unexpected ICL file:\n%s", buf.String())
+	}
+}
+
+// TestICLReadSkipImageData validates reading an ICL file without ImageData
+func TestICLReadSkipImageData(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	skipped, err := NewReader(bytes.NewReader(bs), ReadSkipImageDataOption()).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.ImageDataSkipped() || !skipped.ImageDataSkipped() {
+		t.Errorf("full=%v skipped=%v", full.ImageDataSkipped(), skipped.ImageDataSkipped())
+	}
+	if err := skipped.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if full.Control != skipped.Control {
+		t.Errorf("FileControl mismatch:\n %#v\n %#v", full.Control, skipped.Control)
+	}
+	if full.AverageImageBytes() == 0 || skipped.AverageImageBytes() != 0 {
+		t.Errorf("full=%v skipped=%v", full.AverageImageBytes(), skipped.AverageImageBytes())
+	}
+
+	for i := range full.CashLetters {
+		fullCL, skippedCL := full.CashLetters[i], skipped.CashLetters[i]
+		if *fullCL.GetControl() != *skippedCL.GetControl() {
+			t.Errorf("CashLetterControl mismatch:\n %#v\n %#v", fullCL.GetControl(), skippedCL.GetControl())
+		}
+		for j := range fullCL.Bundles {
+			fullB, skippedB := fullCL.Bundles[j], skippedCL.Bundles[j]
+			if *fullB.GetControl() != *skippedB.GetControl() {
+				t.Errorf("BundleControl mismatch:\n %#v\n %#v", fullB.GetControl(), skippedB.GetControl())
+			}
+			for k := range fullB.Checks {
+				ivData := skippedB.Checks[k].GetImageViewData()
+				if len(ivData) != len(fullB.Checks[k].GetImageViewData()) {
+					t.Errorf("check %d: ImageViewData count mismatch", k)
+				}
+				for _, iv := range ivData {
+					if iv.LengthImageData != "0000001" || len(iv.ImageData) != 0 {
+						t.Errorf("unexpected ImageViewData: LengthImageData=%q ImageData=%q", iv.LengthImageData, iv.ImageData)
+					}
+				}
+			}
+		}
+	}
+
+	// A file without its images can't be written
+	if err := NewWriter(ioutil.Discard).Write(&skipped); err != nil {
+		if e, ok := err.(*FileError); !ok || e.Msg != msgFileImageDataSkipped {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+}
+
+// imageHeavyFile returns an encoded ICL file with large ImageData for benchmarks
+func imageHeavyFile(b *testing.B) []byte {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		b.Fatal(err)
+	}
+	// bufio.Scanner's default buffer limits the size of a line, so stay under 64KB
+	image := bytes.Repeat([]byte{0xFF}, 60000)
+	for _, cl := range file.CashLetters {
+		for _, bundle := range cl.Bundles {
+			for _, cd := range bundle.Checks {
+				for i := range cd.ImageViewData {
+					cd.ImageViewData[i].LengthImageData = "0060000"
+					cd.ImageViewData[i].ImageData = image
+				}
+			}
+			for _, rd := range bundle.Returns {
+				for i := range rd.ImageViewData {
+					rd.ImageViewData[i].LengthImageData = "0060000"
+					rd.ImageViewData[i].ImageData = image
+				}
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkReadImageData(b *testing.B) {
+	bs := imageHeavyFile(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewReader(bytes.NewReader(bs)).Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadSkipImageData(b *testing.B) {
+	bs := imageHeavyFile(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewReader(bytes.NewReader(bs), ReadSkipImageDataOption()).Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestReadLeadingBOM validates a File preceded by a UTF-8 byte order mark and blank lines reads
+// identically to the File alone
+func TestReadLeadingBOM(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for _, prefix := range []string{"\xef\xbb\xbf", "\xef\xbb\xbf\r\n  \n", "\n\n"} {
+		file, err := NewReader(strings.NewReader(prefix + string(bs))).Read()
+		if err != nil {
+			t.Fatalf("%q: %T: %s", prefix, err, err)
+		}
+		if !file.Equal(&want) {
+			t.Errorf("%q: %v", prefix, file.Diff(&want))
+		}
+	}
+
+	// A blank line following the FileHeader is still an error
+	lines := strings.SplitN(string(bs), "\n", 2)
+	if _, err := NewReader(strings.NewReader(lines[0] + "\n\n" + lines[1])).Read(); err == nil {
+		t.Error("expected an error for a blank line following the FileHeader")
+	}
+}
+
+// TestReadCRTerminated validates a File whose records end with a lone CR reads identically to the
+// File with LF line endings
+func TestReadCRTerminated(t *testing.T) {
+	want, err := ReadFile(filepath.Join("test", "testdata", "credit-items.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file, err := ReadFile(filepath.Join("test", "testdata", "credit-items-cr.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !file.Equal(want) {
+		t.Errorf("%v", file.Diff(want))
+	}
+
+	// stray CR and LF bytes ending a record are removed
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "credit-items.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stray := strings.Replace(string(bs), "\n", "\r\r\n", -1)
+	read, err := NewReader(strings.NewReader(stray)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !read.Equal(want) {
+		t.Errorf("%v", read.Diff(want))
+	}
+}
+
+// TestReadObserver validates the records passed to ReadOnRecordOption and summarized for ReadOnEOFOption
+func TestReadObserver(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := make(map[string]int)
+	var summaries []ReadSummary
+	r := NewReader(bytes.NewReader(bs),
+		ReadOnRecordOption(func(recordType string, n int) { records[recordType]++ }),
+		ReadOnEOFOption(func(summary ReadSummary) { summaries = append(summaries, summary) }))
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("unexpected %d summaries", len(summaries))
+	}
+	summary := summaries[0]
+	lines := bytes.Split(bytes.TrimSuffix(bs, []byte("\n")), []byte("\n"))
+	if summary.Records != len(lines) || summary.Bytes != int64(len(bs)-bytes.Count(bs, []byte("\n"))) {
+		t.Errorf("unexpected %d records of %d bytes", summary.Records, summary.Bytes)
+	}
+	if !reflect.DeepEqual(summary.RecordTypes, records) {
+		t.Errorf("summary %v does not match %v", summary.RecordTypes, records)
+	}
+	if n := records["CheckDetail"]; n != len(file.AllCheckDetails()) {
+		t.Errorf("unexpected %d CheckDetail records", n)
+	}
+	if n := records["ReturnDetail"]; n != len(file.AllReturnDetails()) {
+		t.Errorf("unexpected %d ReturnDetail records", n)
+	}
+	if records["FileHeader"] != 1 || records["FileControl"] != 1 || records["CashLetterHeader"] != len(file.CashLetters) {
+		t.Errorf("unexpected records %v", records)
+	}
+
+	// the summary isn't passed when reading stops at an error, such as a short record
+	summaries = nil
+	r.Reset(bytes.NewReader(bytes.Replace(bs, []byte("\n"), []byte("\nshort\n"), 1)))
+	if _, err := r.Read(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(summaries) != 0 {
+		t.Errorf("unexpected summaries %v", summaries)
+	}
+
+	// nor when the file is truncated before its FileControl, or is empty
+	truncated := bs[:bytes.LastIndex(bytes.TrimSuffix(bs, []byte("\n")), []byte("\n"))+1]
+	for _, input := range [][]byte{truncated, nil} {
+		r.Reset(bytes.NewReader(input))
+		if _, err := r.Read(); err == nil {
+			t.Fatal("expected an error")
+		}
+		r.Reset(bytes.NewReader(input))
+		if _, err := r.ReadAll(); err == nil {
+			t.Fatal("expected an error")
+		}
+		if len(summaries) != 0 {
+			t.Errorf("unexpected summaries %v", summaries)
+		}
+	}
+}
+
+// TestReadImageDataNewlines validates ImageData holding LF and CR bytes is read intact rather than ending
+// the ImageViewData record
+func TestReadImageDataNewlines(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	image := []byte("II*\x00\n\x0a\r\n52\r\x0d\n")
+	ivData := &file.CashLetters[0].Bundles[0].Checks[0].ImageViewData[0]
+	ivData.ImageData = image
+	ivData.LengthImageData = fmt.Sprintf("%07d", len(image))
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for _, terminator := range []string{"\n", "\r\n", "\r"} {
+		bs := bytes.Replace(buf.Bytes(), []byte("\n"), []byte(terminator), -1)
+		// the terminators within ImageData are restored
+		bs = bytes.Replace(bs, bytes.Replace(image, []byte("\n"), []byte(terminator), -1), image, 1)
+		read, err := NewReader(bytes.NewReader(bs)).Read()
+		if err != nil {
+			t.Fatalf("%q: %T: %s", terminator, err, err)
+		}
+		if !read.Equal(file) {
+			t.Errorf("%q: %v", terminator, read.Diff(file))
+		}
+
+		read, err = NewReader(bytes.NewReader(bs), ReadSkipImageDataOption()).Read()
+		if err != nil {
+			t.Fatalf("%q: %T: %s", terminator, err, err)
+		}
+		if n := len(read.AllCheckDetails()); n != len(file.AllCheckDetails()) {
+			t.Errorf("%q: unexpected %d checks", terminator, n)
+		}
+	}
+}
+
+// rawLineRecord is a record read with ReadPreserveRawOption
+type rawLineRecord interface {
+	RawLine() string
+	String() string
+}
+
+// fileRecords returns the records of file in the order they are written
+func fileRecords(file *File) []rawLineRecord {
+	records := []rawLineRecord{&file.Header}
+	for i := range file.CashLetters {
+		cl := &file.CashLetters[i]
+		records = append(records, cl.CashLetterHeader)
+		for _, ci := range cl.CreditItems {
+			records = append(records, ci)
+		}
+		for _, cr := range cl.Credits {
+			records = append(records, cr)
+		}
+		for _, b := range cl.Bundles {
+			records = append(records, b.BundleHeader)
+			for _, cd := range b.Checks {
+				records = append(records, cd)
+				for j := range cd.CheckDetailAddendumA {
+					records = append(records, &cd.CheckDetailAddendumA[j])
+				}
+				for j := range cd.CheckDetailAddendumB {
+					records = append(records, &cd.CheckDetailAddendumB[j])
+				}
+				for j := range cd.CheckDetailAddendumC {
+					records = append(records, &cd.CheckDetailAddendumC[j])
+				}
+				for j := range cd.ImageViewDetail {
+					records = append(records, &cd.ImageViewDetail[j])
+				}
+				for j := range cd.ImageViewData {
+					records = append(records, &cd.ImageViewData[j])
+				}
+				for j := range cd.ImageViewAnalysis {
+					records = append(records, &cd.ImageViewAnalysis[j])
+				}
+			}
+			for _, rd := range b.Returns {
+				records = append(records, rd)
+				for j := range rd.ReturnDetailAddendumA {
+					records = append(records, &rd.ReturnDetailAddendumA[j])
+				}
+				for j := range rd.ReturnDetailAddendumB {
+					records = append(records, &rd.ReturnDetailAddendumB[j])
+				}
+				for j := range rd.ReturnDetailAddendumC {
+					records = append(records, &rd.ReturnDetailAddendumC[j])
+				}
+				for j := range rd.ReturnDetailAddendumD {
+					records = append(records, &rd.ReturnDetailAddendumD[j])
+				}
+				for j := range rd.ImageViewDetail {
+					records = append(records, &rd.ImageViewDetail[j])
+				}
+				for j := range rd.ImageViewData {
+					records = append(records, &rd.ImageViewData[j])
+				}
+				for j := range rd.ImageViewAnalysis {
+					records = append(records, &rd.ImageViewAnalysis[j])
+				}
+			}
+			records = append(records, b.BundleControl)
+		}
+		for _, rns := range cl.RoutingNumberSummary {
+			records = append(records, rns)
+		}
+		records = append(records, cl.CashLetterControl)
+	}
+	return append(records, &file.Control)
+}
+
+// TestReadPreserveRaw validates each record read with ReadPreserveRawOption holds the line it was parsed
+// from, which is the same as the record written for a clean file
+func TestReadPreserveRaw(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs), ReadPreserveRawOption()).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	records := fileRecords(&file)
+	if n := len(strings.Split(strings.TrimSpace(string(bs)), "\n")); len(records) != n {
+		t.Errorf("%d records for %d lines", len(records), n)
+	}
+	for i, record := range records {
+		if record.RawLine() == "" {
+			t.Errorf("%d %T: no raw line", i, record)
+			continue
+		}
+		if record.RawLine() != record.String() {
+			t.Errorf("%d %T: raw line %q != %q", i, record, record.RawLine(), record.String())
+		}
+	}
+
+	// Records are only kept with the option
+	file, err = NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for i, record := range fileRecords(&file) {
+		if record.RawLine() != "" {
+			t.Errorf("%d %T: unexpected raw line", i, record)
+		}
+	}
+}
+
+// TestReadTruncated validates a file cut off before its FileControl reports the control record
+// which was expected at the end of the file
+func TestReadTruncated(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+	tests := []struct {
+		lines  int
+		record string
+		msg    string
+	}{
+		{1, "FileControl", msgFileControl},
+		{2, "CashLetterControl", msgFileCashLetterControlEOF},
+		{10, "BundleControl", msgFileBundleControlEOF},
+		{18, "CashLetterControl", msgFileCashLetterControlEOF},
+		{37, "FileControl", msgFileControl},
+		{54, "CashLetterControl", msgFileCashLetterControlEOF},
+		{71, "BundleControl", msgFileBundleControlEOF},
+		{73, "FileControl", msgFileControl},
+	}
+	for _, tc := range tests {
+		input := strings.Join(lines[:tc.lines], "\n")
+		_, err := NewReader(strings.NewReader(input)).Read()
+		p, ok := err.(*ParseError)
+		if !ok || p.Record != tc.record || p.Line != tc.lines {
+			t.Errorf("%d lines: %T: %s", tc.lines, err, err)
+			continue
+		}
+		if e, ok := p.Err.(*FileError); !ok || e.Msg != tc.msg {
+			t.Errorf("%d lines: %T: %s", tc.lines, p.Err, p.Err)
+		}
+
+		_, err = NewReader(strings.NewReader(input)).ReadAll()
+		if p, ok := err.(*ParseError); !ok || p.Record != tc.record {
+			t.Errorf("%d lines: ReadAll: %T: %s", tc.lines, err, err)
+		}
+	}
+}
+
+// TestReadLocation validates dates read with ReadLocationOption are midnight in the location and are
+// written unchanged
+func TestReadLocation(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if loc := file.CashLetters[0].Bundles[0].BundleHeader.BundleBusinessDate.Location(); loc != time.UTC {
+		t.Errorf("default location %s", loc)
+	}
+
+	for _, loc := range []*time.Location{time.FixedZone("UTC-11", -11*3600), time.FixedZone("UTC+14", 14*3600)} {
+		file, err := NewReader(bytes.NewReader(bs), ReadLocationOption(loc)).Read()
+		if err != nil {
+			t.Fatalf("%s: %T: %s", loc, err, err)
+		}
+		bd := file.CashLetters[0].Bundles[0].BundleHeader.BundleBusinessDate
+		if bd.Location() != loc || bd.Format("20060102") != "20181003" || bd.Hour() != 0 {
+			t.Errorf("%s: BundleBusinessDate %s", loc, bd)
+		}
+		// an item presented just before midnight in loc is on the business date
+		presented := time.Date(2018, 10, 3, 23, 59, 0, 0, loc)
+		if y, m, d := presented.Date(); y != bd.Year() || m != bd.Month() || d != bd.Day() {
+			t.Errorf("%s: %s is not on %s", loc, presented, bd)
+		}
+		if !presented.After(bd) || presented.Sub(bd) >= 24*time.Hour {
+			t.Errorf("%s: %s is not within a day of %s", loc, presented, bd)
+		}
+		if ct := file.Header.FileCreationTime; ct.Location() != loc || ct.Format("1504") != "2219" {
+			t.Errorf("%s: FileCreationTime %s", loc, ct)
+		}
+
+		var buf bytes.Buffer
+		if err := NewWriter(&buf).Write(&file); err != nil {
+			t.Fatalf("%s: %T: %s", loc, err, err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(buf.Bytes()), bytes.TrimSpace(bs)) {
+			t.Errorf("%s: written file differs from input", loc)
+		}
+	}
+}
+
+// TestReaderReset validates a Reader which is Reset holds none of the state of the file read before
+func TestReaderReset(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// a file truncated inside its first Bundle, with an invalid item skipped by ReadContinueOnErrorOption
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+	lines[3] = strings.Replace(lines[3], "0000100000", "00001000X0", 1)
+	r := NewReader(strings.NewReader(strings.Join(lines[:12], "\n")), ReadContinueOnErrorOption())
+	if _, err := r.Read(); err == nil {
+		t.Fatal("expected an error for a truncated file")
+	}
+	if len(r.Errors()) == 0 {
+		t.Fatal("expected a skipped item")
+	}
+
+	r.Reset(bytes.NewReader(bs))
+	if len(r.Errors()) != 0 || r.PartialFile().Header.ImmediateOrigin != "" {
+		t.Errorf("state was not cleared: %v", r.Errors())
+	}
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !file.Equal(&want) {
+		t.Errorf("%v", file.Diff(&want))
+	}
+
+	r.Reset(strings.NewReader(lines[0]))
+	_, err = r.Read()
+	if p, ok := err.(*ParseError); !ok || p.Line != 1 || p.Record != "FileControl" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// BenchmarkReaderReset compares reading small files with a new Reader for each and with one Reader Reset
+// for each
+func BenchmarkReaderReset(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20181010121042882-A.icl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("NewReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := NewReader(bytes.NewReader(bs)).Read(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Reset", func(b *testing.B) {
+		b.ReportAllocs()
+		r := NewReader(nil)
+		src := bytes.NewReader(nil)
+		for i := 0; i < b.N; i++ {
+			src.Reset(bs)
+			r.Reset(src)
+			if _, err := r.Read(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// TestReadNormalize validates lowercase code fields are only accepted with ReadNormalizeOption, which writes
+// them in canonical form
+func TestReadNormalize(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	lower := func(line string, positions ...int) string {
+		b := []byte(line)
+		for _, pos := range positions {
+			b[pos-1] = bytes.ToLower(b[pos-1 : pos])[0]
+		}
+		return string(b)
+	}
+	// FileHeader TestFileIndicator, ResendIndicator and CountryCode
+	lines[0] = lower(lines[0], 5, 36, 74, 75)
+	// CheckDetail DocumentationTypeIndicator, ReturnAcceptanceIndicator, BOFDIndicator and ArchiveTypeIndicator
+	lines[3] = lower(lines[3], 73, 74, 76, 80)
+	input := strings.Join(lines, "\n")
+	if input[73:75] != "us" {
+		t.Fatalf("CountryCode %q", input[73:75])
+	}
+
+	if _, err := NewReader(strings.NewReader(input)).Read(); err == nil {
+		t.Error("expected an error for lowercase code fields")
+	}
+
+	file, err := NewReader(strings.NewReader(input), ReadNormalizeOption()).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if file.Header.CountryCode != "US" {
+		t.Errorf("CountryCode=%q", file.Header.CountryCode)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(buf.Bytes()), bytes.TrimSpace(bs)) {
+		t.Error("normalized file differs from the canonical file")
+	}
+}
+
+// TestParseError__ErrorsIs validates errors returned by Reader can be matched with errors.As and errors.Is
+func TestParseError__ErrorsIs(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+
+	// FileHeader without an ImmediateDestination
+	header := lines[0]
+	lines[0] = header[:5] + strings.Repeat(" ", 9) + header[14:]
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Record != "FileHeader" {
+		t.Fatalf("%T: %s", err, err)
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.FieldName != "ImmediateDestination" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if !errors.Is(err, ErrFieldInclusion) || errors.Is(err, ErrRecordType) {
+		t.Errorf("%T: %s", err, err)
+	}
+	lines[0] = header
+
+	// unknown record type
+	lines[2] = "29" + lines[2][2:]
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	var fileErr *FileError
+	if !errors.As(err, &fileErr) || fileErr.FieldName != "recordType" {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !errors.Is(err, ErrRecordType) || errors.Is(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// record type of a record which wasn't parsed
+	bh := mockBundleHeader()
+	bh.recordType = "21"
+	if err := bh.Validate(); !errors.Is(err, ErrRecordType) {
+		t.Errorf("%T: %s", err, err)
+	}
+	b := NewBundle(nil)
+	if err := b.BuildControl(); !errors.Is(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// matching follows Err rather than the message
+	err = &FieldError{FieldName: "Field", Msg: msgFieldInclusion}
+	if errors.Is(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = &FieldError{FieldName: "Field", Msg: "reworded", Err: ErrFieldInclusion}
+	if !errors.Is(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReaderReadInto validates a File read into again holds only the records of the second file read
+func TestReaderReadInto(t *testing.T) {
+	first, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20181010121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewReader(bytes.NewReader(second)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var file File
+	r := NewReader(bytes.NewReader(first))
+	if err := r.ReadInto(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(file.CashLetters) != 2 {
+		t.Fatalf("CashLetters: %d", len(file.CashLetters))
+	}
+	cashLetters := file.CashLetters[:cap(file.CashLetters)]
+
+	r.Reset(bytes.NewReader(second))
+	if err := r.ReadInto(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !file.Equal(&want) {
+		t.Errorf("%v", file.Diff(&want))
+	}
+	if &file.CashLetters[0] != &cashLetters[0] {
+		t.Error("CashLetters were not reused")
+	}
+	for i := len(file.CashLetters); i < len(cashLetters); i++ {
+		if cashLetters[i].CashLetterHeader != nil || cashLetters[i].Bundles != nil {
+			t.Errorf("CashLetters[%d] was not cleared", i)
+		}
+	}
+	for i := range file.CashLetters {
+		bundles := file.CashLetters[i].Bundles
+		for j, b := range bundles[len(bundles):cap(bundles)] {
+			if b != nil {
+				t.Errorf("CashLetters[%d].Bundles[%d] was not cleared", i, len(bundles)+j)
+			}
+		}
+		for _, b := range bundles {
+			if len(b.Returns) != 0 {
+				t.Errorf("CashLetters[%d] holds Returns of the first file", i)
+			}
+		}
+	}
+
+	// reading the first file again reuses the records of the second
+	wantFirst, err := NewReader(bytes.NewReader(first)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	r.Reset(bytes.NewReader(first))
+	if err := r.ReadInto(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !file.Equal(&wantFirst) {
+		t.Errorf("%v", file.Diff(&wantFirst))
+	}
+
+	// a File which fails to parse is reset as well
+	r.Reset(strings.NewReader("bad"))
+	if err := r.ReadInto(&file); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(file.CashLetters) != 0 || file.Header.ImmediateOrigin != "" {
+		t.Errorf("File was not reset: %d CashLetters", len(file.CashLetters))
+	}
+}
+
+// BenchmarkReaderReadInto compares reading files with Read and reading them into one reused File
+func BenchmarkReaderReadInto(b *testing.B) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Read", func(b *testing.B) {
+		b.ReportAllocs()
+		r := NewReader(nil)
+		src := bytes.NewReader(nil)
+		for i := 0; i < b.N; i++ {
+			src.Reset(bs)
+			r.Reset(src)
+			if _, err := r.Read(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ReadInto", func(b *testing.B) {
+		b.ReportAllocs()
+		r := NewReader(nil)
+		src := bytes.NewReader(nil)
+		var file File
+		for i := 0; i < b.N; i++ {
+			src.Reset(bs)
+			r.Reset(src)
+			if err := r.ReadInto(&file); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
@@ -0,0 +1,85 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+// Record types of the X9.100-187 records, found in the first two characters of each record and
+// returned by each record's RecordType method.
+const (
+	// RecordTypeFileHeader is the record type of a FileHeader
+	RecordTypeFileHeader = "01"
+	// RecordTypeCashLetterHeader is the record type of a CashLetterHeader
+	RecordTypeCashLetterHeader = "10"
+	// RecordTypeBundleHeader is the record type of a BundleHeader
+	RecordTypeBundleHeader = "20"
+	// RecordTypeCheckDetail is the record type of a CheckDetail
+	RecordTypeCheckDetail = "25"
+	// RecordTypeCheckDetailAddendumA is the record type of a CheckDetailAddendumA
+	RecordTypeCheckDetailAddendumA = "26"
+	// RecordTypeCheckDetailAddendumB is the record type of a CheckDetailAddendumB
+	RecordTypeCheckDetailAddendumB = "27"
+	// RecordTypeCheckDetailAddendumC is the record type of a CheckDetailAddendumC
+	RecordTypeCheckDetailAddendumC = "28"
+	// RecordTypeReturnDetail is the record type of a ReturnDetail
+	RecordTypeReturnDetail = "31"
+	// RecordTypeReturnDetailAddendumA is the record type of a ReturnDetailAddendumA
+	RecordTypeReturnDetailAddendumA = "32"
+	// RecordTypeReturnDetailAddendumB is the record type of a ReturnDetailAddendumB
+	RecordTypeReturnDetailAddendumB = "33"
+	// RecordTypeReturnDetailAddendumC is the record type of a ReturnDetailAddendumC
+	RecordTypeReturnDetailAddendumC = "34"
+	// RecordTypeReturnDetailAddendumD is the record type of a ReturnDetailAddendumD
+	RecordTypeReturnDetailAddendumD = "35"
+	// RecordTypeImageViewDetail is the record type of an ImageViewDetail
+	RecordTypeImageViewDetail = "50"
+	// RecordTypeImageViewData is the record type of an ImageViewData
+	RecordTypeImageViewData = "52"
+	// RecordTypeImageViewAnalysis is the record type of an ImageViewAnalysis
+	RecordTypeImageViewAnalysis = "54"
+	// RecordTypeCredit is the record type of a Credit
+	RecordTypeCredit = "61"
+	// RecordTypeCreditItem is the record type of a CreditItem
+	RecordTypeCreditItem = "62"
+	// RecordTypeUser is the record type of user records such as UserGeneral and UserPayeeEndorsement
+	RecordTypeUser = "68"
+	// RecordTypeBundleControl is the record type of a BundleControl
+	RecordTypeBundleControl = "70"
+	// RecordTypeRoutingNumberSummary is the record type of a RoutingNumberSummary
+	RecordTypeRoutingNumberSummary = "85"
+	// RecordTypeCashLetterControl is the record type of a CashLetterControl
+	RecordTypeCashLetterControl = "90"
+	// RecordTypeFileControl is the record type of a FileControl
+	RecordTypeFileControl = "99"
+)
+
+// recordLengths are the lengths of each record type. Fixed length records must be exactly their length,
+// while the variable length records, whose reference key, image or user data is preceded by its length,
+// must be at least their length.
+var recordLengths = map[string]struct {
+	length   int
+	variable bool
+}{
+	RecordTypeFileHeader:            {length: 80},
+	RecordTypeCashLetterHeader:      {length: 80},
+	RecordTypeBundleHeader:          {length: 80},
+	RecordTypeCheckDetail:           {length: 80},
+	RecordTypeCheckDetailAddendumA:  {length: 80},
+	RecordTypeCheckDetailAddendumB:  {length: 46, variable: true},
+	RecordTypeCheckDetailAddendumC:  {length: 80},
+	RecordTypeReturnDetail:          {length: 80},
+	RecordTypeReturnDetailAddendumA: {length: 80},
+	RecordTypeReturnDetailAddendumB: {length: 80},
+	RecordTypeReturnDetailAddendumC: {length: 46, variable: true},
+	RecordTypeReturnDetailAddendumD: {length: 80},
+	RecordTypeImageViewDetail:       {length: 80},
+	RecordTypeImageViewData:         {length: 117, variable: true},
+	RecordTypeImageViewAnalysis:     {length: 80},
+	RecordTypeCredit:                {length: 80},
+	RecordTypeCreditItem:            {length: 100},
+	RecordTypeUser:                  {length: 45, variable: true},
+	RecordTypeBundleControl:         {length: 80},
+	RecordTypeRoutingNumberSummary:  {length: 80},
+	RecordTypeCashLetterControl:     {length: 80},
+	RecordTypeFileControl:           {length: 80},
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"testing"
+)
+
+// TestRecordType validates each mock record returns its record type constant
+func TestRecordType(t *testing.T) {
+	fh, fc := mockFileHeader(), mockFileControl()
+	cdAddendumA, cdAddendumB, cdAddendumC := mockCheckDetailAddendumA(), mockCheckDetailAddendumB(), mockCheckDetailAddendumC()
+	rdAddendumA, rdAddendumB, rdAddendumC, rdAddendumD := mockReturnDetailAddendumA(), mockReturnDetailAddendumB(), mockReturnDetailAddendumC(), mockReturnDetailAddendumD()
+	ivDetail, ivData, ivAnalysis := mockImageViewDetail(), mockImageViewData(), mockImageViewAnalysis()
+
+	tests := []struct {
+		record interface{ RecordType() string }
+		want   string
+	}{
+		{&fh, RecordTypeFileHeader},
+		{mockCashLetterHeader(), RecordTypeCashLetterHeader},
+		{mockBundleHeader(), RecordTypeBundleHeader},
+		{mockCheckDetail(), RecordTypeCheckDetail},
+		{&cdAddendumA, RecordTypeCheckDetailAddendumA},
+		{&cdAddendumB, RecordTypeCheckDetailAddendumB},
+		{&cdAddendumC, RecordTypeCheckDetailAddendumC},
+		{mockReturnDetail(), RecordTypeReturnDetail},
+		{&rdAddendumA, RecordTypeReturnDetailAddendumA},
+		{&rdAddendumB, RecordTypeReturnDetailAddendumB},
+		{&rdAddendumC, RecordTypeReturnDetailAddendumC},
+		{&rdAddendumD, RecordTypeReturnDetailAddendumD},
+		{&ivDetail, RecordTypeImageViewDetail},
+		{&ivData, RecordTypeImageViewData},
+		{&ivAnalysis, RecordTypeImageViewAnalysis},
+		{mockCredit(), RecordTypeCredit},
+		{mockCreditItem(), RecordTypeCreditItem},
+		{mockUserGeneral(), RecordTypeUser},
+		{mockUserPayeeEndorsement(), RecordTypeUser},
+		{mockBundleControl(), RecordTypeBundleControl},
+		{mockRoutingNumberSummary(), RecordTypeRoutingNumberSummary},
+		{mockCashLetterControl(), RecordTypeCashLetterControl},
+		{&fc, RecordTypeFileControl},
+	}
+	for _, test := range tests {
+		if v := test.record.RecordType(); v != test.want {
+			t.Errorf("%T: expected %s got %s", test.record, test.want, v)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Repair describes a control record field changed by File.RepairControls
+type Repair struct {
+	// Path locates the field in the File, such as CashLetters[0].Bundles[1].BundleControl.BundleItemsCount
+	Path string `json:"path"`
+	// Old is the value of the field before it was repaired, which is blank when the control record was missing
+	Old string `json:"old"`
+	// New is the value the field was repaired to
+	New string `json:"new"`
+}
+
+// repairs collects the Repairs made by RepairControls
+type repairs []Repair
+
+// compare adds a Repair of the field at path when its value changed from old to new or the control
+// record holding it was missing
+func (r *repairs) compare(path string, old, new int, missing bool) {
+	if old == new && !missing {
+		return
+	}
+	repair := Repair{Path: path, New: strconv.Itoa(new)}
+	if !missing {
+		repair.Old = strconv.Itoa(old)
+	}
+	*r = append(*r, repair)
+}
+
+// RepairControls recalculates the counts, amounts and credit indicators of every BundleControl,
+// CashLetterControl and FileControl from the records they summarize, and returns a Repair for each field
+// it changed. Unlike Recalculate the items are not renumbered and the other control record fields are kept,
+// so a File which already balances is left unchanged. A missing BundleControl or CashLetterControl is
+// created, and each of its recalculated fields is reported with a blank Old value.
+//
+// RepairControls stops at the first control record which can not be built, returning the error along
+// with the Repairs already made.
+func (f *File) RepairControls() ([]Repair, error) {
+	if f == nil {
+		return nil, ErrNilFile
+	}
+	var r repairs
+	for i := range f.CashLetters {
+		if err := f.CashLetters[i].repairControls(fmt.Sprintf("CashLetters[%d]", i), &r); err != nil {
+			return r, err
+		}
+	}
+
+	fc := f.Control
+	fc.CashLetterCount = len(f.CashLetters)
+	fc.FileTotalAmount = 0
+	fc.CreditTotalIndicator = 0
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		fc.FileTotalAmount = fc.FileTotalAmount + int(cl.TotalAmount())
+		if len(cl.allCreditItems())+len(cl.allCredits()) > 0 {
+			fc.CreditTotalIndicator = 1
+		}
+	}
+	fc.TotalRecordCount, fc.TotalItemCount = f.controlCounts(fc.CreditTotalIndicator)
+	if err := fc.Validate(); err != nil {
+		return r, err
+	}
+	old := f.Control
+	r.compare("Control.CashLetterCount", old.CashLetterCount, fc.CashLetterCount, false)
+	r.compare("Control.TotalRecordCount", old.TotalRecordCount, fc.TotalRecordCount, false)
+	r.compare("Control.TotalItemCount", old.TotalItemCount, fc.TotalItemCount, false)
+	r.compare("Control.FileTotalAmount", old.FileTotalAmount, fc.FileTotalAmount, false)
+	r.compare("Control.CreditTotalIndicator", old.CreditTotalIndicator, fc.CreditTotalIndicator, false)
+	f.Control = fc
+	return r, nil
+}
+
+// repairControls rebuilds the BundleControl of each Bundle and the CashLetterControl, adding a Repair to r
+// for each field changed. path locates the CashLetter in the File.
+func (cl *CashLetter) repairControls(path string, r *repairs) error {
+	for j, b := range cl.Bundles {
+		if b == nil {
+			continue
+		}
+		old, missing := b.BundleControl, b.BundleControl == nil
+		if missing {
+			old = &BundleControl{}
+		}
+		if err := b.BuildControl(); err != nil {
+			return err
+		}
+		bc := b.BundleControl
+		bcPath := fmt.Sprintf("%s.Bundles[%d].BundleControl.", path, j)
+		r.compare(bcPath+"BundleItemsCount", old.BundleItemsCount, bc.BundleItemsCount, missing)
+		r.compare(bcPath+"BundleTotalAmount", old.BundleTotalAmount, bc.BundleTotalAmount, missing)
+		r.compare(bcPath+"MICRValidTotalAmount", old.MICRValidTotalAmount, bc.MICRValidTotalAmount, missing)
+		r.compare(bcPath+"BundleImagesCount", old.BundleImagesCount, bc.BundleImagesCount, missing)
+	}
+
+	old, missing := cl.CashLetterControl, cl.CashLetterControl == nil
+	if missing {
+		old = &CashLetterControl{}
+	}
+	if err := cl.BuildControl(); err != nil {
+		return err
+	}
+	clc := cl.CashLetterControl
+	clcPath := path + ".CashLetterControl."
+	r.compare(clcPath+"CashLetterBundleCount", old.CashLetterBundleCount, clc.CashLetterBundleCount, missing)
+	r.compare(clcPath+"CashLetterItemsCount", old.CashLetterItemsCount, clc.CashLetterItemsCount, missing)
+	r.compare(clcPath+"CashLetterTotalAmount", old.CashLetterTotalAmount, clc.CashLetterTotalAmount, missing)
+	r.compare(clcPath+"CashLetterImagesCount", old.CashLetterImagesCount, clc.CashLetterImagesCount, missing)
+	r.compare(clcPath+"CreditTotalIndicator", old.CreditTotalIndicator, clc.CreditTotalIndicator, missing)
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestRepairControls repairs deliberately wrong control records and validates the Repairs reported
+func TestRepairControls(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	repairs, err := file.RepairControls()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(repairs) != 0 {
+		t.Errorf("balanced file repaired: %v", repairs)
+	}
+
+	bc := file.CashLetters[0].Bundles[1].BundleControl
+	itemsCount := bc.BundleItemsCount
+	bc.BundleItemsCount = itemsCount + 3
+	clc := file.CashLetters[1].CashLetterControl
+	totalAmount := clc.CashLetterTotalAmount
+	clc.CashLetterTotalAmount = 1
+	recordCount := file.Control.TotalRecordCount
+	file.Control.TotalRecordCount = 5
+	file.Control.ImmediateOriginContactName = "Contact"
+
+	repairs, err = file.RepairControls()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	expected := []Repair{
+		{Path: "CashLetters[0].Bundles[1].BundleControl.BundleItemsCount", Old: strconv.Itoa(itemsCount + 3), New: strconv.Itoa(itemsCount)},
+		{Path: "CashLetters[1].CashLetterControl.CashLetterTotalAmount", Old: "1", New: strconv.Itoa(totalAmount)},
+		{Path: "Control.TotalRecordCount", Old: "5", New: strconv.Itoa(recordCount)},
+	}
+	if !reflect.DeepEqual(repairs, expected) {
+		t.Errorf("expected %v got %v", expected, repairs)
+	}
+	if v := file.CashLetters[0].Bundles[1].BundleControl.BundleItemsCount; v != itemsCount {
+		t.Errorf("BundleItemsCount %d", v)
+	}
+	if v := file.CashLetters[1].CashLetterControl.CashLetterTotalAmount; v != totalAmount {
+		t.Errorf("CashLetterTotalAmount %d", v)
+	}
+	if v := file.Control.TotalRecordCount; v != recordCount {
+		t.Errorf("TotalRecordCount %d", v)
+	}
+	// other control record fields are kept
+	if file.Control.ImmediateOriginContactName != "Contact" {
+		t.Errorf("ImmediateOriginContactName %q", file.Control.ImmediateOriginContactName)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestRepairControls__Missing validates a missing BundleControl is created and each of its fields reported
+func TestRepairControls__Missing(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	b := file.CashLetters[0].Bundles[0]
+	expected := *b.BundleControl
+	b.BundleControl = nil
+
+	repairs, err := file.RepairControls()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(repairs) != 4 {
+		t.Fatalf("expected 4 repairs got %v", repairs)
+	}
+	for _, r := range repairs {
+		if r.Old != "" {
+			t.Errorf("%s: Old %q", r.Path, r.Old)
+		}
+	}
+	if repairs[0].Path != "CashLetters[0].Bundles[0].BundleControl.BundleItemsCount" || repairs[0].New != strconv.Itoa(expected.BundleItemsCount) {
+		t.Errorf("%v", repairs[0])
+	}
+	if b.BundleControl == nil || b.BundleControl.BundleTotalAmount != expected.BundleTotalAmount {
+		t.Errorf("BundleControl %v", b.BundleControl)
+	}
+}
+
+// TestRepairControls__Error validates an empty Bundle stops the repair
+func TestRepairControls__Error(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	file.CashLetters[1].Bundles[0].Checks = nil
+	file.CashLetters[1].Bundles[0].Returns = nil
+	_, err := file.RepairControls()
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "entries" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var f *File
+	if _, err := f.RepairControls(); err != ErrNilFile {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -18,6 +17,8 @@ var (
 	msgReturnCode = "is invalid"
 )
 
+// CustomerReturnCodeDict and AdministrativeReturnCodeDict hold the ReturnReason codes accepted by Validate.
+// They are read concurrently by validation and must not be changed while files are validated.
 var (
 	CustomerReturnCodeDict       = map[string]*CustomerReturnCode{}
 	AdministrativeReturnCodeDict = map[string]*AdministrativeReturnCode{}
@@ -142,6 +143,8 @@ type ReturnDetail struct {
 	ImageViewData []ImageViewData `json:"imageViewData"`
 	// ImageViewAnalysis
 	ImageViewAnalysis []ImageViewAnalysis `json:"imageViewAnalysis"`
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -171,7 +174,12 @@ func (rd *ReturnDetail) setRecordType() {
 	if rd == nil {
 		return
 	}
-	rd.recordType = "31"
+	rd.recordType = RecordTypeReturnDetail
+}
+
+// RecordType returns the record type of the ReturnDetail, which is RecordTypeReturnDetail once set by NewReturnDetail or Parse
+func (rd *ReturnDetail) RecordType() string {
+	return rd.recordType
 }
 
 // Parse takes the input record string and parses the ReturnDetail values
@@ -189,7 +197,8 @@ func (rd *ReturnDetail) Parse(record string) {
 	// 12-31
 	rd.OnUs = rd.parseStringField(record[11:31])
 	// 32-41
-	rd.ItemAmount = rd.parseNumField(record[31:41])
+	itemAmount, _ := rd.parseAmount(record[31:41])
+	rd.ItemAmount = int(itemAmount)
 	// 42-42
 	rd.ReturnReason = rd.parseStringField(record[41:42])
 	// 43-44
@@ -209,29 +218,51 @@ func (rd *ReturnDetail) Parse(record string) {
 	// 72-72
 	rd.TimesReturned = rd.parseNumField(record[71:72])
 	// 73-80
-	rd.reserved = "        "
+	rd.reserved = rd.parseReservedField(record, 72, 80)
+}
+
+// parseLayout takes the input record string and parses the ReturnDetail values in the layout of the
+// StandardLevel level
+func (rd *ReturnDetail) parseLayout(record, level string) {
+	rd.Parse(record)
+	if level == StandardLevelDSTU2003 {
+		// 72-72 is reserved
+		rd.TimesReturned = 0
+	}
+}
+
+// layoutString writes the ReturnDetail struct to a string in the layout of the StandardLevel level
+func (rd *ReturnDetail) layoutString(level string) string {
+	record := rd.String()
+	if level == StandardLevelDSTU2003 && len(record) == 80 {
+		return record[:71] + " " + record[72:]
+	}
+	return record
 }
 
 // String writes the ReturnDetail struct to a variable length string.
 func (rd *ReturnDetail) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(rd.recordType)
-	buf.WriteString(rd.PayorBankRoutingNumberField())
-	buf.WriteString(rd.PayorBankCheckDigitField())
-	buf.WriteString(rd.OnUsField())
-	buf.WriteString(rd.ItemAmountField())
-	buf.WriteString(rd.ReturnReasonField())
-	buf.WriteString(rd.AddendumCountField())
-	buf.WriteString(rd.DocumentationTypeIndicatorField())
-	buf.WriteString(rd.ForwardBundleDateField())
-	buf.WriteString(rd.EceInstitutionItemSequenceNumberField())
-	buf.WriteString(rd.ExternalProcessingCodeField())
-	buf.WriteString(rd.ReturnNotificationIndicatorField())
-	buf.WriteString(rd.ArchiveTypeIndicatorField())
-	buf.WriteString(rd.TimesReturnedField())
-	buf.WriteString(rd.reservedField())
-	return buf.String()
+	return string(rd.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ReturnDetail record, as String returns it, to b and returns the extended buffer.
+func (rd *ReturnDetail) AppendTo(b []byte) []byte {
+	b = append(b, rd.recordType...)
+	b = rd.appendStringField(b, rd.PayorBankRoutingNumber, 8)
+	b = rd.appendStringField(b, rd.PayorBankCheckDigit, 1)
+	b = rd.appendNBSMField(b, rd.OnUs, 20)
+	b = rd.appendAmount(b, int64(rd.ItemAmount), 10)
+	b = rd.appendAlphaField(b, rd.ReturnReason, 1)
+	b = rd.appendNumericField(b, rd.AddendumCount, 2)
+	b = rd.appendAlphaField(b, rd.DocumentationTypeIndicator, 1)
+	b = rd.appendYYYYMMDDDate(b, rd.ForwardBundleDate)
+	b = rd.appendAlphaField(b, rd.EceInstitutionItemSequenceNumber, 15)
+	b = rd.appendAlphaField(b, rd.ExternalProcessingCode, 1)
+	b = rd.appendNumericField(b, rd.ReturnNotificationIndicator, 1)
+	b = rd.appendAlphaField(b, rd.ArchiveTypeIndicator, 1)
+	b = rd.appendNumericField(b, rd.TimesReturned, 1)
+	b = rd.appendAlphaField(b, rd.reserved, 8)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -240,9 +271,9 @@ func (rd *ReturnDetail) Validate() error {
 	if err := rd.fieldInclusion(); err != nil {
 		return err
 	}
-	if rd.recordType != "31" {
+	if rd.recordType != RecordTypeReturnDetail {
 		msg := fmt.Sprintf(msgRecordType, 31)
-		return &FieldError{FieldName: "recordType", Value: rd.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rd.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if rd.DocumentationTypeIndicator != "" {
 		// Z is valid for CashLetter DocumentationTypeIndicator only
@@ -270,49 +301,53 @@ func (rd *ReturnDetail) Validate() error {
 		}
 	}
 
-	_, crc := CustomerReturnCodeDict[rd.ReturnReason]
-
-	_, arc := AdministrativeReturnCodeDict[rd.ReturnReason]
-	if !crc && !arc {
-		// Return msgReturnCode
-		msg := fmt.Sprint(msgReturnCode)
-		return &FieldError{FieldName: "ReturnReason", Value: rd.ReturnReason, Msg: msg}
+	if err := rd.isReturnReason(rd.ReturnReason); err != nil {
+		return &FieldError{FieldName: "ReturnReason", Value: rd.ReturnReason, Msg: err.Error()}
+	}
+	if err := rd.isDateInRange(rd.ForwardBundleDate); err != nil {
+		return &FieldError{FieldName: "ForwardBundleDate", Value: rd.ForwardBundleDateField(), Msg: err.Error()}
 	}
 	return nil
 }
 
+// validateImageViewCounts checks that each ImageViewDetail of the ReturnDetail has one ImageViewData
+// and at most one ImageViewAnalysis.
+func (rd *ReturnDetail) validateImageViewCounts() error {
+	return validateImageViewCounts(len(rd.ImageViewDetail), len(rd.ImageViewData), len(rd.ImageViewAnalysis))
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the Electronic Exchange will be returned.
 func (rd *ReturnDetail) fieldInclusion() error {
 	if rd.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rd.recordType,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
 	if rd.PayorBankRoutingNumber == "" {
 		return &FieldError{FieldName: "PayorBankRoutingNumber",
 			Value: rd.PayorBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
-	if rd.PayorBankRoutingNumberField() == "00000000" {
+	if rd.isZeroRoutingNumber(rd.PayorBankRoutingNumber) {
 		return &FieldError{FieldName: "PayorBankRoutingNumber",
 			Value: rd.PayorBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
 	if rd.PayorBankCheckDigit == "" {
 		return &FieldError{FieldName: "PayorBankCheckDigit",
 			Value: rd.PayorBankCheckDigit,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
 	if rd.ReturnReason == "" {
 		return &FieldError{FieldName: "ReturnReason",
 			Value: rd.ReturnReason,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
 	if rd.EceInstitutionItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "EceInstitutionItemSequenceNumber",
 			Value: rd.EceInstitutionItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetail()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -334,7 +369,23 @@ func (rd *ReturnDetail) OnUsField() string {
 
 // ItemAmountField gets the ItemAmount right justified and zero padded
 func (rd *ReturnDetail) ItemAmountField() string {
-	return rd.numericField(rd.ItemAmount, 10)
+	return rd.formatAmount(int64(rd.ItemAmount), 10)
+}
+
+// SetItemAmount sets ItemAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 10 digit field.
+func (rd *ReturnDetail) SetItemAmount(cents int64) error {
+	amount, err := rd.amountFromCents("ItemAmount", cents, 10)
+	if err != nil {
+		return err
+	}
+	rd.ItemAmount = amount
+	return nil
+}
+
+// ItemAmountCents returns ItemAmount in cents
+func (rd *ReturnDetail) ItemAmountCents() int64 {
+	return int64(rd.ItemAmount)
 }
 
 // ReturnReasonField gets the ReturnReason field
@@ -387,10 +438,20 @@ func (rd *ReturnDetail) reservedField() string {
 	return rd.alphaField(rd.reserved, 8)
 }
 
-// AddReturnDetailAddendumA appends an AddendumA to the ReturnDetail
-func (rd *ReturnDetail) AddReturnDetailAddendumA(rdAddendaA ReturnDetailAddendumA) []ReturnDetailAddendumA {
+// AddReturnDetailAddendumA appends an AddendumA to the ReturnDetail. An AddendumA with a RecordNumber of 0 is given the
+// number following the highest RecordNumber of the ReturnDetail's AddendumA records, and one with a RecordNumber
+// which is set keeps it. A FieldError is returned, and the AddendumA isn't added, if its RecordNumber is
+// already used.
+func (rd *ReturnDetail) AddReturnDetailAddendumA(rdAddendaA ReturnDetailAddendumA) error {
+	n, err := addendumRecordNumber(rdAddendaA.RecordNumber, rdAddendaA.RecordNumberField(), len(rd.ReturnDetailAddendumA), func(i int) int {
+		return rd.ReturnDetailAddendumA[i].RecordNumber
+	})
+	if err != nil {
+		return err
+	}
+	rdAddendaA.RecordNumber = n
 	rd.ReturnDetailAddendumA = append(rd.ReturnDetailAddendumA, rdAddendaA)
-	return rd.ReturnDetailAddendumA
+	return nil
 }
 
 // GetReturnDetailAddendumA returns a slice of AddendumA for the ReturnDetail
@@ -420,10 +481,20 @@ func (rd *ReturnDetail) GetReturnDetailAddendumC() []ReturnDetailAddendumC {
 	return rd.ReturnDetailAddendumC
 }
 
-// AddReturnDetailAddendumD appends an AddendumD to the ReturnDetail
-func (rd *ReturnDetail) AddReturnDetailAddendumD(rdAddendaD ReturnDetailAddendumD) []ReturnDetailAddendumD {
+// AddReturnDetailAddendumD appends an AddendumD to the ReturnDetail. An AddendumD with a RecordNumber of 0 is given the
+// number following the highest RecordNumber of the ReturnDetail's AddendumD records, and one with a RecordNumber
+// which is set keeps it. A FieldError is returned, and the AddendumD isn't added, if its RecordNumber is
+// already used.
+func (rd *ReturnDetail) AddReturnDetailAddendumD(rdAddendaD ReturnDetailAddendumD) error {
+	n, err := addendumRecordNumber(rdAddendaD.RecordNumber, rdAddendaD.RecordNumberField(), len(rd.ReturnDetailAddendumD), func(i int) int {
+		return rd.ReturnDetailAddendumD[i].RecordNumber
+	})
+	if err != nil {
+		return err
+	}
+	rdAddendaD.RecordNumber = n
 	rd.ReturnDetailAddendumD = append(rd.ReturnDetailAddendumD, rdAddendaD)
-	return rd.ReturnDetailAddendumD
+	return nil
 }
 
 // GetReturnDetailAddendumD returns a slice of AddendumD for the ReturnDetail
@@ -542,3 +613,57 @@ func makeAdministrativeReturnCodeDict() map[string]*AdministrativeReturnCode {
 	}
 	return dict
 }
+
+// ReturnReasonDescription returns the description of a return reason code from CustomerReturnCodeDict,
+// or AdministrativeReturnCodeDict for the administrative only codes, and false if code is unknown.
+func ReturnReasonDescription(code string) (string, bool) {
+	if crc, ok := CustomerReturnCodeDict[code]; ok {
+		return crc.Description, true
+	}
+	if arc, ok := AdministrativeReturnCodeDict[code]; ok {
+		return arc.Description, true
+	}
+	return "", false
+}
+
+// ReturnReasonDescription returns the description of the ReturnDetail ReturnReason
+func (rd *ReturnDetail) ReturnReasonDescription() (string, bool) {
+	return ReturnReasonDescription(rd.ReturnReason)
+}
+
+// renumber sets the ReturnDetail sequence number and the addendum and image sequence numbers which mirror it
+func (rd *ReturnDetail) renumber(seq int) {
+	old := normalizeSequenceNumber(rd.EceInstitutionItemSequenceNumber)
+	rd.SetEceInstitutionItemSequenceNumber(seq)
+	for i := range rd.ReturnDetailAddendumA {
+		addendumA := &rd.ReturnDetailAddendumA[i]
+		if normalizeSequenceNumber(addendumA.BOFDItemSequenceNumber) == old {
+			addendumA.SetBOFDItemSequenceNumber(seq)
+		}
+	}
+	for i := range rd.ReturnDetailAddendumD {
+		addendumD := &rd.ReturnDetailAddendumD[i]
+		if normalizeSequenceNumber(addendumD.EndorsingBankItemSequenceNumber) == old {
+			addendumD.SetEndorsingBankItemSequenceNumber(seq)
+		}
+	}
+	for i := range rd.ImageViewData {
+		ivData := &rd.ImageViewData[i]
+		if normalizeSequenceNumber(ivData.EceInstitutionItemSequenceNumber) == old {
+			ivData.EceInstitutionItemSequenceNumber = rd.EceInstitutionItemSequenceNumber
+		}
+	}
+}
+
+// copy returns a copy of the ReturnDetail which shares no records or ImageData with it
+func (rd *ReturnDetail) copy() *ReturnDetail {
+	c := *rd
+	c.ReturnDetailAddendumA = append([]ReturnDetailAddendumA(nil), rd.ReturnDetailAddendumA...)
+	c.ReturnDetailAddendumB = append([]ReturnDetailAddendumB(nil), rd.ReturnDetailAddendumB...)
+	c.ReturnDetailAddendumC = append([]ReturnDetailAddendumC(nil), rd.ReturnDetailAddendumC...)
+	c.ReturnDetailAddendumD = append([]ReturnDetailAddendumD(nil), rd.ReturnDetailAddendumD...)
+	c.ImageViewDetail = append([]ImageViewDetail(nil), rd.ImageViewDetail...)
+	c.ImageViewData = copyImageViewData(rd.ImageViewData)
+	c.ImageViewAnalysis = append([]ImageViewAnalysis(nil), rd.ImageViewAnalysis...)
+	return &c
+}
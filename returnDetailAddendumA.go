@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -81,6 +80,8 @@ type ReturnDetailAddendumA struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -98,7 +99,12 @@ func (rdAddendumA *ReturnDetailAddendumA) setRecordType() {
 	if rdAddendumA == nil {
 		return
 	}
-	rdAddendumA.recordType = "32"
+	rdAddendumA.recordType = RecordTypeReturnDetailAddendumA
+}
+
+// RecordType returns the record type of the ReturnDetailAddendumA, which is RecordTypeReturnDetailAddendumA once set by NewReturnDetailAddendumA or Parse
+func (rdAddendumA *ReturnDetailAddendumA) RecordType() string {
+	return rdAddendumA.recordType
 }
 
 // Parse takes the input record string and parses the ReturnDetailAddendumA values
@@ -132,28 +138,31 @@ func (rdAddendumA *ReturnDetailAddendumA) Parse(record string) {
 	// 77-77
 	rdAddendumA.UserField = rdAddendumA.parseStringField(record[76:77])
 	// 78-80
-	rdAddendumA.reserved = "   "
+	rdAddendumA.reserved = rdAddendumA.parseReservedField(record, 77, 80)
 
 }
 
 // String writes the ReturnDetailAddendumA struct to a string.
 func (rdAddendumA *ReturnDetailAddendumA) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(rdAddendumA.recordType)
-	buf.WriteString(rdAddendumA.RecordNumberField())
-	buf.WriteString(rdAddendumA.ReturnLocationRoutingNumberField())
-	buf.WriteString(rdAddendumA.BOFDEndorsementDateField())
-	buf.WriteString(rdAddendumA.BOFDItemSequenceNumberField())
-	buf.WriteString(rdAddendumA.BOFDAccountNumberField())
-	buf.WriteString(rdAddendumA.BOFDBranchCodeField())
-	buf.WriteString(rdAddendumA.PayeeNameField())
-	buf.WriteString(rdAddendumA.TruncationIndicatorField())
-	buf.WriteString(rdAddendumA.BOFDConversionIndicatorField())
-	buf.WriteString(rdAddendumA.BOFDCorrectionIndicatorField())
-	buf.WriteString(rdAddendumA.UserFieldField())
-	buf.WriteString(rdAddendumA.reservedField())
-	return buf.String()
+	return string(rdAddendumA.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ReturnDetailAddendumA record, as String returns it, to b and returns the extended buffer.
+func (rdAddendumA *ReturnDetailAddendumA) AppendTo(b []byte) []byte {
+	b = append(b, rdAddendumA.recordType...)
+	b = rdAddendumA.appendNumericField(b, rdAddendumA.RecordNumber, 1)
+	b = rdAddendumA.appendStringField(b, rdAddendumA.ReturnLocationRoutingNumber, 9)
+	b = rdAddendumA.appendYYYYMMDDDate(b, rdAddendumA.BOFDEndorsementDate)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.BOFDItemSequenceNumber, 15)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.BOFDAccountNumber, 18)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.BOFDBranchCode, 5)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.PayeeName, 15)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.TruncationIndicator, 1)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.BOFDConversionIndicator, 1)
+	b = rdAddendumA.appendNumericField(b, rdAddendumA.BOFDCorrectionIndicator, 1)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.UserField, 1)
+	b = rdAddendumA.appendAlphaField(b, rdAddendumA.reserved, 3)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -162,9 +171,9 @@ func (rdAddendumA *ReturnDetailAddendumA) Validate() error {
 	if err := rdAddendumA.fieldInclusion(); err != nil {
 		return err
 	}
-	if rdAddendumA.recordType != "32" {
+	if rdAddendumA.recordType != RecordTypeReturnDetailAddendumA {
 		msg := fmt.Sprintf(msgRecordType, 32)
-		return &FieldError{FieldName: "recordType", Value: rdAddendumA.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rdAddendumA.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := rdAddendumA.isNumeric(rdAddendumA.ReturnLocationRoutingNumber); err != nil {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
@@ -204,6 +213,9 @@ func (rdAddendumA *ReturnDetailAddendumA) Validate() error {
 	if err := rdAddendumA.isAlphanumericSpecial(rdAddendumA.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: rdAddendumA.UserField, Msg: err.Error()}
 	}
+	if err := rdAddendumA.isDateInRange(rdAddendumA.BOFDEndorsementDate); err != nil {
+		return &FieldError{FieldName: "BOFDEndorsementDate", Value: rdAddendumA.BOFDEndorsementDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -213,37 +225,37 @@ func (rdAddendumA *ReturnDetailAddendumA) fieldInclusion() error {
 	if rdAddendumA.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rdAddendumA.recordType,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumA.RecordNumber == 0 {
 		return &FieldError{FieldName: "RecordNumber",
 			Value: rdAddendumA.RecordNumberField(),
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumA.ReturnLocationRoutingNumber == "" {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
 			Value: rdAddendumA.ReturnLocationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
-	if rdAddendumA.ReturnLocationRoutingNumberField() == "000000000" {
+	if rdAddendumA.isZeroRoutingNumber(rdAddendumA.ReturnLocationRoutingNumber) {
 		return &FieldError{FieldName: "ReturnLocationRoutingNumber",
 			Value: rdAddendumA.ReturnLocationRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumA.BOFDItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "BOFDItemSequenceNumber",
 			Value: rdAddendumA.BOFDItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumA.BOFDEndorsementDate.IsZero() {
 		return &FieldError{FieldName: "BOFDEndorsementDate",
 			Value: rdAddendumA.BOFDEndorsementDate.String(),
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumA.TruncationIndicator == "" {
 		return &FieldError{FieldName: "TruncationIndicator",
 			Value: rdAddendumA.TruncationIndicator,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumA()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -34,6 +33,8 @@ type ReturnDetailAddendumB struct {
 	PayorBankBusinessDate time.Time `json:"payorBankBusinessDate"`
 	// PayorAccountName is the account name from payor bank records.
 	PayorAccountName string `json:"payorAccountName"`
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -51,7 +52,12 @@ func (rdAddendumB *ReturnDetailAddendumB) setRecordType() {
 	if rdAddendumB == nil {
 		return
 	}
-	rdAddendumB.recordType = "33"
+	rdAddendumB.recordType = RecordTypeReturnDetailAddendumB
+}
+
+// RecordType returns the record type of the ReturnDetailAddendumB, which is RecordTypeReturnDetailAddendumB once set by NewReturnDetailAddendumB or Parse
+func (rdAddendumB *ReturnDetailAddendumB) RecordType() string {
+	return rdAddendumB.recordType
 }
 
 // Parse takes the input record string and parses the ReturnDetailAddendumB values
@@ -76,15 +82,18 @@ func (rdAddendumB *ReturnDetailAddendumB) Parse(record string) {
 
 // String writes the ReturnDetailAddendumB struct to a string.
 func (rdAddendumB *ReturnDetailAddendumB) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(rdAddendumB.recordType)
-	buf.WriteString(rdAddendumB.PayorBankNameField())
-	buf.WriteString(rdAddendumB.AuxiliaryOnUsField())
-	buf.WriteString(rdAddendumB.PayorBankSequenceNumberField())
-	buf.WriteString(rdAddendumB.PayorBankBusinessDateField())
-	buf.WriteString(rdAddendumB.PayorAccountNameField())
-	return buf.String()
+	return string(rdAddendumB.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ReturnDetailAddendumB record, as String returns it, to b and returns the extended buffer.
+func (rdAddendumB *ReturnDetailAddendumB) AppendTo(b []byte) []byte {
+	b = append(b, rdAddendumB.recordType...)
+	b = rdAddendumB.appendAlphaField(b, rdAddendumB.PayorBankName, 18)
+	b = rdAddendumB.appendNBSMField(b, rdAddendumB.AuxiliaryOnUs, 15)
+	b = rdAddendumB.appendAlphaField(b, rdAddendumB.PayorBankSequenceNumber, 15)
+	b = rdAddendumB.appendYYYYMMDDDate(b, rdAddendumB.PayorBankBusinessDate)
+	b = rdAddendumB.appendAlphaField(b, rdAddendumB.PayorAccountName, 22)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -93,9 +102,9 @@ func (rdAddendumB *ReturnDetailAddendumB) Validate() error {
 	if err := rdAddendumB.fieldInclusion(); err != nil {
 		return err
 	}
-	if rdAddendumB.recordType != "33" {
+	if rdAddendumB.recordType != RecordTypeReturnDetailAddendumB {
 		msg := fmt.Sprintf(msgRecordType, 33)
-		return &FieldError{FieldName: "recordType", Value: rdAddendumB.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rdAddendumB.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := rdAddendumB.isAlphanumericSpecial(rdAddendumB.PayorBankName); err != nil {
 		return &FieldError{FieldName: "PayorBankName", Value: rdAddendumB.PayorBankName, Msg: err.Error()}
@@ -103,6 +112,9 @@ func (rdAddendumB *ReturnDetailAddendumB) Validate() error {
 	if err := rdAddendumB.isAlphanumericSpecial(rdAddendumB.PayorAccountName); err != nil {
 		return &FieldError{FieldName: "PayorAccountName", Value: rdAddendumB.PayorAccountName, Msg: err.Error()}
 	}
+	if err := rdAddendumB.isDateInRange(rdAddendumB.PayorBankBusinessDate); err != nil {
+		return &FieldError{FieldName: "PayorBankBusinessDate", Value: rdAddendumB.PayorBankBusinessDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -112,17 +124,17 @@ func (rdAddendumB *ReturnDetailAddendumB) fieldInclusion() error {
 	if rdAddendumB.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rdAddendumB.recordType,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumB.PayorBankSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "PayorBankSequenceNumber",
 			Value: rdAddendumB.PayorBankSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumB.PayorBankBusinessDate.IsZero() {
 		return &FieldError{FieldName: "PayorBankBusinessDate",
 			Value: rdAddendumB.PayorBankBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumB()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
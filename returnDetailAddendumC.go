@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -46,6 +45,8 @@ type ReturnDetailAddendumC struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -63,7 +64,12 @@ func (rdAddendumC *ReturnDetailAddendumC) setRecordType() {
 	if rdAddendumC == nil {
 		return
 	}
-	rdAddendumC.recordType = "34"
+	rdAddendumC.recordType = RecordTypeReturnDetailAddendumC
+}
+
+// RecordType returns the record type of the ReturnDetailAddendumC, which is RecordTypeReturnDetailAddendumC once set by NewReturnDetailAddendumC or Parse
+func (rdAddendumC *ReturnDetailAddendumC) RecordType() string {
+	return rdAddendumC.recordType
 }
 
 // Parse takes the input record string and parses the ReturnDetailAddendumC values
@@ -93,24 +99,27 @@ func (rdAddendumC *ReturnDetailAddendumC) Parse(record string) {
 	// 38+X - 41+X
 	rdAddendumC.UserField = rdAddendumC.parseStringField(record[37+imageRefKeyLength : 41+imageRefKeyLength])
 	// 42+X - 46+X
-	rdAddendumC.reserved = rdAddendumC.parseStringField(record[41+imageRefKeyLength : 46+imageRefKeyLength])
+	rdAddendumC.reserved = rdAddendumC.parseReservedField(record, 41+imageRefKeyLength, 46+imageRefKeyLength)
 
 }
 
 // String writes the ReturnDetailAddendumC struct to a string.
 func (rdAddendumC *ReturnDetailAddendumC) String() string {
-	var buf strings.Builder
-	buf.Grow(22)
-	buf.WriteString(rdAddendumC.recordType)
-	buf.WriteString(rdAddendumC.ImageReferenceKeyIndicatorField())
-	buf.WriteString(rdAddendumC.MicrofilmArchiveSequenceNumberField())
-	buf.WriteString(rdAddendumC.LengthImageReferenceKeyField())
-	buf.Grow(rdAddendumC.parseNumField(rdAddendumC.LengthImageReferenceKey))
-	buf.WriteString(rdAddendumC.ImageReferenceKeyField())
-	buf.WriteString(rdAddendumC.DescriptionField())
-	buf.WriteString(rdAddendumC.UserFieldField())
-	buf.WriteString(rdAddendumC.reservedField())
-	return buf.String()
+	size := 22 + rdAddendumC.parseNumField(rdAddendumC.LengthImageReferenceKey)
+	return string(rdAddendumC.AppendTo(make([]byte, 0, size)))
+}
+
+// AppendTo appends the ReturnDetailAddendumC record, as String returns it, to b and returns the extended buffer.
+func (rdAddendumC *ReturnDetailAddendumC) AppendTo(b []byte) []byte {
+	b = append(b, rdAddendumC.recordType...)
+	b = rdAddendumC.appendNumericField(b, rdAddendumC.ImageReferenceKeyIndicator, 1)
+	b = rdAddendumC.appendAlphaField(b, rdAddendumC.MicrofilmArchiveSequenceNumber, 15)
+	b = rdAddendumC.appendStringField(b, rdAddendumC.LengthImageReferenceKey, 4)
+	b = rdAddendumC.appendAlphaField(b, rdAddendumC.ImageReferenceKey, uint(rdAddendumC.parseNumField(rdAddendumC.LengthImageReferenceKey)))
+	b = rdAddendumC.appendAlphaField(b, rdAddendumC.Description, 15)
+	b = rdAddendumC.appendAlphaField(b, rdAddendumC.UserField, 4)
+	b = rdAddendumC.appendAlphaField(b, rdAddendumC.reserved, 5)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -119,9 +128,9 @@ func (rdAddendumC *ReturnDetailAddendumC) Validate() error {
 	if err := rdAddendumC.fieldInclusion(); err != nil {
 		return err
 	}
-	if rdAddendumC.recordType != "34" {
+	if rdAddendumC.recordType != RecordTypeReturnDetailAddendumC {
 		msg := fmt.Sprintf(msgRecordType, 34)
-		return &FieldError{FieldName: "recordType", Value: rdAddendumC.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rdAddendumC.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	// Mandatory
 	if err := rdAddendumC.isImageReferenceKeyIndicator(rdAddendumC.ImageReferenceKeyIndicator); err != nil {
@@ -146,12 +155,12 @@ func (rdAddendumC *ReturnDetailAddendumC) fieldInclusion() error {
 	if rdAddendumC.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rdAddendumC.recordType,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumC.MicrofilmArchiveSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "MicrofilmArchiveSequenceNumber",
 			Value: rdAddendumC.MicrofilmArchiveSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumC()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumC()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
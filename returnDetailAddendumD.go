@@ -7,7 +7,6 @@ package imagecashletter
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -83,6 +82,8 @@ type ReturnDetailAddendumD struct {
 	EndorsingBankIdentifier int `json:"endorsingBankIdentifier"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for image cash letter data validation
 	validator
 	// converters is composed for image cash letter to golang Converters
@@ -100,7 +101,12 @@ func (rdAddendumD *ReturnDetailAddendumD) setRecordType() {
 	if rdAddendumD == nil {
 		return
 	}
-	rdAddendumD.recordType = "35"
+	rdAddendumD.recordType = RecordTypeReturnDetailAddendumD
+}
+
+// RecordType returns the record type of the ReturnDetailAddendumD, which is RecordTypeReturnDetailAddendumD once set by NewReturnDetailAddendumD or Parse
+func (rdAddendumD *ReturnDetailAddendumD) RecordType() string {
+	return rdAddendumD.recordType
 }
 
 // Parse takes the input record string and parses the ReturnDetailAddendumD values
@@ -132,26 +138,29 @@ func (rdAddendumD *ReturnDetailAddendumD) Parse(record string) {
 	// 60-60
 	rdAddendumD.EndorsingBankIdentifier = rdAddendumD.parseNumField(record[59:60])
 	// 61-80
-	rdAddendumD.reserved = "                    "
+	rdAddendumD.reserved = rdAddendumD.parseReservedField(record, 60, 80)
 }
 
 // String writes the ReturnDetailAddendumD struct to a string.
 func (rdAddendumD *ReturnDetailAddendumD) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(rdAddendumD.recordType)
-	buf.WriteString(rdAddendumD.RecordNumberField())
-	buf.WriteString(rdAddendumD.EndorsingBankRoutingNumberField())
-	buf.WriteString(rdAddendumD.BOFDEndorsementBusinessDateField())
-	buf.WriteString(rdAddendumD.EndorsingBankItemSequenceNumberField())
-	buf.WriteString(rdAddendumD.TruncationIndicatorField())
-	buf.WriteString(rdAddendumD.EndorsingBankConversionIndicatorField())
-	buf.WriteString(rdAddendumD.EndorsingBankCorrectionIndicatorField())
-	buf.WriteString(rdAddendumD.ReturnReasonField())
-	buf.WriteString(rdAddendumD.UserFieldField())
-	buf.WriteString(rdAddendumD.EndorsingBankIdentifierField())
-	buf.WriteString(rdAddendumD.reservedField())
-	return buf.String()
+	return string(rdAddendumD.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the ReturnDetailAddendumD record, as String returns it, to b and returns the extended buffer.
+func (rdAddendumD *ReturnDetailAddendumD) AppendTo(b []byte) []byte {
+	b = append(b, rdAddendumD.recordType...)
+	b = rdAddendumD.appendNumericField(b, rdAddendumD.RecordNumber, 2)
+	b = rdAddendumD.appendStringField(b, rdAddendumD.EndorsingBankRoutingNumber, 9)
+	b = rdAddendumD.appendYYYYMMDDDate(b, rdAddendumD.BOFDEndorsementBusinessDate)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.EndorsingBankItemSequenceNumber, 15)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.TruncationIndicator, 1)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.EndorsingBankConversionIndicator, 1)
+	b = rdAddendumD.appendNumericField(b, rdAddendumD.EndorsingBankCorrectionIndicator, 1)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.ReturnReason, 1)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.UserField, 19)
+	b = rdAddendumD.appendNumericField(b, rdAddendumD.EndorsingBankIdentifier, 1)
+	b = rdAddendumD.appendAlphaField(b, rdAddendumD.reserved, 20)
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -160,9 +169,9 @@ func (rdAddendumD *ReturnDetailAddendumD) Validate() error {
 	if err := rdAddendumD.fieldInclusion(); err != nil {
 		return err
 	}
-	if rdAddendumD.recordType != "35" {
+	if rdAddendumD.recordType != RecordTypeReturnDetailAddendumD {
 		msg := fmt.Sprintf(msgRecordType, 35)
-		return &FieldError{FieldName: "recordType", Value: rdAddendumD.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rdAddendumD.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := rdAddendumD.isNumeric(rdAddendumD.EndorsingBankRoutingNumber); err != nil {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
@@ -187,9 +196,12 @@ func (rdAddendumD *ReturnDetailAddendumD) Validate() error {
 				Value: rdAddendumD.EndorsingBankCorrectionIndicatorField(), Msg: err.Error()}
 		}
 	}
-	if err := rdAddendumD.isAlphanumeric(rdAddendumD.ReturnReason); err != nil {
-		return &FieldError{FieldName: "ReturnReason",
-			Value: rdAddendumD.ReturnReason, Msg: err.Error()}
+	// Conditional
+	if rdAddendumD.ReturnReason != "" {
+		if err := rdAddendumD.isReturnReason(rdAddendumD.ReturnReason); err != nil {
+			return &FieldError{FieldName: "ReturnReason",
+				Value: rdAddendumD.ReturnReason, Msg: err.Error()}
+		}
 	}
 	if err := rdAddendumD.isAlphanumericSpecial(rdAddendumD.UserField); err != nil {
 		return &FieldError{FieldName: "UserField", Value: rdAddendumD.UserField, Msg: err.Error()}
@@ -198,6 +210,9 @@ func (rdAddendumD *ReturnDetailAddendumD) Validate() error {
 		return &FieldError{FieldName: "EndorsingBankIdentifier",
 			Value: rdAddendumD.EndorsingBankIdentifierField(), Msg: err.Error()}
 	}
+	if err := rdAddendumD.isDateInRange(rdAddendumD.BOFDEndorsementBusinessDate); err != nil {
+		return &FieldError{FieldName: "BOFDEndorsementBusinessDate", Value: rdAddendumD.BOFDEndorsementBusinessDateField(), Msg: err.Error()}
+	}
 	return nil
 }
 
@@ -207,37 +222,37 @@ func (rdAddendumD *ReturnDetailAddendumD) fieldInclusion() error {
 	if rdAddendumD.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rdAddendumD.recordType,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumD.RecordNumber == 0 {
 		return &FieldError{FieldName: "RecordNumber",
 			Value: rdAddendumD.RecordNumberField(),
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumD.EndorsingBankRoutingNumber == "" {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
 			Value: rdAddendumD.EndorsingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
-	if rdAddendumD.EndorsingBankRoutingNumberField() == "000000000" {
+	if rdAddendumD.isZeroRoutingNumber(rdAddendumD.EndorsingBankRoutingNumber) {
 		return &FieldError{FieldName: "EndorsingBankRoutingNumber",
 			Value: rdAddendumD.EndorsingBankRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumD.BOFDEndorsementBusinessDate.IsZero() {
 		return &FieldError{FieldName: "BOFDEndorsementBusinessDate",
 			Value: rdAddendumD.BOFDEndorsementBusinessDate.String(),
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumD.EndorsingBankItemSequenceNumberField() == "               " {
 		return &FieldError{FieldName: "EndorsingBankItemSequenceNumber",
 			Value: rdAddendumD.EndorsingBankItemSequenceNumber,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	if rdAddendumD.TruncationIndicator == "" {
 		return &FieldError{FieldName: "TruncationIndicator",
 			Value: rdAddendumD.TruncationIndicator,
-			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?"}
+			Msg:   msgFieldInclusion + ", did you use ReturnDetailAddendumD()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -282,6 +297,11 @@ func (rdAddendumD *ReturnDetailAddendumD) ReturnReasonField() string {
 	return rdAddendumD.alphaField(rdAddendumD.ReturnReason, 1)
 }
 
+// ReturnReasonDescription returns the description of the ReturnDetailAddendumD ReturnReason
+func (rdAddendumD *ReturnDetailAddendumD) ReturnReasonDescription() (string, bool) {
+	return ReturnReasonDescription(rdAddendumD.ReturnReason)
+}
+
 // UserFieldField gets the UserField field
 func (rdAddendumD *ReturnDetailAddendumD) UserFieldField() string {
 	return rdAddendumD.alphaField(rdAddendumD.UserField, 19)
@@ -292,6 +312,12 @@ func (rdAddendumD *ReturnDetailAddendumD) EndorsingBankIdentifierField() string
 	return rdAddendumD.numericField(rdAddendumD.EndorsingBankIdentifier, 1)
 }
 
+// EndorsingBankType returns the EndorsingBankIdentifier as an EndorsingBankType. An error is returned
+// if EndorsingBankIdentifier is not a defined value.
+func (rdAddendumD *ReturnDetailAddendumD) EndorsingBankType() (EndorsingBankType, error) {
+	return endorsingBankType(rdAddendumD.EndorsingBankIdentifier)
+}
+
 // reservedField gets reserved - blank space
 func (rdAddendumD *ReturnDetailAddendumD) reservedField() string {
 	return rdAddendumD.alphaField(rdAddendumD.reserved, 20)
@@ -250,6 +250,20 @@ func TestRDAddendumDReturnReason(t *testing.T) {
 	}
 }
 
+// TestRDAddendumDReturnReasonCode validates ReturnReason against the return reason codes
+func TestRDAddendumDReturnReasonCode(t *testing.T) {
+	rdAddendumD := mockReturnDetailAddendumD()
+	rdAddendumD.ReturnReason = ""
+	if err := rdAddendumD.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	rdAddendumD.ReturnReason = "a"
+	err := rdAddendumD.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ReturnReason" || e.Value != "a" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestRDAddendumDUserField validation
 func TestRDAddendumDUserField(t *testing.T) {
 	rdAddendumD := mockReturnDetailAddendumD()
@@ -276,6 +290,38 @@ func TestRDAddendumDEndorsingBankIdentifier(t *testing.T) {
 	}
 }
 
+// TestRDAddendumDEndorsingBankType validates the typed EndorsingBankIdentifier
+func TestRDAddendumDEndorsingBankType(t *testing.T) {
+	rdAddendumD := mockReturnDetailAddendumD()
+	rdAddendumD.EndorsingBankIdentifier = 3
+	if err := rdAddendumD.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	bankType, err := rdAddendumD.EndorsingBankType()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if bankType != EndorsingBankPayor || bankType.String() != "Payor Bank" {
+		t.Errorf("unexpected EndorsingBankType: %v", bankType)
+	}
+
+	rdAddendumD.EndorsingBankIdentifier = 9
+	if err := rdAddendumD.Validate(); err != nil {
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "EndorsingBankIdentifier" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+	if _, err := rdAddendumD.EndorsingBankType(); err != nil {
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "EndorsingBankIdentifier" {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected error")
+	}
+}
+
 // Field Inclusion
 
 // TestRDAddendumDFIRecordType validation
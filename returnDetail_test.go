@@ -353,3 +353,62 @@ func TestRDFIEceInstitutionItemSequenceNumber(t *testing.T) {
 		}
 	}
 }
+
+// TestReturnReasonDescription validates return reason codes are mapped to their descriptions
+func TestReturnReasonDescription(t *testing.T) {
+	tests := []struct {
+		code, description string
+	}{
+		{"A", "NSF - Not Sufficient Funds"},
+		{"C", "Stop Payment"},
+		{"D", "Closed Account"},
+		{"V", "Image Fails Security Check"},
+		{"1", "Does not conform with ANSI X9.100-181 Specification for TIFF Image Format for Image Exchange standard"},
+	}
+	for _, test := range tests {
+		description, ok := ReturnReasonDescription(test.code)
+		if !ok || description != test.description {
+			t.Errorf("%s: expected %q got %q", test.code, test.description, description)
+		}
+	}
+	if description, ok := ReturnReasonDescription("a"); ok || description != "" {
+		t.Errorf("unexpected description %q for unknown code", description)
+	}
+
+	rd := mockReturnDetail()
+	if description, ok := rd.ReturnReasonDescription(); !ok || description != "NSF - Not Sufficient Funds" {
+		t.Errorf("unexpected ReturnDetail description %q", description)
+	}
+	rdAddendumD := mockReturnDetailAddendumD()
+	rdAddendumD.ReturnReason = ""
+	if _, ok := rdAddendumD.ReturnReasonDescription(); ok {
+		t.Error("expected no description for a blank ReturnReason")
+	}
+}
+
+// TestReturnDetailAddAddendumRecordNumber validates addenda are numbered when added and duplicates are rejected
+func TestReturnDetailAddAddendumRecordNumber(t *testing.T) {
+	rd := mockReturnDetail()
+	for i := 0; i < 2; i++ {
+		rdAddendumD := mockReturnDetailAddendumD()
+		rdAddendumD.RecordNumber = 0
+		if err := rd.AddReturnDetailAddendumD(rdAddendumD); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+	}
+	if n := rd.ReturnDetailAddendumD[1].RecordNumber; n != 2 {
+		t.Errorf("ReturnDetailAddendumD RecordNumber %d, expected 2", n)
+	}
+
+	rdAddendumA := mockReturnDetailAddendumA()
+	if err := rd.AddReturnDetailAddendumA(rdAddendumA); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	err := rd.AddReturnDetailAddendumA(rdAddendumA)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "RecordNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(rd.ReturnDetailAddendumA) != 1 {
+		t.Errorf("duplicate ReturnDetailAddendumA was added")
+	}
+}
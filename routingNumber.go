@@ -0,0 +1,61 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors specific to routing numbers
+var (
+	msgRoutingNumberLength     = "must be 8 digits, or 9 digits including the check digit"
+	msgRoutingNumberCheckDigit = "check digit %s does not match calculated %d"
+)
+
+// routingNumberWeights are the ABA checksum weights of the first 8 digits of a routing number
+var routingNumberWeights = [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+
+// RoutingNumberCheckDigit returns the ABA check digit of a routing number given as its first 8 digits
+// (TTTTAAAA) or all 9 digits (TTTTAAAAC), in which case the existing check digit is ignored.
+func RoutingNumberCheckDigit(rtn string) (int, error) {
+	rtn = strings.TrimSpace(rtn)
+	if len(rtn) != 8 && len(rtn) != 9 {
+		return 0, &FieldError{FieldName: "RoutingNumber", Value: rtn, Msg: msgRoutingNumberLength}
+	}
+	sum := 0
+	for i, weight := range routingNumberWeights {
+		c := rtn[i]
+		if c < '0' || c > '9' {
+			return 0, &FieldError{FieldName: "RoutingNumber", Value: rtn, Msg: msgNumeric}
+		}
+		sum = sum + int(c-'0')*weight
+	}
+	if len(rtn) == 9 && (rtn[8] < '0' || rtn[8] > '9') {
+		return 0, &FieldError{FieldName: "RoutingNumber", Value: rtn, Msg: msgNumeric}
+	}
+	return (10 - sum%10) % 10, nil
+}
+
+// FormatRoutingNumber returns rtn as a 9 digit routing number (TTTTAAAAC). An 8 digit rtn has its check
+// digit appended and a 9 digit rtn is returned if its check digit is valid. Leading and trailing spaces
+// are removed. Records which hold the check digit separately, such as CheckDetail, take the first 8
+// digits for PayorBankRoutingNumber and the last for PayorBankCheckDigit.
+func FormatRoutingNumber(rtn string) (string, error) {
+	rtn = strings.TrimSpace(rtn)
+	checkDigit, err := RoutingNumberCheckDigit(rtn)
+	if err != nil {
+		return "", err
+	}
+	if len(rtn) == 8 {
+		return rtn + strconv.Itoa(checkDigit), nil
+	}
+	if int(rtn[8]-'0') != checkDigit {
+		msg := fmt.Sprintf(msgRoutingNumberCheckDigit, rtn[8:], checkDigit)
+		return "", &FieldError{FieldName: "RoutingNumber", Value: rtn, Msg: msg}
+	}
+	return rtn, nil
+}
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -26,6 +25,8 @@ type RoutingNumberSummary struct {
 	UserField string `json:"userField"`
 	// reserved is a field reserved for future use.  Reserved should be blank.
 	reserved string
+	// rawRecord is composed to hold the line the record was read from
+	rawRecord
 	// validator is composed for imagecashletter data validation
 	validator
 	// converters is composed for imagecashletter to golang Converters
@@ -43,7 +44,12 @@ func (rns *RoutingNumberSummary) setRecordType() {
 	if rns == nil {
 		return
 	}
-	rns.recordType = "85"
+	rns.recordType = RecordTypeRoutingNumberSummary
+}
+
+// RecordType returns the record type of the RoutingNumberSummary, which is RecordTypeRoutingNumberSummary once set by NewRoutingNumberSummary or Parse
+func (rns *RoutingNumberSummary) RecordType() string {
+	return rns.recordType
 }
 
 // Parse takes the input record string and parses the ImageViewDetail values
@@ -57,26 +63,30 @@ func (rns *RoutingNumberSummary) Parse(record string) {
 	// 03-11
 	rns.CashLetterRoutingNumber = rns.parseStringField(record[2:11])
 	// 12-25
-	rns.RoutingNumberTotalAmount = rns.parseNumField(record[11:25])
+	routingNumberTotalAmount, _ := rns.parseAmount(record[11:25])
+	rns.RoutingNumberTotalAmount = int(routingNumberTotalAmount)
 	// 26-31
 	rns.RoutingNumberItemCount = rns.parseNumField(record[26:31])
 	// 32-55
 	rns.UserField = rns.parseStringField(record[31:55])
 	// 56-80
-	rns.reserved = "                         "
+	rns.reserved = rns.parseReservedField(record, 55, 80)
 }
 
 // String writes the ImageViewDetail struct to a string.
 func (rns *RoutingNumberSummary) String() string {
-	var buf strings.Builder
-	buf.Grow(80)
-	buf.WriteString(rns.recordType)
-	buf.WriteString(rns.CashLetterRoutingNumberField())
-	buf.WriteString(rns.RoutingNumberTotalAmountField())
-	buf.WriteString(rns.RoutingNumberItemCountField())
-	buf.WriteString(rns.UserFieldField())
-	buf.WriteString(rns.reservedField())
-	return buf.String()
+	return string(rns.AppendTo(make([]byte, 0, 80)))
+}
+
+// AppendTo appends the RoutingNumberSummary record, as String returns it, to b and returns the extended buffer.
+func (rns *RoutingNumberSummary) AppendTo(b []byte) []byte {
+	b = append(b, rns.recordType...)
+	b = rns.appendStringField(b, rns.CashLetterRoutingNumber, 9)
+	b = rns.appendAmount(b, int64(rns.RoutingNumberTotalAmount), 14)
+	b = rns.appendNumericField(b, rns.RoutingNumberItemCount, 6)
+	b = rns.appendAlphaField(b, rns.UserField, 24)
+	b = rns.appendAlphaField(b, rns.reserved, 25)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -85,9 +95,9 @@ func (rns *RoutingNumberSummary) Validate() error {
 	if err := rns.fieldInclusion(); err != nil {
 		return err
 	}
-	if rns.recordType != "85" {
+	if rns.recordType != RecordTypeRoutingNumberSummary {
 		msg := fmt.Sprintf(msgRecordType, 85)
-		return &FieldError{FieldName: "recordType", Value: rns.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: rns.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if err := rns.isAlphanumericSpecial(rns.UserField); err != nil {
 		return &FieldError{FieldName: "UserField",
@@ -102,12 +112,17 @@ func (rns *RoutingNumberSummary) fieldInclusion() error {
 	if rns.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: rns.recordType,
-			Msg:   msgFieldInclusion + ", did you use RoutingNumberSummary()?"}
+			Msg:   msgFieldInclusion + ", did you use RoutingNumberSummary()?", Err: ErrFieldInclusion}
 	}
 	if rns.CashLetterRoutingNumber == "" {
 		return &FieldError{FieldName: "CashLetterRoutingNumber",
 			Value: rns.CashLetterRoutingNumber,
-			Msg:   msgFieldInclusion + ", did you use RoutingNumberSummary()?"}
+			Msg:   msgFieldInclusion + ", did you use RoutingNumberSummary()?", Err: ErrFieldInclusion}
+	}
+	if rns.isZeroRoutingNumber(rns.CashLetterRoutingNumber) {
+		return &FieldError{FieldName: "CashLetterRoutingNumber",
+			Value: rns.CashLetterRoutingNumber,
+			Msg:   msgFieldInclusion + ", did you use RoutingNumberSummary()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -119,7 +134,23 @@ func (rns *RoutingNumberSummary) CashLetterRoutingNumberField() string {
 
 // RoutingNumberTotalAmountField gets a string of RoutingNumberTotalAmount zero padded
 func (rns *RoutingNumberSummary) RoutingNumberTotalAmountField() string {
-	return rns.numericField(rns.RoutingNumberTotalAmount, 14)
+	return rns.formatAmount(int64(rns.RoutingNumberTotalAmount), 14)
+}
+
+// SetRoutingNumberTotalAmount sets RoutingNumberTotalAmount from an amount in cents, returning an error if cents is negative or
+// doesn't fit in the 14 digit field.
+func (rns *RoutingNumberSummary) SetRoutingNumberTotalAmount(cents int64) error {
+	amount, err := rns.amountFromCents("RoutingNumberTotalAmount", cents, 14)
+	if err != nil {
+		return err
+	}
+	rns.RoutingNumberTotalAmount = amount
+	return nil
+}
+
+// RoutingNumberTotalAmountCents returns RoutingNumberTotalAmount in cents
+func (rns *RoutingNumberSummary) RoutingNumberTotalAmountCents() int64 {
+	return int64(rns.RoutingNumberTotalAmount)
 }
 
 // RoutingNumberItemCountField gets a string of RoutingNumberItemCount zero padded
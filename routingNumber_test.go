@@ -0,0 +1,130 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRoutingNumberCheckDigit validates the ABA check digit calculated for 8 and 9 digit routing numbers
+func TestRoutingNumberCheckDigit(t *testing.T) {
+	tests := []struct {
+		rtn        string
+		checkDigit int
+	}{
+		{"03130001", 2},
+		{"031300012", 2},
+		{"12104288", 2},
+		{"23138010", 4},
+		{"23138010 ", 4},
+		{"00000000", 0},
+	}
+	for _, test := range tests {
+		checkDigit, err := RoutingNumberCheckDigit(test.rtn)
+		if err != nil {
+			t.Errorf("%q: %T: %s", test.rtn, err, err)
+		}
+		if checkDigit != test.checkDigit {
+			t.Errorf("%q: expected %d got %d", test.rtn, test.checkDigit, checkDigit)
+		}
+	}
+	for _, rtn := range []string{"", "0313000", "0313000123", "0313000A", "03130001A"} {
+		if _, err := RoutingNumberCheckDigit(rtn); err == nil {
+			t.Errorf("%q: expected error", rtn)
+		}
+	}
+}
+
+// TestFormatRoutingNumber validates routing numbers are normalized to 9 digits
+func TestFormatRoutingNumber(t *testing.T) {
+	tests := []struct {
+		rtn, formatted string
+	}{
+		{"031300012", "031300012"},
+		{"03130001", "031300012"},
+		{" 121042882 ", "121042882"},
+		{"23138010", "231380104"},
+	}
+	for _, test := range tests {
+		formatted, err := FormatRoutingNumber(test.rtn)
+		if err != nil {
+			t.Errorf("%q: %T: %s", test.rtn, err, err)
+		}
+		if formatted != test.formatted {
+			t.Errorf("%q: expected %q got %q", test.rtn, test.formatted, formatted)
+		}
+	}
+	for _, rtn := range []string{"031300013", "0313-0001", "3130001", "routing"} {
+		_, err := FormatRoutingNumber(rtn)
+		if e, ok := err.(*FieldError); !ok || e.FieldName != "RoutingNumber" {
+			t.Errorf("%q: %T: %s", rtn, err, err)
+		}
+	}
+}
+
+// TestRoutingNumberZero validates that routing numbers of all zeros are rejected by each record, while an
+// optional routing number may be blank
+func TestRoutingNumberZero(t *testing.T) {
+	tests := []struct {
+		fieldName string
+		validate  func(rtn string) error
+		optional  bool
+	}{
+		{"DestinationRoutingNumber", func(rtn string) error {
+			clh := mockCashLetterHeader()
+			clh.DestinationRoutingNumber = rtn
+			return clh.Validate()
+		}, false},
+		{"ECEInstitutionRoutingNumber", func(rtn string) error {
+			bh := mockBundleHeader()
+			bh.ECEInstitutionRoutingNumber = rtn
+			return bh.Validate()
+		}, false},
+		{"ReturnLocationRoutingNumber", func(rtn string) error {
+			bh := mockBundleHeader()
+			bh.ReturnLocationRoutingNumber = rtn
+			return bh.Validate()
+		}, true},
+		{"PayorBankRoutingNumber", func(rtn string) error {
+			cd := mockCheckDetail()
+			cd.PayorBankRoutingNumber = rtn
+			return cd.Validate()
+		}, false},
+		{"EndorsingBankRoutingNumber", func(rtn string) error {
+			rdAddendumD := mockReturnDetailAddendumD()
+			rdAddendumD.EndorsingBankRoutingNumber = rtn
+			return rdAddendumD.Validate()
+		}, false},
+		{"CashLetterRoutingNumber", func(rtn string) error {
+			rns := mockRoutingNumberSummary()
+			rns.CashLetterRoutingNumber = rtn
+			return rns.Validate()
+		}, false},
+		{"BankRoutingNumber", func(rtn string) error {
+			upe := mockUserPayeeEndorsement()
+			upe.BankRoutingNumber = rtn
+			return upe.Validate()
+		}, true},
+	}
+	for _, test := range tests {
+		for _, rtn := range []string{"0", "00000000", "000000000"} {
+			err := test.validate(rtn)
+			var e *FieldError
+			if !errors.As(err, &e) || e.FieldName != test.fieldName {
+				t.Errorf("%s %q: expected a FieldError, got %v", test.fieldName, rtn, err)
+				continue
+			}
+			if got := errors.Is(err, ErrFieldInclusion); got == test.optional {
+				t.Errorf("%s %q: %s", test.fieldName, rtn, err)
+			}
+		}
+		if test.optional {
+			if err := test.validate("         "); err != nil {
+				t.Errorf("%s blank: %T: %s", test.fieldName, err, err)
+			}
+		}
+	}
+}
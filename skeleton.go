@@ -0,0 +1,82 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"time"
+)
+
+// NewSkeletonFile returns a File sent from immediateOrigin to immediateDestination, which are 9 digit
+// routing numbers, with FileHeader defaults for a US X9.100-187-2013 test file created now. The
+// FileHeader names, FileIDModifier and UserField are blank and may be set by the caller, who must set
+// TestFileIndicator to P for a production file.
+//
+// A skeleton File has no CashLetters. Once items are added to the CashLetters from AddSkeletonCashLetter,
+// calling Recalculate creates the control records and the File passes validation.
+func NewSkeletonFile(immediateDestination, immediateOrigin string) *File {
+	today, now := skeletonNow()
+	fh := NewFileHeader()
+	fh.StandardLevel = StandardLevel2013
+	fh.TestFileIndicator = "T"
+	fh.ImmediateDestination = immediateDestination
+	fh.ImmediateOrigin = immediateOrigin
+	fh.FileCreationDate = today
+	fh.FileCreationTime = now
+	fh.ResendIndicator = "N"
+	fh.CountryCode = "US"
+
+	f := NewFile()
+	f.SetHeader(fh)
+	return f
+}
+
+// AddSkeletonCashLetter adds a CashLetter with the CashLetterID cashLetterID holding one empty Bundle and
+// returns it, so items can be added to its Bundles[0]. The CashLetterHeader and BundleHeader are forward
+// presentment (CollectionTypeIndicator 01) of items with images (DocumentationTypeIndicator G) from the
+// FileHeader ImmediateOrigin to its ImmediateDestination, with today's business date. The originator contact
+// and FedWorkType are blank and may be set by the caller.
+//
+// The returned CashLetter is only valid until another CashLetter is added to the File.
+func (f *File) AddSkeletonCashLetter(cashLetterID string) *CashLetter {
+	today, now := skeletonNow()
+	clh := NewCashLetterHeader()
+	clh.CollectionTypeIndicator = "01"
+	clh.DestinationRoutingNumber = f.Header.ImmediateDestination
+	clh.ECEInstitutionRoutingNumber = f.Header.ImmediateOrigin
+	clh.CashLetterBusinessDate = today
+	clh.CashLetterCreationDate = today
+	clh.CashLetterCreationTime = now
+	clh.RecordTypeIndicator = "I"
+	clh.DocumentationTypeIndicator = "G"
+	clh.CashLetterID = cashLetterID
+
+	cl := NewCashLetter(clh)
+	bh := newBundleHeader(clh)
+	bh.BundleSequenceNumber = "1"
+	cl.AddBundle(NewBundle(bh))
+	f.AddCashLetter(cl)
+	return &f.CashLetters[len(f.CashLetters)-1]
+}
+
+// skeletonNow returns today's date and the current time as they are read from a YYYYMMDD date field and
+// an HHMM time field, so a skeleton File is unchanged when it is written and read
+func skeletonNow() (today time.Time, now time.Time) {
+	t := time.Now()
+	today = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	now = time.Date(0, time.January, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+	return today, now
+}
+
+// newBundleHeader returns a BundleHeader for a Bundle of the CashLetter with the CashLetterHeader clh, which
+// the caller gives a BundleSequenceNumber
+func newBundleHeader(clh *CashLetterHeader) *BundleHeader {
+	bh := NewBundleHeader()
+	bh.CollectionTypeIndicator = clh.CollectionTypeIndicator
+	bh.DestinationRoutingNumber = clh.DestinationRoutingNumber
+	bh.ECEInstitutionRoutingNumber = clh.ECEInstitutionRoutingNumber
+	bh.BundleBusinessDate = clh.CashLetterBusinessDate
+	bh.BundleCreationDate = clh.CashLetterCreationDate
+	return bh
+}
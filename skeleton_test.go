@@ -0,0 +1,65 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSkeletonFile validates a skeleton File with one item is valid and round trips
+func TestSkeletonFile(t *testing.T) {
+	file := NewSkeletonFile("231380104", "121042882")
+	if err := file.Header.Validate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(file.CashLetters) != 0 {
+		t.Fatalf("expected no CashLetters, got %d", len(file.CashLetters))
+	}
+
+	cl := file.AddSkeletonCashLetter("A1")
+	if err := cl.CashLetterHeader.Validate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := cl.Bundles[0].BundleHeader.Validate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	cd := mockCheckDetail()
+	cd.AddendumCount = 0
+	cl.Bundles[0].AddCheckDetail(cd)
+	cd = mockCheckDetail()
+	cd.AddendumCount = 0
+	file.AddSkeletonCashLetter("A2").Bundles[0].AddCheckDetail(cd)
+
+	if err := file.Recalculate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if file.Control.CashLetterCount != 2 || file.Control.TotalItemCount != 2 {
+		t.Errorf("CashLetterCount %d TotalItemCount %d", file.Control.CashLetterCount, file.Control.TotalItemCount)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	read, err := NewReader(bytes.NewReader(buf.Bytes())).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// CashLetter.Create sets SettlementDate to the current time, so the records written are compared
+	var rewritten bytes.Buffer
+	if err := NewWriter(&rewritten).Write(&read); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(rewritten.Bytes(), buf.Bytes()) {
+		t.Error("skeleton File changed when written and read")
+	}
+	if read.Header.TestFileIndicator != "T" || read.CashLetters[1].CashLetterHeader.CashLetterID != "A2" {
+		t.Errorf("TestFileIndicator %q CashLetterID %q", read.Header.TestFileIndicator, read.CashLetters[1].CashLetterHeader.CashLetterID)
+	}
+}
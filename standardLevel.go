@@ -0,0 +1,39 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+// StandardLevel values of the FileHeader, which identify the revision of the standard a File follows
+const (
+	// StandardLevelDSTU2003 is DSTU X9.37 - 2003
+	StandardLevelDSTU2003 = "03"
+	// StandardLevel2008 is X9.100-187-2008
+	StandardLevel2008 = "30"
+	// StandardLevel2013 is X9.100-187-2013 and 2016
+	StandardLevel2013 = "35"
+)
+
+// layoutRecord is a record whose layout differs between revisions of the standard. Records are laid
+// out as X9.100-187-2008 and later unless the StandardLevel is StandardLevelDSTU2003, where:
+//
+// - FileHeader position 80 is reserved rather than CompanionDocumentIndicator
+//
+// - CashLetterHeader positions 78-79 are a 2 character UserField rather than ReturnsIndicator and a
+// 1 character UserField
+//
+// - ReturnDetail position 72 is reserved rather than TimesReturned
+type layoutRecord interface {
+	// parseLayout parses record in the layout of the StandardLevel level
+	parseLayout(record, level string)
+	// layoutString writes the record in the layout of the StandardLevel level
+	layoutString(level string) string
+}
+
+// layout returns the StandardLevel whose record layouts the File is written with
+func (f *File) layout() string {
+	if f.Layout != "" {
+		return f.Layout
+	}
+	return f.Header.StandardLevel
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestStandardLevelRoundTrip validates files of each revision are read in the layout of their StandardLevel
+// and written unchanged
+func TestStandardLevelRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		level         string
+		userField     string
+		timesReturned int
+	}{
+		{"dstu-x937-2003.icl", StandardLevelDSTU2003, "UF", 0},
+		{"x9100-187-2008.icl", StandardLevel2008, "9", 1},
+		{"BNK20180905121042882-A.icl", StandardLevel2013, "", 0},
+	}
+	for _, tc := range tests {
+		bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", tc.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := NewReader(bytes.NewReader(bs)).Read()
+		if err != nil {
+			t.Fatalf("%s: %T: %s", tc.name, err, err)
+		}
+		if file.Header.StandardLevel != tc.level || file.Layout != "" {
+			t.Errorf("%s: StandardLevel %q Layout %q", tc.name, file.Header.StandardLevel, file.Layout)
+		}
+		clh := file.CashLetters[0].CashLetterHeader
+		if clh.UserField != tc.userField || clh.ReturnsIndicator != "" {
+			t.Errorf("%s: UserField %q ReturnsIndicator %q", tc.name, clh.UserField, clh.ReturnsIndicator)
+		}
+		if rd := file.CashLetters[0].Bundles[1].Returns[0]; rd.TimesReturned != tc.timesReturned {
+			t.Errorf("%s: TimesReturned %d", tc.name, rd.TimesReturned)
+		}
+
+		var buf bytes.Buffer
+		if err := NewWriter(&buf).Write(&file); err != nil {
+			t.Fatalf("%s: %T: %s", tc.name, err, err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(buf.Bytes()), bytes.TrimSpace(bs)) {
+			t.Errorf("%s: written file differs from input", tc.name)
+		}
+	}
+}
+
+// TestReadStandardLevelOption validates the StandardLevel of the FileHeader is overridden by ReadStandardLevelOption
+func TestReadStandardLevelOption(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "dstu-x937-2003.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// In the 2013 layout the 2003 UserField begins with an invalid ReturnsIndicator
+	_, err = NewReader(bytes.NewReader(bs), ReadStandardLevelOption(StandardLevel2013)).Read()
+	if p, ok := err.(*ParseError); !ok || p.Record != "CashLetterHeader" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	bs, err = ioutil.ReadFile(filepath.Join("test", "testdata", "x9100-187-2008.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs), ReadStandardLevelOption(StandardLevelDSTU2003)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if file.Layout != StandardLevelDSTU2003 {
+		t.Errorf("Layout %q", file.Layout)
+	}
+	if rd := file.CashLetters[0].Bundles[1].Returns[0]; rd.TimesReturned != 0 {
+		t.Errorf("TimesReturned %d", rd.TimesReturned)
+	}
+	if clh := file.CashLetters[0].CashLetterHeader; clh.UserField != "9" {
+		t.Errorf("UserField %q", clh.UserField)
+	}
+
+	// The File is written in its Layout
+	rd := file.CashLetters[0].Bundles[1].Returns[0]
+	rd.TimesReturned = 2
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if record := rd.layoutString(file.Layout); !bytes.Contains(buf.Bytes(), []byte(record)) || record[71] != ' ' {
+		t.Errorf("ReturnDetail %q", record)
+	}
+}
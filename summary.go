@@ -0,0 +1,185 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"strings"
+)
+
+// FileSummary holds the counts and totals of a File returned by File.Summary
+type FileSummary struct {
+	// CashLetterCount is the number of CashLetters
+	CashLetterCount int `json:"cashLetterCount"`
+	// BundleCount is the number of Bundles in every CashLetter
+	BundleCount int `json:"bundleCount"`
+	// CheckCount is the number of CheckDetail records
+	CheckCount int `json:"checkCount"`
+	// ReturnCount is the number of ReturnDetail records
+	ReturnCount int `json:"returnCount"`
+	// CreditItemCount is the number of CreditItem records
+	CreditItemCount int `json:"creditItemCount"`
+	// CreditCount is the number of Credit records
+	CreditCount int `json:"creditCount"`
+	// ImageCount is the number of ImageViewDetail records of every CheckDetail and ReturnDetail
+	ImageCount int `json:"imageCount"`
+	// CheckAmount is the sum in cents of the ItemAmount of every CheckDetail
+	CheckAmount int64 `json:"checkAmount"`
+	// ReturnAmount is the sum in cents of the ItemAmount of every ReturnDetail
+	ReturnAmount int64 `json:"returnAmount"`
+	// TotalAmount is the sum in cents of every item, CreditItem and Credit, which is the
+	// FileTotalAmount calculated by Create
+	TotalAmount int64 `json:"totalAmount"`
+}
+
+// Summary returns the counts and totals of the records in the File. They are calculated from the
+// parsed records without validating them or reading the control records, so a File which was read
+// but fails validation can still be summarized.
+func (f *File) Summary() FileSummary {
+	var s FileSummary
+	if f == nil {
+		return s
+	}
+	s.CashLetterCount = len(f.CashLetters)
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		s.CreditItemCount = s.CreditItemCount + len(cl.allCreditItems())
+		s.CreditCount = s.CreditCount + len(cl.allCredits())
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			s.BundleCount++
+			s.CheckCount = s.CheckCount + len(b.Checks)
+			s.ReturnCount = s.ReturnCount + len(b.Returns)
+			s.ImageCount = s.ImageCount + b.imagesCount()
+			for _, cd := range b.Checks {
+				s.CheckAmount = s.CheckAmount + int64(cd.ItemAmount)
+			}
+			for _, rd := range b.Returns {
+				s.ReturnAmount = s.ReturnAmount + int64(rd.ItemAmount)
+			}
+		}
+	}
+	s.TotalAmount = f.TotalAmount()
+	return s
+}
+
+// RoleTotals holds the number and total amount of the items which reference a routing number in one role
+type RoleTotals struct {
+	// ItemCount is the number of CheckDetail and ReturnDetail records
+	ItemCount int `json:"itemCount"`
+	// TotalAmount is the sum in cents of their ItemAmount
+	TotalAmount int64 `json:"totalAmount"`
+}
+
+// EndpointTotals holds the number and total amount of the items which reference a routing number, which
+// are returned by File.RoutingNumberSummary, in total and by the role the routing number has for them.
+// An item is counted once in each role it references the routing number in, and once in the total.
+type EndpointTotals struct {
+	// ItemCount is the number of CheckDetail and ReturnDetail records referencing the routing number in any role
+	ItemCount int `json:"itemCount"`
+	// TotalAmount is the sum in cents of their ItemAmount
+	TotalAmount int64 `json:"totalAmount"`
+	// Payor holds the items drawn on the routing number, the PayorBankRoutingNumber followed by
+	// PayorBankCheckDigit of the CheckDetail or ReturnDetail
+	Payor RoleTotals `json:"payor"`
+	// Endorsing holds the items endorsed by the routing number, the EndorsingBankRoutingNumber of a
+	// CheckDetailAddendumC or ReturnDetailAddendumD
+	Endorsing RoleTotals `json:"endorsing"`
+	// ReturnLocation holds the items returned to the routing number, the ReturnLocationRoutingNumber of a
+	// CheckDetailAddendumA or ReturnDetailAddendumA
+	ReturnLocation RoleTotals `json:"returnLocation"`
+	// Destination holds the items sent to the routing number, the DestinationRoutingNumber of the
+	// CashLetterHeader or BundleHeader holding the item
+	Destination RoleTotals `json:"destination"`
+}
+
+// add adds the counts and amounts of other to t
+func (t *EndpointTotals) add(other EndpointTotals) {
+	for _, rt := range []struct{ to, from *RoleTotals }{
+		{&t.Payor, &other.Payor}, {&t.Endorsing, &other.Endorsing},
+		{&t.ReturnLocation, &other.ReturnLocation}, {&t.Destination, &other.Destination},
+	} {
+		rt.to.ItemCount = rt.to.ItemCount + rt.from.ItemCount
+		rt.to.TotalAmount = rt.to.TotalAmount + rt.from.TotalAmount
+	}
+	t.ItemCount = t.ItemCount + other.ItemCount
+	t.TotalAmount = t.TotalAmount + other.TotalAmount
+}
+
+// itemEndpoints holds the EndpointTotals of a single item by each routing number it references
+type itemEndpoints struct {
+	amount int64
+	totals map[string]*EndpointTotals
+}
+
+// ref records that the item references routingNumber in the role selected by role, unless routingNumber is blank
+func (e *itemEndpoints) ref(routingNumber string, role func(t *EndpointTotals) *RoleTotals) {
+	routingNumber = strings.TrimSpace(routingNumber)
+	if routingNumber == "" {
+		return
+	}
+	t, ok := e.totals[routingNumber]
+	if !ok {
+		t = &EndpointTotals{ItemCount: 1, TotalAmount: e.amount}
+		e.totals[routingNumber] = t
+	}
+	*role(t) = RoleTotals{ItemCount: 1, TotalAmount: e.amount}
+}
+
+func payorRole(t *EndpointTotals) *RoleTotals          { return &t.Payor }
+func endorsingRole(t *EndpointTotals) *RoleTotals      { return &t.Endorsing }
+func returnLocationRole(t *EndpointTotals) *RoleTotals { return &t.ReturnLocation }
+func destinationRole(t *EndpointTotals) *RoleTotals    { return &t.Destination }
+
+// RoutingNumberSummary returns the EndpointTotals of each distinct payor, endorsing, return location and
+// destination routing number referenced by the CheckDetail and ReturnDetail records of the File. Blank
+// routing numbers are left out. Payor totals are the aggregation a RoutingNumberSummary record holds,
+// calculated whether or not the File has any.
+func (f *File) RoutingNumberSummary() map[string]EndpointTotals {
+	totals := make(map[string]EndpointTotals)
+	newItem := func(cl *CashLetter, b *Bundle, amount int) *itemEndpoints {
+		item := &itemEndpoints{amount: int64(amount), totals: make(map[string]*EndpointTotals)}
+		if cl.CashLetterHeader != nil {
+			item.ref(cl.CashLetterHeader.DestinationRoutingNumber, destinationRole)
+		}
+		if b.BundleHeader != nil {
+			item.ref(b.BundleHeader.DestinationRoutingNumber, destinationRole)
+		}
+		return item
+	}
+	addItem := func(item *itemEndpoints) {
+		for routingNumber, t := range item.totals {
+			total := totals[routingNumber]
+			total.add(*t)
+			totals[routingNumber] = total
+		}
+	}
+	f.ForEachItem(func(cl *CashLetter, b *Bundle, cd *CheckDetail) error {
+		item := newItem(cl, b, cd.ItemAmount)
+		item.ref(cd.PayorBankRoutingNumber+cd.PayorBankCheckDigit, payorRole)
+		for _, addendumA := range cd.CheckDetailAddendumA {
+			item.ref(addendumA.ReturnLocationRoutingNumber, returnLocationRole)
+		}
+		for _, addendumC := range cd.CheckDetailAddendumC {
+			item.ref(addendumC.EndorsingBankRoutingNumber, endorsingRole)
+		}
+		addItem(item)
+		return nil
+	})
+	f.ForEachReturnItem(func(cl *CashLetter, b *Bundle, rd *ReturnDetail) error {
+		item := newItem(cl, b, rd.ItemAmount)
+		item.ref(rd.PayorBankRoutingNumber+rd.PayorBankCheckDigit, payorRole)
+		for _, addendumA := range rd.ReturnDetailAddendumA {
+			item.ref(addendumA.ReturnLocationRoutingNumber, returnLocationRole)
+		}
+		for _, addendumD := range rd.ReturnDetailAddendumD {
+			item.ref(addendumD.EndorsingBankRoutingNumber, endorsingRole)
+		}
+		addItem(item)
+		return nil
+	})
+	return totals
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFileSummary validates the counts and totals of a File with two CashLetters of two Bundles each
+func TestFileSummary(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	want := FileSummary{
+		CashLetterCount: 2,
+		BundleCount:     4,
+		CheckCount:      4,
+		ReturnCount:     4,
+		ImageCount:      8,
+		CheckAmount:     400000,
+		ReturnAmount:    400000,
+		TotalAmount:     800000,
+	}
+	if s := file.Summary(); s != want {
+		t.Errorf("got %+v", s)
+	}
+
+	// The Summary doesn't depend on validation or the control records
+	file.CashLetters[0].Bundles[0].BundleControl.BundleItemsCount = 1
+	file.CashLetters[0].CashLetterHeader.DestinationRoutingNumber = "121042882"
+	if err := file.ValidateWith(WithCountTolerance(0), WithStrict()); err == nil {
+		t.Error("expected a validation error")
+	}
+	if s := file.Summary(); s != want {
+		t.Errorf("got %+v", s)
+	}
+
+	var nilFile *File
+	if s := nilFile.Summary(); s != (FileSummary{}) {
+		t.Errorf("got %+v", s)
+	}
+}
+
+// TestFileRoutingNumberSummary validates the EndpointTotals by role of a File whose items all reference the same
+// payor, endorsing, return location and destination routing numbers, and once an item references others
+func TestFileRoutingNumberSummary(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	all := RoleTotals{ItemCount: 8, TotalAmount: 800000}
+	want := map[string]EndpointTotals{
+		"031300012": {ItemCount: 8, TotalAmount: 800000, Payor: all},
+		"121042882": {ItemCount: 8, TotalAmount: 800000, Endorsing: all, ReturnLocation: all},
+		"231380104": {ItemCount: 8, TotalAmount: 800000, Destination: all},
+	}
+	if totals := file.RoutingNumberSummary(); !reflect.DeepEqual(totals, want) {
+		t.Errorf("got %+v", totals)
+	}
+
+	// a check drawn on and endorsed by another routing number, which is also the destination of its Bundle of
+	// two checks. The CashLetterHeader destination is unchanged.
+	b := file.CashLetters[1].Bundles[0]
+	if len(b.Checks) != 2 {
+		t.Fatalf("unexpected %d checks", len(b.Checks))
+	}
+	cd := b.Checks[0]
+	cd.PayorBankRoutingNumber = "07640125"
+	cd.PayorBankCheckDigit = "1"
+	cd.ItemAmount = 2500
+	cd.CheckDetailAddendumC[0].EndorsingBankRoutingNumber = "076401251"
+	b.BundleHeader.DestinationRoutingNumber = "076401251"
+	others := RoleTotals{ItemCount: 7, TotalAmount: 700000}
+	all = RoleTotals{ItemCount: 8, TotalAmount: 702500}
+	want = map[string]EndpointTotals{
+		"031300012": {ItemCount: 7, TotalAmount: 700000, Payor: others},
+		"121042882": {ItemCount: 8, TotalAmount: 702500, Endorsing: others, ReturnLocation: all},
+		"231380104": {ItemCount: 8, TotalAmount: 702500, Destination: all},
+		"076401251": {ItemCount: 2, TotalAmount: 102500,
+			Payor:       RoleTotals{ItemCount: 1, TotalAmount: 2500},
+			Endorsing:   RoleTotals{ItemCount: 1, TotalAmount: 2500},
+			Destination: RoleTotals{ItemCount: 2, TotalAmount: 102500}},
+	}
+	if totals := file.RoutingNumberSummary(); !reflect.DeepEqual(totals, want) {
+		t.Errorf("got %+v", totals)
+	}
+
+	var nilFile *File
+	if totals := nilFile.RoutingNumberSummary(); len(totals) != 0 {
+		t.Errorf("got %+v", totals)
+	}
+}
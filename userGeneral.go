@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 )
 
@@ -87,7 +86,12 @@ func NewUserGeneral() *UserGeneral {
 }
 
 func (ug *UserGeneral) setRecordType() {
-	ug.recordType = "68"
+	ug.recordType = RecordTypeUser
+}
+
+// RecordType returns the record type of the UserGeneral, which is RecordTypeUser once set by NewUserGeneral or Parse
+func (ug *UserGeneral) RecordType() string {
+	return ug.recordType
 }
 
 // Parse takes the input record string and parses the UserGeneral values
@@ -116,18 +120,21 @@ func (ug *UserGeneral) Parse(record string) {
 
 // String writes the UserGeneral struct to a variable length string.
 func (ug *UserGeneral) String() string {
-	var buf strings.Builder
-	buf.Grow(45)
-	buf.WriteString(ug.recordType)
-	buf.WriteString(ug.OwnerIdentifierIndicatorField())
-	buf.WriteString(ug.OwnerIdentifierField())
-	buf.WriteString(ug.OwnerIdentifierModifierField())
-	buf.WriteString(ug.UserRecordFormatTypeField())
-	buf.WriteString(ug.FormatTypeVersionLevelField())
-	buf.WriteString(ug.LengthUserDataField())
-	buf.Grow(ug.parseNumField(ug.LengthUserData))
-	buf.WriteString(ug.UserDataField())
-	return buf.String()
+	size := 45 + ug.parseNumField(ug.LengthUserData)
+	return string(ug.AppendTo(make([]byte, 0, size)))
+}
+
+// AppendTo appends the UserGeneral record, as String returns it, to b and returns the extended buffer.
+func (ug *UserGeneral) AppendTo(b []byte) []byte {
+	b = append(b, ug.recordType...)
+	b = ug.appendNumericField(b, ug.OwnerIdentifierIndicator, 1)
+	b = ug.appendAlphaField(b, ug.OwnerIdentifier, 9)
+	b = ug.appendAlphaField(b, ug.OwnerIdentifierModifier, 20)
+	b = ug.appendAlphaField(b, ug.UserRecordFormatType, 3)
+	b = ug.appendAlphaField(b, ug.FormatTypeVersionLevel, 3)
+	b = ug.appendAlphaField(b, ug.LengthUserData, 7)
+	b = ug.appendAlphaField(b, ug.UserData, uint(ug.parseNumField(ug.LengthUserData)))
+	return b
 }
 
 // Validate performs image cash letter format rule checks on the record and returns an error if not Validated
@@ -136,9 +143,9 @@ func (ug *UserGeneral) Validate() error {
 	if err := ug.fieldInclusion(); err != nil {
 		return err
 	}
-	if ug.recordType != "68" {
+	if ug.recordType != RecordTypeUser {
 		msg := fmt.Sprintf(msgRecordType, 68)
-		return &FieldError{FieldName: "recordType", Value: ug.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ug.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if ug.UserRecordFormatType == "001" {
 		msg := fmt.Sprint(msgInvalid)
@@ -191,27 +198,27 @@ func (ug *UserGeneral) fieldInclusion() error {
 	if ug.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: ug.recordType,
-			Msg:   msgFieldInclusion + ", did you use UserGeneral()?"}
+			Msg:   msgFieldInclusion + ", did you use UserGeneral()?", Err: ErrFieldInclusion}
 	}
 	if ug.UserRecordFormatType == "" {
 		return &FieldError{FieldName: "UserRecordFormatType",
 			Value: ug.UserRecordFormatType,
-			Msg:   msgFieldInclusion + ", did you use UserGeneral()?"}
+			Msg:   msgFieldInclusion + ", did you use UserGeneral()?", Err: ErrFieldInclusion}
 	}
 	if ug.FormatTypeVersionLevel == "" {
 		return &FieldError{FieldName: "FormatTypeVersionLevel",
 			Value: ug.FormatTypeVersionLevel,
-			Msg:   msgFieldInclusion + ", did you use UserGeneral()?"}
+			Msg:   msgFieldInclusion + ", did you use UserGeneral()?", Err: ErrFieldInclusion}
 	}
 	if ug.LengthUserData == "" {
 		return &FieldError{FieldName: "LengthUserData",
 			Value: ug.LengthUserData,
-			Msg:   msgFieldInclusion + ", did you use UserGeneral()?"}
+			Msg:   msgFieldInclusion + ", did you use UserGeneral()?", Err: ErrFieldInclusion}
 	}
 	if ug.UserData == "" {
 		return &FieldError{FieldName: "UserData",
 			Value: ug.UserData,
-			Msg:   msgFieldInclusion + ", did you use UserGeneral()?"}
+			Msg:   msgFieldInclusion + ", did you use UserGeneral()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -6,7 +6,6 @@ package imagecashletter
 
 import (
 	"fmt"
-	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -120,7 +119,12 @@ func (upe *UserPayeeEndorsement) setRecordType() {
 	if upe == nil {
 		return
 	}
-	upe.recordType = "68"
+	upe.recordType = RecordTypeUser
+}
+
+// RecordType returns the record type of the UserPayeeEndorsement, which is RecordTypeUser once set by NewUserPayeeEndorsement or Parse
+func (upe *UserPayeeEndorsement) RecordType() string {
+	return upe.recordType
 }
 
 // Parse takes the input record string and parses the UserPayeeEndorsement values
@@ -179,32 +183,35 @@ func (upe *UserPayeeEndorsement) Parse(record string) {
 
 // String writes the UserPayeeEndorsement struct to a variable length string.
 func (upe *UserPayeeEndorsement) String() string {
-	var buf strings.Builder
-	buf.Grow(335)
-	buf.WriteString(upe.recordType)
-	buf.WriteString(upe.OwnerIdentifierIndicatorField())
-	buf.WriteString(upe.OwnerIdentifierField())
-	buf.WriteString(upe.OwnerIdentifierModifierField())
-	buf.WriteString(upe.UserRecordFormatTypeField())
-	buf.WriteString(upe.FormatTypeVersionLevelField())
-	buf.WriteString(upe.LengthUserDataField())
-	buf.WriteString(upe.PayeeNameField())
-	buf.WriteString(upe.EndorsementDateField())
-	buf.WriteString(upe.BankRoutingNumberField())
-	buf.WriteString(upe.BankAccountNumberField())
-	buf.WriteString(upe.CustomerIdentifierField())
-	buf.WriteString(upe.CustomerContactInformationField())
-	buf.WriteString(upe.StoreMerchantProcessingSiteNumberField())
-	buf.WriteString(upe.InternalControlSequenceNumberField())
-	buf.WriteString(upe.TimeField())
-	buf.WriteString(upe.OperatorNameField())
-	buf.WriteString(upe.OperatorNumberField())
-	buf.WriteString(upe.ManagerNameField())
-	buf.WriteString(upe.ManagerNumberField())
-	buf.WriteString(upe.EquipmentNumberField())
-	buf.WriteString(upe.EndorsementIndicatorField())
-	buf.WriteString(upe.UserFieldField())
-	return buf.String()
+	return string(upe.AppendTo(make([]byte, 0, 335)))
+}
+
+// AppendTo appends the UserPayeeEndorsement record, as String returns it, to b and returns the extended buffer.
+func (upe *UserPayeeEndorsement) AppendTo(b []byte) []byte {
+	b = append(b, upe.recordType...)
+	b = upe.appendNumericField(b, upe.OwnerIdentifierIndicator, 1)
+	b = upe.appendAlphaField(b, upe.OwnerIdentifier, 9)
+	b = upe.appendAlphaField(b, upe.OwnerIdentifierModifier, 20)
+	b = upe.appendAlphaField(b, upe.UserRecordFormatType, 3)
+	b = upe.appendAlphaField(b, upe.FormatTypeVersionLevel, 3)
+	b = upe.appendAlphaField(b, upe.LengthUserData, 7)
+	b = upe.appendAlphaField(b, upe.PayeeName, 50)
+	b = upe.appendYYYYMMDDDate(b, upe.EndorsementDate)
+	b = upe.appendAlphaField(b, upe.BankRoutingNumber, 9)
+	b = upe.appendAlphaField(b, upe.BankAccountNumber, 20)
+	b = upe.appendAlphaField(b, upe.CustomerIdentifier, 20)
+	b = upe.appendAlphaField(b, upe.CustomerContactInformation, 50)
+	b = upe.appendAlphaField(b, upe.StoreMerchantProcessingSiteNumber, 8)
+	b = upe.appendAlphaField(b, upe.InternalControlSequenceNumber, 25)
+	b = upe.appendSimpleTime(b, upe.Time)
+	b = upe.appendAlphaField(b, upe.OperatorName, 30)
+	b = upe.appendAlphaField(b, upe.OperatorNumber, 5)
+	b = upe.appendAlphaField(b, upe.ManagerName, 30)
+	b = upe.appendAlphaField(b, upe.ManagerNumber, 5)
+	b = upe.appendAlphaField(b, upe.EquipmentNumber, 15)
+	b = upe.appendNumericField(b, upe.EndorsementIndicator, 1)
+	b = upe.appendAlphaField(b, upe.UserField, 10)
+	return b
 }
 
 // Validate performs imagecashletter format rule checks on the record and returns an error if not Validated
@@ -213,9 +220,9 @@ func (upe *UserPayeeEndorsement) Validate() error {
 	if err := upe.fieldInclusion(); err != nil {
 		return err
 	}
-	if upe.recordType != "68" {
+	if upe.recordType != RecordTypeUser {
 		msg := fmt.Sprintf(msgRecordType, 68)
-		return &FieldError{FieldName: "recordType", Value: upe.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: upe.recordType, Msg: msg, Err: ErrRecordType}
 	}
 	if upe.UserRecordFormatType != "001" {
 		msg := fmt.Sprint(msgInvalid)
@@ -277,6 +284,9 @@ func (upe *UserPayeeEndorsement) validateNameNumberFields() error {
 		if err := upe.isNumeric(upe.BankRoutingNumber); err != nil {
 			return &FieldError{FieldName: "BankRoutingNumber", Value: upe.BankRoutingNumber, Msg: err.Error()}
 		}
+		if upe.isZeroRoutingNumber(upe.BankRoutingNumber) {
+			return &FieldError{FieldName: "BankRoutingNumber", Value: upe.BankRoutingNumber, Msg: msgRoutingNumberZero}
+		}
 	}
 	if upe.BankAccountNumber != "" {
 		if err := upe.isAlphanumericSpecial(upe.BankAccountNumber); err != nil {
@@ -351,22 +361,22 @@ func (upe *UserPayeeEndorsement) fieldInclusion() error {
 	if upe.recordType == "" {
 		return &FieldError{FieldName: "recordType",
 			Value: upe.recordType,
-			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?"}
+			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?", Err: ErrFieldInclusion}
 	}
 	if upe.UserRecordFormatType == "" {
 		return &FieldError{FieldName: "UserRecordFormatType",
 			Value: upe.UserRecordFormatType,
-			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?"}
+			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?", Err: ErrFieldInclusion}
 	}
 	if upe.FormatTypeVersionLevel == "" {
 		return &FieldError{FieldName: "FormatTypeVersionLevel",
 			Value: upe.FormatTypeVersionLevel,
-			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?"}
+			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?", Err: ErrFieldInclusion}
 	}
 	if upe.LengthUserData == "" {
 		return &FieldError{FieldName: "LengthUserData",
 			Value: upe.LengthUserData,
-			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?"}
+			Msg:   msgFieldInclusion + ", did you use UserPayeeEndorsement()?", Err: ErrFieldInclusion}
 	}
 	return nil
 }
@@ -0,0 +1,602 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors specific to validating control records
+var (
+	msgControlCount          = "%d does not match calculated %d"
+	msgMaxCount              = "%d exceeds the maximum of %d"
+	msgCollectionType        = "%s does not allow %s"
+	msgCollectionTypeBundles = "%s does not allow both check and return Bundles"
+	msgCollectionTypeItems   = "%s does not allow %s in cash letter %s"
+	msgBusinessDateFuture    = "is more than %s after the FileHeader FileCreationDate %s"
+	msgBundleSequenceDup     = "is a duplicate in cash letter %s"
+	msgBundleSequenceOrder   = "is out of order in cash letter %s, expected %d"
+	msgBundleSequenceGap     = "is not contiguous in cash letter %s, expected %d"
+)
+
+// Maximum counts checked by File.ValidateWith unless changed with WithMaxBundleItems or
+// WithMaxCashLetterBundles. They are the largest counts the BundleControl BundleItemsCount and
+// CashLetterControl CashLetterBundleCount fields can hold.
+const (
+	DefaultMaxBundleItems       = 9999
+	DefaultMaxCashLetterBundles = 999999
+)
+
+// ValidateOption can be used to change the default behavior of File.ValidateWith
+type ValidateOption interface {
+	apply(opts *validateOptions)
+}
+
+type validateOptionFunc func(opts *validateOptions)
+
+func (fn validateOptionFunc) apply(opts *validateOptions) {
+	fn(opts)
+}
+
+// validateOptions holds the settings from each ValidateOption passed to File.ValidateWith
+type validateOptions struct {
+	// checkControlCounts enables comparing control record counts against the records they summarize
+	checkControlCounts bool
+	// countTolerance is the discrepancy allowed between a declared and calculated count
+	countTolerance int
+	// warn is called with discrepancies which do not fail validation
+	warn func(err error)
+	// strict enables cross-record consistency checks
+	strict bool
+	// profile is the ValidationProfile whose rules are checked, if any
+	profile *ValidationProfile
+	// maxBundleItems is the most items allowed in a Bundle
+	maxBundleItems int
+	// maxCashLetterBundles is the most Bundles allowed in a CashLetter
+	maxCashLetterBundles int
+	// collectionTypes enables checking items against the CollectionTypeIndicator of their Bundle and CashLetter
+	collectionTypes bool
+	// checkBusinessDates enables checking business dates against the FileHeader FileCreationDate
+	checkBusinessDates bool
+	// businessDateTolerance is how far a business date may follow the FileHeader FileCreationDate
+	businessDateTolerance time.Duration
+	// bundleSequence enables checking the BundleSequenceNumber of each Bundle is its position in the CashLetter
+	bundleSequence bool
+}
+
+func newValidateOptions(opts []ValidateOption) *validateOptions {
+	o := &validateOptions{
+		warn:                 func(error) {},
+		maxBundleItems:       DefaultMaxBundleItems,
+		maxCashLetterBundles: DefaultMaxCashLetterBundles,
+	}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return o
+}
+
+// WithCountTolerance enables checking the counts in BundleControl, CashLetterControl and FileControl
+// records against the records they summarize. Discrepancies of up to n pass validation and are
+// reported through WithWarnings, while larger discrepancies are returned as errors. The tolerance also
+// applies to the CashLetterImagesCount, which Validate requires to match exactly.
+func WithCountTolerance(n int) ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		opts.checkControlCounts = true
+		opts.countTolerance = n
+	})
+}
+
+// WithStrict enables consistency checks between related records which are skipped by Validate:
+//
+// - CheckDetail DocumentationTypeIndicator (or the superseding CashLetterHeader value) matches
+// whether ImageViewDetail records are present
+//
+// - CashLetterHeader DestinationRoutingNumber and ECEInstitutionRoutingNumber match the FileHeader
+// ImmediateDestination and ImmediateOrigin
+//
+// - BundleHeader DestinationRoutingNumber and ECEInstitutionRoutingNumber match the CashLetterHeader
+func WithStrict() ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		opts.strict = true
+	})
+}
+
+// WithCollectionTypes enables checking that the items of each Bundle are of the kind its BundleHeader
+// CollectionTypeIndicator declares, CheckDetail records for forward presentment (00, 01 and 02) and
+// ReturnDetail records for returns and return notifications (03 through 06), and that the Bundles of each
+// CashLetter are all check or all return Bundles unless its CollectionTypeIndicator is 99. A mismatch is
+// returned as a BundleError or CashLetterError.
+//
+// The items of each CashLetter are also checked against its CashLetterHeader CollectionTypeIndicator in
+// the same way, which is returned as a FileError.
+func WithCollectionTypes() ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		opts.collectionTypes = true
+	})
+}
+
+// WithMaxBundleItems sets the most items allowed in each Bundle, counted as BundleControl BundleItemsCount
+// is, for receivers who negotiate a limit below DefaultMaxBundleItems. A Bundle with more items is
+// returned as a BundleError. n less than one restores DefaultMaxBundleItems.
+func WithMaxBundleItems(n int) ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		if n < 1 {
+			n = DefaultMaxBundleItems
+		}
+		opts.maxBundleItems = n
+	})
+}
+
+// WithMaxCashLetterBundles sets the most Bundles allowed in each CashLetter, for receivers who negotiate
+// a limit below DefaultMaxCashLetterBundles. A CashLetter with more Bundles is returned as a CashLetterError.
+// n less than one restores DefaultMaxCashLetterBundles.
+func WithMaxCashLetterBundles(n int) ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		if n < 1 {
+			n = DefaultMaxCashLetterBundles
+		}
+		opts.maxCashLetterBundles = n
+	})
+}
+
+// WithBusinessDateTolerance enables checking that no business date is more than tolerance after the
+// FileHeader FileCreationDate, which catches transposed or mistyped dates that are still valid calendar
+// dates. The CashLetterHeader CashLetterBusinessDate, BundleHeader and ImageViewData BundleBusinessDate,
+// ReturnDetailAddendumB PayorBankBusinessDate, the BOFDEndorsementDate of CheckDetailAddendumA and
+// ReturnDetailAddendumA records and the BOFDEndorsementBusinessDate of CheckDetailAddendumC and
+// ReturnDetailAddendumD records are checked by day, and a date too far in the future is returned as a
+// FieldError. Business dates before the FileCreationDate, such as those of backdated corrections, are not
+// checked.
+func WithBusinessDateTolerance(tolerance time.Duration) ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		opts.checkBusinessDates = true
+		opts.businessDateTolerance = tolerance
+	})
+}
+
+// WithBundleSequence enables checking that the BundleSequenceNumber values of the Bundles of each CashLetter
+// start at 1 and increase by 1 in the order the Bundles appear, as receivers expect. A duplicate sequence number,
+// the sequence numbers 1 through n in another order, and a gap in the sequence are each returned as a BundleError
+// with their own message. File.Renumber numbers the Bundles of each CashLetter this way.
+func WithBundleSequence() ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		opts.bundleSequence = true
+	})
+}
+
+// WithWarnings sets a function called with each non-fatal discrepancy found during validation.
+func WithWarnings(fn func(warning error)) ValidateOption {
+	return validateOptionFunc(func(opts *validateOptions) {
+		if fn != nil {
+			opts.warn = fn
+		}
+	})
+}
+
+// checkCount compares a declared count against the calculated count. Discrepancies within the
+// count tolerance are passed to warn and nil is returned.
+func (opts *validateOptions) checkCount(declared, calculated int, newErr func(msg string) error) error {
+	if declared == calculated {
+		return nil
+	}
+	diff := declared - calculated
+	if diff < 0 {
+		diff = -diff
+	}
+	err := newErr(fmt.Sprintf(msgControlCount, declared, calculated))
+	if diff <= opts.countTolerance {
+		opts.warn(err)
+		return nil
+	}
+	return err
+}
+
+// validateControlCounts checks the BundleControl counts against the Bundle's items
+func (b *Bundle) validateControlCounts(opts *validateOptions) error {
+	if b.BundleControl == nil {
+		return nil
+	}
+	newErr := func(fieldName string) func(string) error {
+		return func(msg string) error {
+			return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: fieldName, Msg: msg}
+		}
+	}
+	if err := opts.checkCount(b.BundleControl.BundleItemsCount, b.itemsCount(), newErr("BundleItemsCount")); err != nil {
+		return err
+	}
+	return opts.checkCount(b.BundleControl.BundleImagesCount, b.imagesCount(), newErr("BundleImagesCount"))
+}
+
+// validateControlCounts checks the CashLetterControl counts against the CashLetter's bundles and items
+func (cl *CashLetter) validateControlCounts(opts *validateOptions) error {
+	for _, b := range cl.Bundles {
+		if err := b.validateControlCounts(opts); err != nil {
+			return err
+		}
+	}
+	if cl.CashLetterControl == nil {
+		return nil
+	}
+	newErr := func(fieldName string) func(string) error {
+		return func(msg string) error {
+			return &CashLetterError{CashLetterID: cl.CashLetterHeader.CashLetterID, FieldName: fieldName, Msg: msg}
+		}
+	}
+	if err := opts.checkCount(cl.CashLetterControl.CashLetterBundleCount, len(cl.Bundles), newErr("CashLetterBundleCount")); err != nil {
+		return err
+	}
+	itemsCount := cl.itemsCount()
+	if cl.CashLetterControl.CreditTotalIndicator == 1 {
+		itemsCount = itemsCount + len(cl.allCreditItems()) + len(cl.allCredits())
+	}
+	return opts.checkCount(cl.CashLetterControl.CashLetterItemsCount, itemsCount, newErr("CashLetterItemsCount"))
+}
+
+// validateImagesCount checks the CashLetterControl CashLetterImagesCount against the image views of the
+// CashLetter's Bundles. Validate requires them to match, while ValidateWith allows the count tolerance.
+func (cl *CashLetter) validateImagesCount(opts *validateOptions) error {
+	if cl.CashLetterControl == nil {
+		return nil
+	}
+	declared := cl.CashLetterControl.CashLetterImagesCount
+	return opts.checkCount(declared, cl.imagesCount(), func(msg string) error {
+		return &FieldError{FieldName: "CashLetterImagesCount", Value: strconv.Itoa(declared), Msg: msg}
+	})
+}
+
+// validateControlCounts checks the FileControl counts against the File's cash letters and records
+func (f *File) validateControlCounts(opts *validateOptions) error {
+	for i := range f.CashLetters {
+		if err := f.CashLetters[i].validateControlCounts(opts); err != nil {
+			return err
+		}
+	}
+	recordCount, itemsCount := f.controlCounts(f.Control.CreditTotalIndicator)
+	newErr := func(fieldName string) func(string) error {
+		return func(msg string) error {
+			return &FileError{FieldName: fieldName, Value: strconv.Itoa(f.Control.CashLetterCount), Msg: msg}
+		}
+	}
+	if err := opts.checkCount(f.Control.CashLetterCount, len(f.CashLetters), newErr("CashLetterCount")); err != nil {
+		return err
+	}
+	if err := opts.checkCount(f.Control.TotalRecordCount, recordCount, newErr("TotalRecordCount")); err != nil {
+		return err
+	}
+	return opts.checkCount(f.Control.TotalItemCount, itemsCount, newErr("TotalItemCount"))
+}
+
+// controlCounts returns the FileControl TotalRecordCount and TotalItemCount calculated from the File's
+// records. CreditItems and Credits are counted as items when creditTotalIndicator is 1.
+func (f *File) controlCounts(creditTotalIndicator int) (recordCount int, itemsCount int) {
+	// FileHeader and FileControl
+	recordCount = 2
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		// CashLetterHeader, CashLetterControl, CreditItems, Credits and RoutingNumberSummary
+		recordCount = recordCount + 2 + len(cl.allCreditItems()) + len(cl.allCredits()) + len(cl.RoutingNumberSummary)
+		for _, b := range cl.Bundles {
+			// BundleHeader and BundleControl
+			recordCount = recordCount + 2 + b.itemsCount()
+		}
+		itemsCount = itemsCount + cl.itemsCount()
+		if creditTotalIndicator == 1 {
+			itemsCount = itemsCount + len(cl.allCreditItems()) + len(cl.allCredits())
+		}
+	}
+	return recordCount, itemsCount
+}
+
+// validateMaxCounts checks the number of Bundles in each CashLetter and items in each Bundle against
+// the maximums
+func (f *File) validateMaxCounts(opts *validateOptions) error {
+	for i := range f.CashLetters {
+		cl := &f.CashLetters[i]
+		if n := len(cl.Bundles); n > opts.maxCashLetterBundles {
+			msg := fmt.Sprintf(msgMaxCount, n, opts.maxCashLetterBundles)
+			return &CashLetterError{CashLetterID: cl.CashLetterHeader.CashLetterID, FieldName: "CashLetterBundleCount", Msg: msg}
+		}
+		for _, b := range cl.Bundles {
+			if n := b.itemsCount(); n > opts.maxBundleItems {
+				msg := fmt.Sprintf(msgMaxCount, n, opts.maxBundleItems)
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "BundleItemsCount", Msg: msg}
+			}
+		}
+	}
+	return nil
+}
+
+// validateCollectionTypes performs the checks enabled by WithCollectionTypes
+func (f *File) validateCollectionTypes() error {
+	for _, cl := range f.CashLetters {
+		checkBundles, returnBundles := false, false
+		for _, b := range cl.Bundles {
+			if b == nil || b.BundleHeader == nil {
+				continue
+			}
+			bh := b.BundleHeader
+			switch {
+			case isForwardCollectionType(bh.CollectionTypeIndicator) && len(b.Returns) > 0:
+				msg := fmt.Sprintf(msgCollectionType, bh.CollectionTypeIndicator, "Return Detail")
+				return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "CollectionTypeIndicator", Msg: msg}
+			case isReturnCollectionType(bh.CollectionTypeIndicator) && len(b.Checks) > 0:
+				msg := fmt.Sprintf(msgCollectionType, bh.CollectionTypeIndicator, "Check Detail")
+				return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "CollectionTypeIndicator", Msg: msg}
+			}
+			checkBundles = checkBundles || len(b.Checks) > 0
+			returnBundles = returnBundles || len(b.Returns) > 0
+		}
+		clh := cl.CashLetterHeader
+		if clh != nil && clh.CollectionTypeIndicator != "99" && checkBundles && returnBundles {
+			msg := fmt.Sprintf(msgCollectionTypeBundles, clh.CollectionTypeIndicator)
+			return &CashLetterError{CashLetterID: clh.CashLetterID, FieldName: "CollectionTypeIndicator", Msg: msg}
+		}
+		if err := cl.validateCollectionType(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCollectionType checks the items of the CashLetter are of the kind its CashLetterHeader
+// CollectionTypeIndicator declares
+func (cl *CashLetter) validateCollectionType() error {
+	clh := cl.CashLetterHeader
+	if clh == nil {
+		return nil
+	}
+	code := clh.CollectionTypeIndicator
+	for _, b := range cl.Bundles {
+		if b == nil {
+			continue
+		}
+		switch {
+		case isForwardCollectionType(code) && len(b.Returns) > 0:
+			msg := fmt.Sprintf(msgCollectionTypeItems, code, "Return Detail", clh.CashLetterID)
+			return &FileError{FieldName: "CollectionTypeIndicator", Value: code, Msg: msg}
+		case isReturnCollectionType(code) && len(b.Checks) > 0:
+			msg := fmt.Sprintf(msgCollectionTypeItems, code, "Check Detail", clh.CashLetterID)
+			return &FileError{FieldName: "CollectionTypeIndicator", Value: code, Msg: msg}
+		}
+	}
+	return nil
+}
+
+// isForwardCollectionType reports whether a CollectionTypeIndicator is forward presentment, which holds checks
+func isForwardCollectionType(code string) bool {
+	switch code {
+	case "00", "01", "02":
+		return true
+	}
+	return false
+}
+
+// isReturnCollectionType reports whether a CollectionTypeIndicator is a return or return notification, which
+// holds returns
+func isReturnCollectionType(code string) bool {
+	switch code {
+	case "03", "04", "05", "06":
+		return true
+	}
+	return false
+}
+
+// validateBusinessDates performs the checks enabled by WithBusinessDateTolerance
+func (f *File) validateBusinessDates(opts *validateOptions) error {
+	created := f.Header.FileCreationDate
+	check := func(fieldName string, date time.Time) error {
+		if date.IsZero() || dateOf(date).Sub(dateOf(created)) <= opts.businessDateTolerance {
+			return nil
+		}
+		msg := fmt.Sprintf(msgBusinessDateFuture, opts.businessDateTolerance, created.Format("20060102"))
+		return &FieldError{FieldName: fieldName, Value: date.Format("20060102"), Msg: msg}
+	}
+	for _, cl := range f.CashLetters {
+		if clh := cl.CashLetterHeader; clh != nil {
+			if err := check("CashLetterBusinessDate", clh.CashLetterBusinessDate); err != nil {
+				return err
+			}
+		}
+		for _, b := range cl.Bundles {
+			if b == nil {
+				continue
+			}
+			if bh := b.BundleHeader; bh != nil {
+				if err := check("BundleBusinessDate", bh.BundleBusinessDate); err != nil {
+					return err
+				}
+			}
+			checkImages := func(images []ImageViewData) error {
+				for _, ivData := range images {
+					if err := check("BundleBusinessDate", ivData.BundleBusinessDate); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			for _, cd := range b.Checks {
+				for _, addendumA := range cd.CheckDetailAddendumA {
+					if err := check("BOFDEndorsementDate", addendumA.BOFDEndorsementDate); err != nil {
+						return err
+					}
+				}
+				for _, addendumC := range cd.CheckDetailAddendumC {
+					if err := check("BOFDEndorsementBusinessDate", addendumC.BOFDEndorsementBusinessDate); err != nil {
+						return err
+					}
+				}
+				if err := checkImages(cd.ImageViewData); err != nil {
+					return err
+				}
+			}
+			for _, rd := range b.Returns {
+				for _, addendumA := range rd.ReturnDetailAddendumA {
+					if err := check("BOFDEndorsementDate", addendumA.BOFDEndorsementDate); err != nil {
+						return err
+					}
+				}
+				for _, addendumB := range rd.ReturnDetailAddendumB {
+					if err := check("PayorBankBusinessDate", addendumB.PayorBankBusinessDate); err != nil {
+						return err
+					}
+				}
+				for _, addendumD := range rd.ReturnDetailAddendumD {
+					if err := check("BOFDEndorsementBusinessDate", addendumD.BOFDEndorsementBusinessDate); err != nil {
+						return err
+					}
+				}
+				if err := checkImages(rd.ImageViewData); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateBundleSequences performs the checks enabled by WithBundleSequence
+func (f *File) validateBundleSequences() error {
+	for _, cl := range f.CashLetters {
+		var cashLetterID string
+		if cl.CashLetterHeader != nil {
+			cashLetterID = cl.CashLetterHeader.CashLetterID
+		}
+		var headers []*BundleHeader
+		for _, b := range cl.Bundles {
+			if b != nil && b.BundleHeader != nil {
+				headers = append(headers, b.BundleHeader)
+			}
+		}
+		seen := make(map[int]bool)
+		// contiguous is false once a sequence number outside 1 through len(headers) is found
+		contiguous := true
+		for _, bh := range headers {
+			seq, err := strconv.Atoi(strings.TrimSpace(bh.BundleSequenceNumber))
+			if err != nil {
+				// a blank or non-numeric sequence number is a gap rather than a duplicate
+				contiguous = false
+				continue
+			}
+			if seen[seq] {
+				msg := fmt.Sprintf(msgBundleSequenceDup, cashLetterID)
+				return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "BundleSequenceNumber", Msg: msg}
+			}
+			seen[seq] = true
+			if seq < 1 || seq > len(headers) {
+				contiguous = false
+			}
+		}
+		for i, bh := range headers {
+			if seq, err := strconv.Atoi(strings.TrimSpace(bh.BundleSequenceNumber)); err == nil && seq == i+1 {
+				continue
+			}
+			msg := fmt.Sprintf(msgBundleSequenceGap, cashLetterID, i+1)
+			if contiguous {
+				msg = fmt.Sprintf(msgBundleSequenceOrder, cashLetterID, i+1)
+			}
+			return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "BundleSequenceNumber", Msg: msg}
+		}
+	}
+	return nil
+}
+
+// dateOf returns the date of t at midnight UTC, so dates are compared by day regardless of time of day
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// validateStrict performs the checks enabled by WithStrict
+func (f *File) validateStrict(opts *validateOptions) error {
+	if err := f.validateRoutingNumbers(); err != nil {
+		return err
+	}
+	return f.ForEachItem(func(cl *CashLetter, _ *Bundle, cd *CheckDetail) error {
+		return cd.validateImageViews(cl.CashLetterHeader.DocumentationTypeIndicator)
+	})
+}
+
+// validateRoutingNumbers checks the routing numbers of each CashLetterHeader against the FileHeader and
+// the routing numbers of each BundleHeader against its CashLetterHeader
+func (f *File) validateRoutingNumbers() error {
+	for _, cl := range f.CashLetters {
+		clh := cl.CashLetterHeader
+		if clh == nil {
+			continue
+		}
+		if clh.DestinationRoutingNumber != f.Header.ImmediateDestination {
+			msg := fmt.Sprintf(msgFileRoutingNumberMatch, "FileHeader ImmediateDestination", f.Header.ImmediateDestination)
+			return &CashLetterError{CashLetterID: clh.CashLetterID, FieldName: "DestinationRoutingNumber", Msg: msg}
+		}
+		if clh.ECEInstitutionRoutingNumber != f.Header.ImmediateOrigin {
+			msg := fmt.Sprintf(msgFileRoutingNumberMatch, "FileHeader ImmediateOrigin", f.Header.ImmediateOrigin)
+			return &CashLetterError{CashLetterID: clh.CashLetterID, FieldName: "ECEInstitutionRoutingNumber", Msg: msg}
+		}
+		for _, b := range cl.Bundles {
+			bh := b.BundleHeader
+			if bh == nil {
+				continue
+			}
+			if bh.DestinationRoutingNumber != clh.DestinationRoutingNumber {
+				msg := fmt.Sprintf(msgFileRoutingNumberMatch, "CashLetterHeader DestinationRoutingNumber", clh.DestinationRoutingNumber)
+				return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "DestinationRoutingNumber", Msg: msg}
+			}
+			if bh.ECEInstitutionRoutingNumber != clh.ECEInstitutionRoutingNumber {
+				msg := fmt.Sprintf(msgFileRoutingNumberMatch, "CashLetterHeader ECEInstitutionRoutingNumber", clh.ECEInstitutionRoutingNumber)
+				return &BundleError{BundleSequenceNumber: bh.BundleSequenceNumber, FieldName: "ECEInstitutionRoutingNumber", Msg: msg}
+			}
+		}
+	}
+	return nil
+}
+
+// itemsCount returns the number of CheckDetail, ReturnDetail, addendum and image view records in the Bundle
+func (b *Bundle) itemsCount() int {
+	count := 0
+	for _, cd := range b.Checks {
+		count = count + 1
+		count = count + len(cd.CheckDetailAddendumA) + len(cd.CheckDetailAddendumB) + len(cd.CheckDetailAddendumC)
+		count = count + len(cd.ImageViewDetail) + len(cd.ImageViewData) + len(cd.ImageViewAnalysis)
+	}
+	for _, rd := range b.Returns {
+		count = count + 1
+		count = count + len(rd.ReturnDetailAddendumA) + len(rd.ReturnDetailAddendumB) + len(rd.ReturnDetailAddendumC) + len(rd.ReturnDetailAddendumD)
+		count = count + len(rd.ImageViewDetail) + len(rd.ImageViewData) + len(rd.ImageViewAnalysis)
+	}
+	return count
+}
+
+// imagesCount returns the number of ImageViewDetail records in the Bundle
+func (b *Bundle) imagesCount() int {
+	count := 0
+	for _, cd := range b.Checks {
+		count = count + len(cd.ImageViewDetail)
+	}
+	for _, rd := range b.Returns {
+		count = count + len(rd.ImageViewDetail)
+	}
+	return count
+}
+
+// itemsCount returns the number of items in every Bundle of the CashLetter, excluding CreditItems
+func (cl *CashLetter) itemsCount() int {
+	count := 0
+	for _, b := range cl.Bundles {
+		count = count + b.itemsCount()
+	}
+	return count
+}
+
+// imagesCount returns the number of ImageViewDetail records in every Bundle of the CashLetter
+func (cl *CashLetter) imagesCount() int {
+	count := 0
+	for _, b := range cl.Bundles {
+		count = count + b.imagesCount()
+	}
+	return count
+}
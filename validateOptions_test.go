@@ -0,0 +1,622 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readValidateOptionsFile(t *testing.T, name string) File {
+	t.Helper()
+	fd, err := os.Open(filepath.Join("test", "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// TestValidateWith__ControlCounts validates a fixture has correct control counts
+func TestValidateWith__ControlCounts(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	var warnings []error
+	if err := file.ValidateWith(WithCountTolerance(0), WithWarnings(func(w error) { warnings = append(warnings, w) })); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+// TestValidateWith__CountTolerance validates a count off by one against tolerances
+func TestValidateWith__CountTolerance(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	file.CashLetters[0].Bundles[0].BundleControl.BundleItemsCount++
+
+	// the default Validate doesn't check control counts
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var warnings []error
+	if err := file.ValidateWith(WithCountTolerance(1), WithWarnings(func(w error) { warnings = append(warnings, w) })); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning: %v", warnings)
+	}
+	if e, ok := warnings[0].(*BundleError); !ok || e.FieldName != "BundleItemsCount" {
+		t.Errorf("%T: %s", warnings[0], warnings[0])
+	}
+
+	err := file.ValidateWith(WithCountTolerance(0))
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "BundleItemsCount" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__StrictImageViews validates the DocumentationTypeIndicator against image views
+func TestValidateWith__StrictImageViews(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	if err := file.ValidateWith(WithStrict()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// images included, but none attached
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	ivDetail := cd.ImageViewDetail
+	cd.ImageViewDetail = nil
+	clc := file.CashLetters[0].CashLetterControl
+	clc.CashLetterImagesCount -= len(ivDetail)
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(WithStrict())
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "DocumentationTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+	cd.ImageViewDetail = ivDetail
+	clc.CashLetterImagesCount += len(ivDetail)
+
+	// the CheckDetail value is used when the CashLetterHeader has Z
+	file.CashLetters[0].CashLetterHeader.DocumentationTypeIndicator = "Z"
+	cd.DocumentationTypeIndicator = "K"
+	err = file.ValidateWith(WithStrict())
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "DocumentationTypeIndicator" || e.Value != "K" {
+		t.Errorf("%T: %s", err, err)
+	}
+	cd.DocumentationTypeIndicator = "J"
+	if err := file.ValidateWith(WithStrict()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__StrictRoutingNumbers validates the CashLetterHeader and BundleHeader routing numbers
+// against the FileHeader
+func TestValidateWith__StrictRoutingNumbers(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+
+	clh := file.CashLetters[0].CashLetterHeader
+	clh.DestinationRoutingNumber = "121042882"
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(WithStrict())
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "DestinationRoutingNumber" || e.CashLetterID != "A1" {
+		t.Errorf("%T: %s", err, err)
+	}
+	clh.DestinationRoutingNumber = file.Header.ImmediateDestination
+
+	clh.ECEInstitutionRoutingNumber = "231380104"
+	err = file.ValidateWith(WithStrict())
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "ECEInstitutionRoutingNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+	clh.ECEInstitutionRoutingNumber = file.Header.ImmediateOrigin
+
+	bh := file.CashLetters[0].Bundles[1].BundleHeader
+	bh.DestinationRoutingNumber = "121042882"
+	err = file.ValidateWith(WithStrict())
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "DestinationRoutingNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+	bh.DestinationRoutingNumber = clh.DestinationRoutingNumber
+	if err := file.ValidateWith(WithStrict()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__Profiles validates a file which passes one profile and fails another
+func TestValidateWith__Profiles(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+
+	if err := file.ValidateWith(ProfileDSTU()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(ProfileECCHO()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	// FedWorkType is blank in the fixture
+	err := file.ValidateWith(ProfileFedForward())
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "FedWorkType" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	for i := range file.CashLetters {
+		file.CashLetters[i].CashLetterHeader.FedWorkType = "C"
+	}
+	if err := file.ValidateWith(ProfileFedForward()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	cd.CheckDetailAddendumA = nil
+	cd.AddendumCount = len(cd.CheckDetailAddendumB) + len(cd.CheckDetailAddendumC)
+	if err := file.ValidateWith(ProfileFedForward()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = file.ValidateWith(ProfileECCHO())
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "CheckDetailAddendumA" || e.Value != cd.EceInstitutionItemSequenceNumber {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// preliminary forward information isn't presented, so doesn't need the BOFD endorsement
+	file.CashLetters[0].Bundles[0].BundleHeader.CollectionTypeIndicator = "00"
+	if err := file.ValidateWith(ProfileECCHO()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__ProfileFresh validates changing a built-in profile doesn't change the next one returned
+func TestValidateWith__ProfileFresh(t *testing.T) {
+	profile := ProfileFedForward()
+	profile.CollectionTypeIndicators[0] = "00"
+	profile.RequireFedWorkType = false
+	if p := ProfileFedForward(); p.CollectionTypeIndicators[0] != "01" || !p.RequireFedWorkType {
+		t.Errorf("unexpected profile: %v", p)
+	}
+}
+
+// TestValidateWith__ProfileCodeValues validates a profile's accepted code values
+func TestValidateWith__ProfileCodeValues(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	file.CashLetters[0].Bundles[0].BundleHeader.CollectionTypeIndicator = "05"
+
+	profile := ValidationProfile{Name: "Correspondent", CollectionTypeIndicators: []string{"01"}}
+	err := file.ValidateWith(profile)
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "CollectionTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	file.CashLetters[0].Bundles[0].BundleHeader.CollectionTypeIndicator = "01"
+	file.CashLetters[0].Bundles[0].Checks[0].DocumentationTypeIndicator = "K"
+	if err := file.ValidateWith(profile); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = file.ValidateWith(ProfileECCHO())
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "DocumentationTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__ProfileDebitCreditIndicator validates a profile's accepted Credit DebitCreditIndicator values
+func TestValidateWith__ProfileDebitCreditIndicator(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	cr := mockCredit()
+	cr.DebitCreditIndicator = "2"
+	file.CashLetters[0].AddCredit(cr)
+
+	profile := ValidationProfile{Name: "Correspondent", DebitCreditIndicators: []string{"2", "5"}}
+	if err := file.ValidateWith(profile); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	cr.DebitCreditIndicator = "C"
+	err := file.ValidateWith(profile)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "DebitCreditIndicator" || e.Value != "C" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(ProfileDSTU()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__ProfileStrictMICR validates the MICR characters of AuxiliaryOnUs and OnUs with StrictMICR
+func TestValidateWith__ProfileStrictMICR(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	profile := ValidationProfile{Name: "MICR", StrictMICR: true}
+
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	cd.OnUs = "5558881/1234"
+	cd.AuxiliaryOnUs = "12-34*"
+	if err := file.ValidateWith(profile); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	cd.OnUs = "555888I/1234"
+	if err := file.ValidateWith(ProfileDSTU()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(profile)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "OnUs" || e.Msg != msgNBSMOS {
+		t.Errorf("%T: %s", err, err)
+	}
+	cd.OnUs = "5558881/1234/5678/90123"
+	err = file.ValidateWith(profile)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "OnUs" {
+		t.Errorf("%T: %s", err, err)
+	}
+	cd.OnUs = "5558881"
+
+	// the On-Us symbol is not a MICR character of AuxiliaryOnUs
+	cd.AuxiliaryOnUs = "1234/5"
+	err = file.ValidateWith(profile)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "AuxiliaryOnUs" || e.Msg != msgNBSM {
+		t.Errorf("%T: %s", err, err)
+	}
+	cd.AuxiliaryOnUs = ""
+
+	rd := file.CashLetters[0].Bundles[1].Returns[0]
+	rd.OnUs = "ACCT 5558881"
+	err = file.ValidateWith(profile)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "OnUs" || e.Value != rd.OnUs {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__ProfileImageFormats validates a profile's accepted image format and compression codes
+func TestValidateWith__ProfileImageFormats(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	for i := range file.CashLetters {
+		file.CashLetters[i].CashLetterHeader.FedWorkType = "C"
+	}
+	// the fixture's image views are TIFF with Group 4 compression
+	if err := file.ValidateWith(ProfileFedForward()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var ivDetail *ImageViewDetail
+	for _, b := range file.CashLetters[0].Bundles {
+		if len(b.Returns) > 0 {
+			ivDetail = &b.Returns[0].ImageViewDetail[0]
+			break
+		}
+	}
+	if ivDetail == nil {
+		t.Fatal("expected ReturnDetail with ImageViewDetail")
+	}
+	ivDetail.ImageViewFormatIndicator = "21"
+	ivDetail.ImageViewCompressionAlgorithm = "01"
+	if err := file.ValidateWith(ProfileDSTU()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(ProfileFedForward())
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ImageViewFormatIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// image views which aren't present are not checked
+	ivDetail.ImageIndicator = 0
+	if err := file.ValidateWith(ProfileFedForward()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__MaxCounts validates the limits on items in a Bundle and Bundles in a CashLetter
+func TestValidateWith__MaxCounts(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	items := 0
+	for _, cl := range file.CashLetters {
+		for _, b := range cl.Bundles {
+			if n := b.itemsCount(); n > items {
+				items = n
+			}
+		}
+	}
+	if err := file.ValidateWith(WithMaxBundleItems(items)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(WithMaxBundleItems(items - 1))
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "BundleItemsCount" {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	if err := file.ValidateWith(WithMaxCashLetterBundles(2)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = file.ValidateWith(WithMaxCashLetterBundles(1))
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "CashLetterBundleCount" {
+		t.Errorf("%T: %v", err, err)
+	}
+
+	// values less than one restore the defaults
+	if err := file.ValidateWith(WithMaxBundleItems(0), WithMaxCashLetterBundles(-1)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__DefaultMaxBundleItems validates a Bundle at and one item over DefaultMaxBundleItems
+func TestValidateWith__DefaultMaxBundleItems(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	b := file.CashLetters[0].Bundles[0]
+	cd := &CheckDetail{}
+	for b.itemsCount() < DefaultMaxBundleItems {
+		b.AddCheckDetail(cd)
+	}
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	b.AddCheckDetail(cd)
+	err := file.ValidateWith()
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "BundleItemsCount" {
+		t.Errorf("%T: %v", err, err)
+	}
+}
+
+// TestValidateWith__CollectionTypes validates the kind of items in each Bundle and CashLetter against
+// their CollectionTypeIndicator
+func TestValidateWith__CollectionTypes(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	for _, cl := range file.CashLetters {
+		if len(cl.Bundles) != 2 || len(cl.Bundles[0].Checks) == 0 || len(cl.Bundles[1].Returns) == 0 {
+			t.Fatalf("CashLetter %s: expected a check and a return Bundle", cl.CashLetterHeader.CashLetterID)
+		}
+	}
+
+	// the return Bundles are marked as forward presentment
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(WithCollectionTypes())
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "CollectionTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// check and return Bundles in forward presentment CashLetters
+	for _, cl := range file.CashLetters {
+		cl.Bundles[1].BundleHeader.CollectionTypeIndicator = "03"
+	}
+	err = file.ValidateWith(WithCollectionTypes())
+	if e, ok := err.(*CashLetterError); !ok || e.FieldName != "CollectionTypeIndicator" {
+		t.Errorf("%T: %s", err, err)
+	}
+	for _, cl := range file.CashLetters {
+		cl.CashLetterHeader.CollectionTypeIndicator = "99"
+	}
+	if err := file.ValidateWith(WithCollectionTypes()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// checks in a return Bundle
+	checks := file.CashLetters[0].Bundles[0]
+	checks.BundleHeader.CollectionTypeIndicator = "04"
+	err = file.ValidateWith(WithCollectionTypes())
+	if e, ok := err.(*BundleError); !ok || e.FieldName != "CollectionTypeIndicator" || e.BundleSequenceNumber != checks.BundleHeader.BundleSequenceNumber {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__CashLetterCollectionTypes validates the items of each CashLetter against its
+// CashLetterHeader CollectionTypeIndicator
+func TestValidateWith__CashLetterCollectionTypes(t *testing.T) {
+	tests := []struct {
+		collectionType string
+		// returns is whether the CashLetter holds the return Bundle rather than the check Bundle
+		returns bool
+		valid   bool
+	}{
+		{"00", true, false},
+		{"01", true, false},
+		{"02", true, false},
+		{"03", false, false},
+		{"04", false, false},
+		{"05", false, false},
+		{"06", false, false},
+		{"01", false, true},
+		{"03", true, true},
+		{"99", false, true},
+		{"99", true, true},
+	}
+	for _, test := range tests {
+		file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+		file.CashLetters = file.CashLetters[:1]
+		cl := &file.CashLetters[0]
+		cl.Bundles[1].BundleHeader.CollectionTypeIndicator = "03"
+		if test.returns {
+			cl.Bundles = cl.Bundles[1:]
+		} else {
+			cl.Bundles = cl.Bundles[:1]
+		}
+		cl.CashLetterControl.CashLetterImagesCount = cl.imagesCount()
+		cl.CashLetterHeader.CollectionTypeIndicator = test.collectionType
+
+		err := file.ValidateWith(WithCollectionTypes())
+		if test.valid {
+			if err != nil {
+				t.Errorf("%s: %T: %s", test.collectionType, err, err)
+			}
+			continue
+		}
+		if e, ok := err.(*FileError); !ok || e.FieldName != "CollectionTypeIndicator" || e.Value != test.collectionType {
+			t.Errorf("%s: %T: %v", test.collectionType, err, err)
+		}
+		// the check is only made with WithCollectionTypes
+		if err := file.ValidateWith(); err != nil {
+			t.Errorf("%s: %T: %s", test.collectionType, err, err)
+		}
+	}
+}
+
+// TestValidateWith__BusinessDateTolerance validates business dates after the FileCreationDate
+// against tolerances
+func TestValidateWith__BusinessDateTolerance(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	if err := file.ValidateWith(WithBusinessDateTolerance(0)); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	rd := file.CashLetters[0].Bundles[1].Returns[0]
+	if len(rd.ReturnDetailAddendumB) == 0 {
+		t.Fatal("expected a ReturnDetailAddendumB")
+	}
+	rd.ReturnDetailAddendumB[0].PayorBankBusinessDate = file.Header.FileCreationDate.AddDate(0, 0, 30)
+
+	// the check is opt-in
+	if err := file.ValidateWith(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(WithBusinessDateTolerance(30 * 24 * time.Hour)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err := file.ValidateWith(WithBusinessDateTolerance(7 * 24 * time.Hour))
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "PayorBankBusinessDate" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// backdated business dates pass
+	rd.ReturnDetailAddendumB[0].PayorBankBusinessDate = file.Header.FileCreationDate.AddDate(0, 0, -30)
+	if err := file.ValidateWith(WithBusinessDateTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// the BOFD endorsement dates of addendum A and the BundleBusinessDate of image view data are checked
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	if len(cd.CheckDetailAddendumA) == 0 || len(cd.ImageViewData) == 0 || len(rd.ReturnDetailAddendumA) == 0 ||
+		len(rd.ImageViewData) == 0 {
+		t.Fatal("expected addendum A and image view data records")
+	}
+	future := file.Header.FileCreationDate.AddDate(0, 0, 30)
+	dates := []struct {
+		date      *time.Time
+		fieldName string
+	}{
+		{&cd.CheckDetailAddendumA[0].BOFDEndorsementDate, "BOFDEndorsementDate"},
+		{&cd.ImageViewData[0].BundleBusinessDate, "BundleBusinessDate"},
+		{&rd.ReturnDetailAddendumA[0].BOFDEndorsementDate, "BOFDEndorsementDate"},
+		{&rd.ImageViewData[0].BundleBusinessDate, "BundleBusinessDate"},
+	}
+	for _, d := range dates {
+		date := *d.date
+		*d.date = future
+		err := file.ValidateWith(WithBusinessDateTolerance(7 * 24 * time.Hour))
+		if e, ok := err.(*FieldError); !ok || e.FieldName != d.fieldName {
+			t.Errorf("%T: %s", err, err)
+		}
+		*d.date = date
+	}
+}
+
+// TestValidateWith__CashLetterImagesCount validates the CashLetterImagesCount against the image views of the
+// CashLetter's Bundles once an image is dropped, and that Recalculate corrects it. Validate requires an exact
+// match, while ValidateWith allows the count tolerance.
+func TestValidateWith__CashLetterImagesCount(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	cl := &file.CashLetters[0]
+	b := cl.Bundles[0]
+	cd := b.Checks[0]
+	images := cl.CashLetterControl.CashLetterImagesCount
+
+	// drop an image view, correcting every count but the CashLetterImagesCount
+	cd.ImageViewDetail = cd.ImageViewDetail[1:]
+	cd.ImageViewData = cd.ImageViewData[1:]
+	cd.ImageViewAnalysis = cd.ImageViewAnalysis[1:]
+	if err := b.BuildControl(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	cl.CashLetterControl.CashLetterItemsCount = cl.itemsCount()
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	err := file.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "CashLetterImagesCount" || e.Value != strconv.Itoa(images) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf(msgControlCount, images, images-1)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	var warnings []error
+	if err := file.ValidateWith(WithCountTolerance(1), WithWarnings(func(w error) { warnings = append(warnings, w) })); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning: %v", warnings)
+	}
+	if e, ok := warnings[0].(*FieldError); !ok || e.FieldName != "CashLetterImagesCount" {
+		t.Errorf("%T: %s", warnings[0], warnings[0])
+	}
+
+	if err := file.Recalculate(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := cl.CashLetterControl.CashLetterImagesCount; n != images-1 {
+		t.Errorf("unexpected CashLetterImagesCount %d", n)
+	}
+	if err := file.ValidateWith(WithCountTolerance(0)); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateWith__BundleSequence validates the BundleSequenceNumber values of each CashLetter with a duplicate,
+// out of order and non-contiguous sequence, and that Renumber corrects them
+func TestValidateWith__BundleSequence(t *testing.T) {
+	file := readValidateOptionsFile(t, "BNK20180905121042882-A.icl")
+	if err := file.ValidateWith(WithBundleSequence()); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	bundles := file.CashLetters[1].Bundles
+
+	tests := []struct {
+		sequence []int
+		msg      string
+	}{
+		{[]int{1, 1}, "is a duplicate"},
+		{[]int{2, 1}, "is out of order"},
+		{[]int{1, 3}, "is not contiguous"},
+		{[]int{0, 1}, "is not contiguous"},
+	}
+	for _, test := range tests {
+		for i, seq := range test.sequence {
+			bundles[i].BundleHeader.SetBundleSequenceNumber(seq)
+		}
+		// the check is opt-in
+		if err := file.ValidateWith(); err != nil {
+			t.Errorf("%v: %T: %s", test.sequence, err, err)
+		}
+		err := file.ValidateWith(WithBundleSequence())
+		if e, ok := err.(*BundleError); !ok || e.FieldName != "BundleSequenceNumber" || !strings.Contains(e.Msg, test.msg) {
+			t.Errorf("%v: %T: %s", test.sequence, err, err)
+		}
+	}
+
+	// blank and non-numeric sequence numbers are gaps, not duplicates of each other or of 0
+	for _, sequence := range [][]string{{"", ""}, {"", "0"}, {"x", "1"}} {
+		for i, seq := range sequence {
+			bundles[i].BundleHeader.BundleSequenceNumber = seq
+		}
+		err := file.ValidateWith(WithBundleSequence())
+		if e, ok := err.(*BundleError); !ok || e.FieldName != "BundleSequenceNumber" || !strings.Contains(e.Msg, "is not contiguous") {
+			t.Errorf("%q: %T: %s", sequence, err, err)
+		}
+	}
+
+	file.Renumber()
+	if err := file.ValidateWith(WithBundleSequence()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package imagecashletter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Errors specific to validating against a ValidationProfile
+var (
+	msgProfileRequired = "is required by the %s profile"
+	msgProfileValue    = "is not accepted by the %s profile, accepted values are %s"
+)
+
+// ValidationProfile is a set of rules a clearing channel applies on top of the X9.100-187 rules
+// checked by Validate. A ValidationProfile is passed to File.ValidateWith like any other
+// ValidateOption, and when no profile is passed only the X9.100-187 rules are checked.
+type ValidationProfile struct {
+	// Name identifies the profile in error messages
+	Name string
+	// RequireFedWorkType makes CashLetterHeader FedWorkType mandatory
+	RequireFedWorkType bool
+	// RequireCheckDetailAddendumA makes at least one CheckDetailAddendumA mandatory for each CheckDetail of a
+	// forward presentment Bundle, one with a BundleHeader CollectionTypeIndicator of 01 or 02
+	RequireCheckDetailAddendumA bool
+	// CollectionTypeIndicators are the accepted CashLetterHeader and BundleHeader CollectionTypeIndicator
+	// values. All valid values are accepted when empty.
+	CollectionTypeIndicators []string
+	// DocumentationTypeIndicators are the accepted CheckDetail DocumentationTypeIndicator values. All
+	// valid values are accepted when empty.
+	DocumentationTypeIndicators []string
+	// ImageViewFormatIndicators are the accepted ImageViewDetail ImageViewFormatIndicator values for
+	// image views which are present. All valid values are accepted when empty.
+	ImageViewFormatIndicators []string
+	// ImageViewCompressionAlgorithms are the accepted ImageViewDetail ImageViewCompressionAlgorithm
+	// values for image views which are present. All valid values are accepted when empty.
+	ImageViewCompressionAlgorithms []string
+	// DebitCreditIndicators are the accepted Credit DebitCreditIndicator values, which X9.100-187 leaves
+	// to the exchange partners. Any alphanumeric value is accepted when empty.
+	DebitCreditIndicators []string
+	// StrictMICR checks that CheckDetail AuxiliaryOnUs holds only MICR NBSM characters and CheckDetail and
+	// ReturnDetail OnUs only NBSMOS characters, and that neither is longer than its field. Validate accepts any value
+	// and truncates long values when written, as MICR conventions vary between exchange partners.
+	StrictMICR bool
+}
+
+// ProfileDSTU returns a profile which checks only the X9.100-187 DSTU rules, which is the behavior of Validate.
+func ProfileDSTU() ValidationProfile {
+	return ValidationProfile{Name: "DSTU"}
+}
+
+// ProfileFedForward returns a profile which checks the rules for forward presentment files sent to the
+// Federal Reserve:
+//
+// - CashLetterHeader FedWorkType is mandatory
+// - CashLetterHeader and BundleHeader CollectionTypeIndicator is 01, 02 or 03
+// - image views are TIFF 6 (ImageViewFormatIndicator 00) with Group 4 compression
+// (ImageViewCompressionAlgorithm 00)
+func ProfileFedForward() ValidationProfile {
+	return ValidationProfile{
+		Name:                           "FedForward",
+		RequireFedWorkType:             true,
+		CollectionTypeIndicators:       []string{"01", "02", "03"},
+		ImageViewFormatIndicators:      []string{"00"},
+		ImageViewCompressionAlgorithms: []string{"00"},
+	}
+}
+
+// ProfileECCHO returns a profile which checks the rules for image exchange under ECCHO (SVPCO) rules:
+//
+// - each CheckDetail presented forward has at least one CheckDetailAddendumA (the BOFD endorsement)
+// - CheckDetail DocumentationTypeIndicator is G, an image included with no paper provided
+// - image views are TIFF 6 (ImageViewFormatIndicator 00) with Group 4 compression
+// (ImageViewCompressionAlgorithm 00)
+func ProfileECCHO() ValidationProfile {
+	return ValidationProfile{
+		Name:                           "ECCHO",
+		RequireCheckDetailAddendumA:    true,
+		DocumentationTypeIndicators:    []string{"G"},
+		ImageViewFormatIndicators:      []string{"00"},
+		ImageViewCompressionAlgorithms: []string{"00"},
+	}
+}
+
+func (p ValidationProfile) apply(opts *validateOptions) {
+	opts.profile = &p
+}
+
+// validateFile checks the File against the profile's rules
+func (p *ValidationProfile) validateFile(f *File) error {
+	for i := range f.CashLetters {
+		clh := f.CashLetters[i].CashLetterHeader
+		if clh == nil {
+			continue
+		}
+		newErr := func(fieldName, msg string) error {
+			return &CashLetterError{CashLetterID: clh.CashLetterID, FieldName: fieldName, Msg: msg}
+		}
+		if p.RequireFedWorkType && clh.FedWorkType == "" {
+			return newErr("FedWorkType", fmt.Sprintf(msgProfileRequired, p.Name))
+		}
+		if err := p.accepts(clh.CollectionTypeIndicator, p.CollectionTypeIndicators); err != nil {
+			return newErr("CollectionTypeIndicator", err.Error())
+		}
+		for _, cr := range f.CashLetters[i].allCredits() {
+			if err := p.accepts(cr.DebitCreditIndicator, p.DebitCreditIndicators); err != nil {
+				return &FieldError{FieldName: "DebitCreditIndicator", Value: cr.DebitCreditIndicator, Msg: err.Error()}
+			}
+		}
+		for _, b := range f.CashLetters[i].Bundles {
+			if b.BundleHeader == nil {
+				continue
+			}
+			if err := p.accepts(b.BundleHeader.CollectionTypeIndicator, p.CollectionTypeIndicators); err != nil {
+				return &BundleError{BundleSequenceNumber: b.BundleHeader.BundleSequenceNumber, FieldName: "CollectionTypeIndicator", Msg: err.Error()}
+			}
+			for _, rd := range b.Returns {
+				if err := p.validateMICR("", rd.OnUs); err != nil {
+					return err
+				}
+				if err := p.validateImageViews(rd.ImageViewDetail); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return f.ForEachItem(func(_ *CashLetter, b *Bundle, cd *CheckDetail) error {
+		if p.RequireCheckDetailAddendumA && isForwardPresentment(b) && len(cd.CheckDetailAddendumA) == 0 {
+			return &FieldError{FieldName: "CheckDetailAddendumA", Value: cd.EceInstitutionItemSequenceNumber, Msg: fmt.Sprintf(msgProfileRequired, p.Name)}
+		}
+		if err := p.accepts(cd.DocumentationTypeIndicator, p.DocumentationTypeIndicators); err != nil {
+			return &FieldError{FieldName: "DocumentationTypeIndicator", Value: cd.DocumentationTypeIndicator, Msg: err.Error()}
+		}
+		if err := p.validateMICR(cd.AuxiliaryOnUs, cd.OnUs); err != nil {
+			return err
+		}
+		return p.validateImageViews(cd.ImageViewDetail)
+	})
+}
+
+// validateMICR checks the AuxiliaryOnUs and OnUs MICR fields of a CheckDetail, or the OnUs of a ReturnDetail,
+// when the profile is StrictMICR
+func (p *ValidationProfile) validateMICR(auxiliaryOnUs, onUs string) error {
+	if !p.StrictMICR {
+		return nil
+	}
+	var v validator
+	if err := v.isNBSM(auxiliaryOnUs); err != nil {
+		return &FieldError{FieldName: "AuxiliaryOnUs", Value: auxiliaryOnUs, Msg: err.Error()}
+	}
+	if len(auxiliaryOnUs) > 15 {
+		return &FieldError{FieldName: "AuxiliaryOnUs", Value: auxiliaryOnUs, Msg: fmt.Sprintf(msgFieldLength, 15)}
+	}
+	if err := v.isNBSMOS(onUs); err != nil {
+		return &FieldError{FieldName: "OnUs", Value: onUs, Msg: err.Error()}
+	}
+	if len(onUs) > 20 {
+		return &FieldError{FieldName: "OnUs", Value: onUs, Msg: fmt.Sprintf(msgFieldLength, 20)}
+	}
+	return nil
+}
+
+// validateImageViews checks the image format and compression of each ImageViewDetail which has an image
+// view present against the profile's accepted values
+func (p *ValidationProfile) validateImageViews(ivDetails []ImageViewDetail) error {
+	for _, ivDetail := range ivDetails {
+		if ivDetail.ImageIndicator == 0 {
+			// Image view not present
+			continue
+		}
+		if err := p.accepts(ivDetail.ImageViewFormatIndicator, p.ImageViewFormatIndicators); err != nil {
+			return &FieldError{FieldName: "ImageViewFormatIndicator", Value: ivDetail.ImageViewFormatIndicator, Msg: err.Error()}
+		}
+		if err := p.accepts(ivDetail.ImageViewCompressionAlgorithm, p.ImageViewCompressionAlgorithms); err != nil {
+			return &FieldError{FieldName: "ImageViewCompressionAlgorithm", Value: ivDetail.ImageViewCompressionAlgorithm, Msg: err.Error()}
+		}
+	}
+	return nil
+}
+
+// accepts returns an error if values is not empty and does not contain code
+func (p *ValidationProfile) accepts(code string, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	for _, v := range values {
+		if code == v {
+			return nil
+		}
+	}
+	return fmt.Errorf(msgProfileValue, p.Name, strings.Join(values, ", "))
+}
+
+// isForwardPresentment reports whether the Bundle b presents its items forward for payment, which excludes
+// preliminary forward information (CollectionTypeIndicator 00)
+func isForwardPresentment(b *Bundle) bool {
+	if b.BundleHeader == nil {
+		return false
+	}
+	switch b.BundleHeader.CollectionTypeIndicator {
+	case "01", "02":
+		return true
+	}
+	return false
+}
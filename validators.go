@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 )
 
 var (
@@ -15,13 +17,425 @@ var (
 	alphanumericRegex        = regexp.MustCompile(`[^ a-zA-Z0-9]`)
 	alphanumericRegexSpecial = regexp.MustCompile(`[^ \w!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
 	numericRegex             = regexp.MustCompile(`[^ 0-9]`)
+	embeddedBlankRegex       = regexp.MustCompile(`[0-9] +[0-9]`)
+	nbsmRegex                = regexp.MustCompile(`[^ 0-9*-]`)
+	nbsmosRegex              = regexp.MustCompile(`[^ 0-9*/-]`)
 	msgAlphanumeric          = "has non alphanumeric characters"
 	msgAlphanumericSpecial   = "has non alphanumeric or special characters"
 	//msgUpperAlpha             = "is not uppercase A-Z or 0-9"
-	msgNumeric        = "is not 0-9"
-	msgFieldInclusion = "is a mandatory field and has a default value"
+	msgNumeric           = "is not 0-9"
+	msgNBSM              = "has characters which are not 0-9, blank, dash (-) or asterisk (*)"
+	msgNBSMOS            = "has characters which are not 0-9, blank, dash (-), asterisk (*) or On-Us symbol (/)"
+	msgFieldLength       = "is longer than %d characters"
+	msgFieldInclusion    = "is a mandatory field and has a default value"
+	msgRoutingNumberZero = "is all zeros, an optional routing number which isn't present is blank"
 	//msgValidFieldLength    = "is not length %d"
-	msgInvalid = "is invalid"
+	msgInvalid   = "is invalid"
+	msgDate      = "is not a valid YYYYMMDD date"
+	msgDateRange = "is before 1990 or after 2099"
+	// earliestDate and latestDate bound the dates accepted by isDateInRange
+	earliestDate = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	latestDate   = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Codes of the enumerated fields, grouped so the accepted values are easy to audit and extend. The
+// ReturnReason codes are CustomerReturnCodeDict and AdministrativeReturnCodeDict. Credit DebitCreditIndicator
+// codes are left by X9.100-187 to the exchange partners, so they're accepted by ValidationProfile
+// DebitCreditIndicators rather than here.
+var (
+	// creditTotalIndicatorCodes are the CreditTotalIndicator codes of a FileControl, CashLetterControl or
+	// BundleControl
+	creditTotalIndicatorCodes = map[int]string{
+		0: "Credit Items are NOT included in totals",
+		1: "Credit Items are included in totals",
+	}
+	// documentationTypeIndicatorCodes are the DocumentationTypeIndicator codes of a CashLetterHeader,
+	// CheckDetail, ReturnDetail, Credit or CreditItem
+	documentationTypeIndicatorCodes = map[string]string{
+		"":  "Conditional value, blank/space indicates no DocumentationTypeIndicator",
+		"A": "No image provided, paper provided separately",
+		"B": "No image provided, paper provided separately, image upon request",
+		"C": "Image provided separately, no paper provided",
+		"D": "Image provided separately, no paper provided, image upon request",
+		"E": "Image and paper provided separately",
+		"F": "Image and paper provided separately, image upon request",
+		"G": "Image included, no paper provided",
+		"H": "Image included, no paper provided, image upon request",
+		"I": "Image included, paper provided separately",
+		"J": "Image included, paper provided separately, image upon request",
+		"K": "No image provided, no paper provided",
+		"L": "No image provided, no paper provided, image upon request",
+		"M": "No image provided, Electronic Check provided separately",
+		// Documentation associated with each item in the CashLetter will be different, so the CheckDetail or
+		// ReturnDetail has to be interrogated. Z is only valid for a CashLetterHeader.
+		"Z": "Not Same Type",
+	}
+	// ***File Header codes***
+
+	// standardLevelCodes are the StandardLevel codes of a FileHeader
+	standardLevelCodes = map[string]string{
+		"03": "DSTU X9.37 - 2003",
+		"30": "X9.100-187-2008",
+		"35": "X9.100-187-2013 and 2016",
+	}
+	// resendIndicatorCodes are the ResendIndicator codes of a FileHeader
+	resendIndicatorCodes = map[string]string{
+		"Y": "The file has been previously transmitted",
+		"N": "The file has NOT been previously transmitted",
+	}
+	// testFileIndicatorCodes are the TestFileIndicator codes of a FileHeader
+	testFileIndicatorCodes = map[string]string{
+		"P": "Production File",
+		"T": "Test File",
+	}
+	// companionDocumentIndicatorUSCodes are the CompanionDocumentIndicatorUS codes of a FileHeader. Other values
+	// as defined by clearing arrangements aren't implemented.
+	companionDocumentIndicatorUSCodes = map[string]string{
+		"":  "Conditional value, blank/space indicates no CompanionDocumentIndicator",
+		"0": "Reserved for United States use",
+		"1": "Reserved for United States use",
+		"2": "Reserved for United States use",
+		"3": "Reserved for United States use",
+		"4": "Reserved for United States use",
+		"5": "Reserved for United States use",
+		"6": "Reserved for United States use",
+		"7": "Reserved for United States use",
+	}
+	// companionDocumentIndicatorCACodes are the CompanionDocumentIndicatorCA codes of a FileHeader. Other values
+	// as defined by clearing arrangements aren't implemented.
+	companionDocumentIndicatorCACodes = map[string]string{
+		"":  "Conditional value, blank/space indicates no CompanionDocumentIndicator",
+		"A": "Reserved for Canadian use",
+		"B": "Reserved for Canadian use",
+		"C": "Reserved for Canadian use",
+		"D": "Reserved for Canadian use",
+		"E": "Reserved for Canadian use",
+		"F": "Reserved for Canadian use",
+		"G": "Reserved for Canadian use",
+		"H": "Reserved for Canadian use",
+		"I": "Reserved for Canadian use",
+		"J": "Reserved for Canadian use",
+	}
+
+	// ***Cash Letter Header codes***
+
+	// collectionTypeIndicatorCodes are the CollectionTypeIndicator codes of a CashLetterHeader
+	collectionTypeIndicatorCodes = map[string]string{
+		// Used when information may change and the information is treated as not final.
+		"00": "Preliminary Forward Information",
+		// For the collection and settlement of checks (demand instruments). Data are treated as final.
+		"01": "Forward Presentment",
+		// For the collection and settlement of checks (demand instruments) presented under the Federal Reserve’s
+		// same day settlement amendments to Regulation CC (12CFR Part 229). Data are treated as final.
+		"02": "Forward Presentment–Same-Day Settlement",
+		// For the return of check(s). Transaction carries value. Data are treated as final.
+		"03": "Return",
+		// For the notification of return of check(s). Transaction carries no value. The Return Notification
+		// Indicator (Field 12) in the Return Record (Type 31) has to be interrogated to determine whether a notice
+		// is a preliminary or final notification.
+		"04": "Return Notification",
+		// For the notification of return of check(s). Transaction carries no value. Used to indicate that an item
+		// may be returned. This field supersedes the Return Notification Indicator (Field 12) in the Return Record
+		// (Type 31).
+		"05": "Preliminary Return Notification",
+		// For the notification of return of check(s). Transaction carries no value. Used to indicate that an item
+		// will be returned. This field supersedes the Return Notification Indicator (Field 12) in the Return
+		// Record (Type 31).
+		"06": "Final Return Notification",
+		// There are no detail records contained within the bundle or cash letter. Defined Value of the Cash
+		// Letter Record Type Indicator (Field 8) shall be set to ‘N’.
+		"20": "No Detail",
+		// Use of the value is only allowed by clearing arrangement.
+		"99": "Bundles not the same collection type",
+	}
+	// recordTypeIndicatorCodes are the CashLetterRecordTypeIndicator codes of a CashLetterHeader
+	recordTypeIndicatorCodes = map[string]string{
+		"N": "No electronic check records or image records (Type 2x’s, 3x’s, 5x’s); e.g., an empty cash letter",
+		"E": "Cash letter contains electronic check records with no images (Type 2x’s and 3x’s only)",
+		"I": "Cash letter contains electronic check records (Type 2x’s, 3x’s) and image records (Type 5x’s)",
+		// The image records correspond to a previously sent cash letter (i.e., E file).
+		"F": "Cash letter contains electronic check records (Type 2x’s and 3x’s) and image records (Type 5x’s)",
+	}
+	// returnsIndicatorCodes are the ReturnsIndicator codes of a CashLetterHeader
+	returnsIndicatorCodes = map[string]string{
+		"": "Blank for Forward Presentment",
+		// Items being returned that are handled by the bank and usually do not directly affect the customer or
+		// its account.
+		"E": "Administrative",
+		// Items being returned that directly affect a customer’s account.
+		"R": "Customer",
+		"J": "Reject Return",
+	}
+
+	// ***CheckDetail codes***
+
+	// returnAcceptanceIndicatorCodes are the ReturnAcceptanceIndicator codes of a CheckDetail
+	returnAcceptanceIndicatorCodes = map[string]string{
+		"0": "Will not accept any electronic information",
+		"1": "Will accept preliminary return notifications, returns, and final return notifications",
+		"2": "Will accept preliminary return notifications and returns",
+		"3": "Will accept preliminary return notifications and final return notifications",
+		"4": "Will accept returns and final return notifications",
+		"5": "Will accept preliminary return notifications only",
+		"6": "Will accept returns only",
+		"7": "Will accept final return notifications only",
+		"8": "Will accept preliminary return notifications, returns, final return notifications, and image returns",
+		"9": "Will accept preliminary return notifications, returns and image returns",
+		"A": "Will accept preliminary return notifications, final return notifications and image returns",
+		"B": "Will accept returns, final return notifications and image returns",
+		"C": "Will accept preliminary return notifications and image returns",
+		"D": "Will accept returns and image returns",
+		"E": "Will accept final return notifications and image returns",
+		"F": "Will accept image returns only",
+	}
+	// micrValidIndicatorCodes are the MICRValidIndicator codes of a CheckDetail
+	micrValidIndicatorCodes = map[int]string{
+		1: "Good read",
+		2: "Good read, missing field",
+		3: "Read error encountered",
+		4: "Missing field and read error encountered",
+	}
+	// bofdIndicatorCodes are the BOFDIndicator codes of a CheckDetail
+	bofdIndicatorCodes = map[string]string{
+		"Y": "ECE institution is BOFD",
+		"N": "ECE institution is not BOFD",
+		"U": "ECE institution relationship to BOFD is undetermined",
+	}
+	// correctionIndicatorCodes are the CheckDetail CorrectionIndicator, addendum A BOFDCorrectionIndicator and
+	// addendum C and D EndorsingBankCorrectionIndicator codes
+	correctionIndicatorCodes = map[int]string{
+		0: "No Repair",
+		1: "Repaired (form of repair unknown)",
+		2: "Repaired without Operator intervention",
+		3: "Repaired with Operator intervention",
+		4: "Undetermined if repair has been done or not",
+	}
+	// archiveTypeIndicatorCodes are the ArchiveTypeIndicator codes of a CheckDetail
+	archiveTypeIndicatorCodes = map[string]string{
+		"A": "Microfilm",
+		"B": "Image",
+		"C": "Paper",
+		"D": "Microfilm and image",
+		"E": "Microfilm and paper",
+		"F": "Image and paper",
+		"G": "Microfilm, image and paper",
+		"H": "Electronic Check Instrument",
+		"I": "None",
+	}
+
+	// ***CheckDetail Addendum codes***
+
+	// truncationIndicatorCodes are the TruncationIndicator codes of a CheckDetailAddendumA
+	truncationIndicatorCodes = map[string]string{
+		"Y": "This institution truncated this original check item and this is first endorsement for the institution",
+		// or, this is not the first endorsement for the institution or, this item is an IRD not an original check
+		// item (EPC equals 4).
+		"N": "This institution did not truncate the original check",
+	}
+	// conversionIndicatorCodes are the BOFD and Endorsing Bank ConversionIndicator codes of a CheckDetailAddendumA
+	// and CheckDetailAddendumC
+	conversionIndicatorCodes = map[string]string{
+		"0": "Did not convert physical document",
+		"1": "Original paper converted to IRD",
+		"2": "Original paper converted to image",
+		"3": "IRD converted to another IRD",
+		"4": "IRD converted to image of IRD",
+		"5": "Image converted to an IRD",
+		"6": "Image converted to another image (e.g., transcoded)",
+		"7": "Did not convert image (e.g., same as source)",
+		"8": "Undetermined",
+	}
+	// imageReferenceKeyIndicatorCodes are the ImageReferenceKeyIndicator codes of a CheckDetailAddendumB
+	imageReferenceKeyIndicatorCodes = map[int]string{
+		0: "ImageReferenceKeyIndicator has Defined Value of 0034 and ImageReferenceKey contains the Image Reference Key",
+		// or ImageReferenceKeyIndicator contains Value 0034, which is not a Defined Value, and the content of
+		// ImageReferenceKey has no special significance with regards to an Image Reference Key;
+		// or ImageReferenceKeyIndicator is 0000, meaning the ImageReferenceKey is not present.
+		1: "ImageReferenceKeyIndicator contains a value other than Value 0034",
+	}
+	// endorsingBankIdentifierCodes are the EndorsingBankIdentifier codes of a CheckDetailAddendumC
+	endorsingBankIdentifierCodes = map[int]string{
+		// This value is used when the CheckDetailAddendumC Record reflects the Return Processing Bank in lieu of
+		// BOFD.
+		0: "Depository Bank (BOFD)",
+		1: "Other Collecting Bank",
+		2: "Other Returning Bank",
+		3: "Payor Bank",
+	}
+
+	// ***ImageView codes***
+
+	// imageIndicatorCodes are the ImageIndicator codes of an ImageViewDetail
+	imageIndicatorCodes = map[int]string{
+		0: "Image view not present",
+		1: "Image view present, actual check",
+		2: "Image view present, not actual check",
+		3: "Image view present, unable to determine if value is 1 or 2",
+	}
+	// imageViewFormatIndicatorCodes are the ImageViewFormatIndicator codes of an ImageViewDetail. Only 00 is
+	// accepted without an agreement.
+	imageViewFormatIndicatorCodes = map[string]string{
+		"00": "TIFF 6; Extension: TIF",
+		"01": "IOCA FS 11; Extension: ICA",
+		"20": "PNG (Portable Network Graphics); Extension: PNG",
+		"21": "JFIF (JPEG File Interchange Format); Extension: JPG",
+		"22": "SPIFF (Still Picture Interchange File Format) (ITU-T Rec. T.84 Annex F); Extension: SPF",
+		"23": "JBIG data stream (ITU-T Rec. T.82/ISO/IEC 11544:1993); Extension: JBG",
+		"24": "JPEG 2000 (ISO/IEC 15444-1:2000); Extension: JP2",
+	}
+	// imageViewCompressionAlgorithmCodes are the ImageViewCompressionAlgorithm codes of an ImageViewDetail. Only
+	// 00 is accepted without an agreement.
+	imageViewCompressionAlgorithmCodes = map[string]string{
+		"00": "Group 4 facsimile compression (ITU-T Rec. T.563/CCITT Rec. T.6)",
+		"01": "JPEG Baseline (JPEG Interchange Format) (ITU-T Rec. T.81/ISO/IEC 10918)",
+		"02": "ABIC",
+		"21": "PNG (Portable Network Graphics)",
+		"22": "JBIG (ITU-T Rec. T.82/ISO/IEC 11544:1993)",
+		"23": "JPEG 2000 (ISO/IEC 15444–1:2000)",
+	}
+	// viewSideIndicatorCodes are the ViewSideIndicator codes of an ImageViewDetail
+	viewSideIndicatorCodes = map[int]string{
+		0: "Front image view",
+		1: "Rear image view",
+	}
+	// viewDescriptorCodes are the ViewDescriptor codes of an ImageViewDetail
+	viewDescriptorCodes = map[string]string{
+		"00": "Full view",
+		"01": "Partial view–unspecified Area of Interest",
+		"02": "Partial view–date Area of Interest",
+		"03": "Partial view–payee Area of Interest",
+		"04": "Partial view–convenience amount Area of Interest",
+		"05": "Partial view–amount in words (legal amount) Area of Interest",
+		"06": "Partial view–signature Area(s) of Interest",
+		"07": "Partial view–payor name and address Area of Interest",
+		"08": "Partial view–MICR line Area of Interest",
+		"09": "Partial view–memo line Area of Interest",
+		"10": "Partial view–payor bank name and address Area of Interest",
+		"11": "Partial view–payee endorsement Area of Interest",
+		"12": "Partial view–Bank Of First Deposit (BOFD) endorsement Area of Interest",
+		"13": "Partial view–transit endorsement Area of Interest",
+		// 14 - 99 are reserved for X9, of which only 14 is accepted
+		"14": "Reserved for X9",
+	}
+	// digitalSignatureIndicatorCodes are the DigitalSignatureIndicator codes of an ImageViewDetail
+	digitalSignatureIndicatorCodes = map[int]string{
+		0: "Digital Signature is not present",
+		1: "Digital Signature is present",
+	}
+	// digitalSignatureMethodCodes are the DigitalSignatureMethod codes of an ImageViewDetail
+	digitalSignatureMethodCodes = map[string]string{
+		"00": "Digital Signature Algorithm (DSA) with SHA1 (ANSI X9.30)",
+		"01": "RSA with MD5 (ANSI X9.31)",
+		"02": "RSA with MDC2 (ANSI X9.31)",
+		"03": "RSA with SHA1 (ANSI X9.31)",
+		"04": "Elliptic Curve DSA (ECDSA) with SHA1 (ANSI X9.62)",
+		// 05 - 99 are reserved for emerging cryptographic algorithms, of which only 05 is accepted
+		"05": "Reserved for emerging cryptographic algorithms",
+	}
+	// imageRecreateIndicatorCodes are the ImageRecreateIndicator codes of an ImageViewDetail
+	imageRecreateIndicatorCodes = map[int]string{
+		0: "Sender can recreate the image view for the duration of the agreed upon retention time frames",
+		1: "Sender cannot recreate image view",
+	}
+	// overrideIndicatorCodes are the OverrideIndicator codes of an ImageViewDetail
+	overrideIndicatorCodes = map[string]string{
+		"":  "Blank/space indicates no observed image test failure present",
+		"0": "No override information for this view or not applicable",
+		"1": "Imperfect image",
+		"A": "IQA Fail–Image view reviewed and deemed usable—no alternate format",
+		"B": "IQA Fail–Image view reviewed and deemed usable—alternate format included in this file",
+		"C": "IQA Fail–Image view reviewed and deemed usable–alternate format included in this file and original document available",
+		"D": "IQA Fail–Image view reviewed and deemed usable–alternate format available",
+		"E": "IQA Fail–Image view reviewed and deemed usable–original document available",
+		"F": "IQA Fail–Image view reviewed and deemed usable–original document and alternate format available",
+		"G": "IQA Fail–Image view reviewed and deemed unusable–no alternate format",
+		"H": "IQA Fail–Image view reviewed and deemed unusable–alternate format included in this file",
+		"I": "IQA Fail–Image view reviewed and deemed unusable–alternate format included in this file and original document available",
+		"J": "IQA Fail–Image view reviewed and deemed unusable–alternate format available",
+		"K": "IQA Fail–Image view reviewed and deemed unusable–original document available",
+		"L": "IQA Fail–Image view reviewed and deemed unusable–original document and alternate format available",
+		"M": "IQA Fail–Image view not reviewed–no alternate format",
+		"N": "IQA Fail–Image view not reviewed–alternate format included in this file",
+		"O": "IQA Fail–Image view not reviewed–alternate format included in this file and original",
+	}
+	// imageViewAnalysisIndicatorCodes are the codes of the enumerated indicator properties of an ImageViewAnalysis,
+	// whose meaning is described by each property
+	imageViewAnalysisIndicatorCodes = map[int]string{
+		0: "Refer to ImageViewAnalysis property",
+		1: "Refer to ImageViewAnalysis property",
+		2: "Refer to ImageViewAnalysis property",
+	}
+
+	// ***Return codes***
+
+	// returnNotificationIndicatorCodes are the ReturnNotificationIndicator codes of a ReturnDetail
+	returnNotificationIndicatorCodes = map[int]string{
+		1: "Preliminary notification",
+		2: "Final notification",
+	}
+	// timesReturnedCodes are the TimesReturned codes of a ReturnDetail
+	timesReturnedCodes = map[int]string{
+		0: "The item has been returned an unknown number of times",
+		1: "The item has been returned once",
+		2: "The item has been returned twice",
+		3: "The item has been returned three times",
+	}
+
+	// ***Credit and user record codes***
+
+	// accountTypeCodes are the AccountTypeCode codes of a Credit or CreditItem
+	accountTypeCodes = map[string]string{
+		"0": "Unknown",
+		"1": "DDA account",
+		"2": "General Ledger account",
+		"3": "Savings account",
+		"4": "Money Market account",
+		"5": "Other account",
+		// A - J are accepted without a description
+		"A": "", "B": "", "C": "", "D": "", "E": "", "F": "", "G": "", "H": "", "I": "", "J": "",
+	}
+	// sourceWorkCodes are the SourceWorkCode codes of a Credit or CreditItem
+	sourceWorkCodes = map[string]string{
+		"00": "Unknown",
+		"01": "Internal–ATM",
+		"02": "Internal–Branch",
+		"03": "Internal–Other",
+		"04": "External–Bank to Bank (Correspondent)",
+		"05": "External–Business to Bank (Customer)",
+		"06": "External–Business to Bank Remote Capture",
+		"07": "External–Processor to Bank",
+		"08": "External–Bank to Processor",
+		"09": "Lockbox",
+		"10": "International–Internal",
+		"11": "International–External",
+		"21": "User Defined", "22": "User Defined", "23": "User Defined", "24": "User Defined", "25": "User Defined",
+		"26": "User Defined", "27": "User Defined", "28": "User Defined", "29": "User Defined", "30": "User Defined",
+		"31": "User Defined", "32": "User Defined", "33": "User Defined", "34": "User Defined", "35": "User Defined",
+		"36": "User Defined", "37": "User Defined", "38": "User Defined", "39": "User Defined", "40": "User Defined",
+		"41": "User Defined", "42": "User Defined", "43": "User Defined", "44": "User Defined", "45": "User Defined",
+		"46": "User Defined", "47": "User Defined", "48": "User Defined", "49": "User Defined", "50": "User Defined",
+	}
+	// ownerIdentifierIndicatorCodes are the OwnerIdentifierIndicator codes of the user records
+	ownerIdentifierIndicatorCodes = map[int]string{
+		0: "Not Used",
+		1: "Routing Number",
+		2: "DUNS Number",
+		3: "Federal Tax Identification Number",
+		4: "X9 Assignment",
+		5: "Other",
+	}
+	// endorsementIndicatorCodes are the EndorsementIndicator codes of a UserPayeeEndorsement
+	endorsementIndicatorCodes = map[int]string{
+		0: "Endorsed in Blank–Instrument becomes payable to bearer",
+		1: "For Deposit Only",
+		2: "For Collection Only",
+		3: "Anomalous Endorsement–Endorsement made by person who is not holder of instrument",
+		4: "Restrictive Endorsement–Limiting to a particular person or situation",
+		// Deposit to the account of within named payee absence of endorsement guaranteed by the bank whose
+		// Routing Number appears in BankRoutingNumber
+		5: "Guaranteed Endorsement",
+		9: "Other",
+	}
 )
 
 // validator is common validation and formatting of golang types to imagecashletter type strings
@@ -32,6 +446,7 @@ type FieldError struct {
 	FieldName string // field name where error happened
 	Value     string // value that cause error
 	Msg       string // context of the error.
+	Err       error  // sentinel error, such as ErrFieldInclusion, the error was built from
 }
 
 // Error message is constructed
@@ -42,14 +457,21 @@ func (e *FieldError) Error() string {
 	return fmt.Sprintf("%s %s %s", e.FieldName, e.Value, e.Msg)
 }
 
+// Unwrap returns the sentinel error the error was built from, if any, for errors.Is
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// isZeroRoutingNumber reports whether the routing number rtn is all zeros, the default value of a mandatory
+// routing number field. A blank routing number, of only spaces, isn't zero.
+func (v *validator) isZeroRoutingNumber(rtn string) bool {
+	rtn = strings.TrimSpace(rtn)
+	return rtn != "" && strings.Trim(rtn, "0") == ""
+}
+
 // isCreditTotalIndicator ensures CreditTotalIndicator of a FileControl, CashLetterControl, and BundleControl is valid
 func (v *validator) isCreditTotalIndicator(code int) error {
-	switch code {
-	case
-		// 	Credit Items are NOT included in totals
-		0,
-		//  Credit Items are included in totals
-		1:
+	if _, ok := creditTotalIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -57,40 +479,7 @@ func (v *validator) isCreditTotalIndicator(code int) error {
 
 // isDocumentationTypeIndicator ensures DocumentationTypeIndicator of a CashLetterHeader and CheckDetail is valid
 func (v *validator) isDocumentationTypeIndicator(code string) error {
-	switch code {
-	case
-		// Conditional value, blank/space indicates no DocumentationTypeIndicator
-		"",
-		// No image provided, paper provided separately
-		"A",
-		// No image provided, paper provided separately, image upon request
-		"B",
-		// Image provided separately, no paper provided
-		"C",
-		// Image provided separately, no paper provided, image upon request
-		"D",
-		// Image and paper provided separately
-		"E",
-		// Image and paper provided separately, image upon request
-		"F",
-		// Image included, no paper provided
-		"G",
-		// Image included, no paper provided, image upon request
-		"H",
-		// Image included, paper provided separately
-		"I",
-		// Image included, paper provided separately, image upon request
-		"J",
-		// No image provided, no paper provided
-		"K",
-		// No image provided, no paper provided, image upon request
-		"L",
-		// No image provided, Electronic Check provided separately
-		"M",
-		// Not Same Type–Documentation associated with each item in Cash Letter will be different. The CheckDetail
-		// Record or ReturnRecord has to be interrogated for further information.
-		// Z is only valid for CashLetter
-		"Z":
+	if _, ok := documentationTypeIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -100,14 +489,7 @@ func (v *validator) isDocumentationTypeIndicator(code string) error {
 
 // isStandardLevel ensures StandardLevel of a FileHeader is valid
 func (v *validator) isStandardLevel(code string) error {
-	switch code {
-	case
-		// 03: DSTU X9.37 - 2003
-		"03",
-		// 30: X9.100-187-2008
-		"30",
-		// 35: X9.100-187-2013 and 2016
-		"35":
+	if _, ok := standardLevelCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -115,12 +497,7 @@ func (v *validator) isStandardLevel(code string) error {
 
 // isResendIndicator ensures ResendIndicator of a FileHeader is valid
 func (v *validator) isResendIndicator(code string) error {
-	switch code {
-	case
-		// The file has been previously transmitted
-		"Y",
-		// The file has NOT been previously transmitted
-		"N":
+	if _, ok := resendIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -128,12 +505,7 @@ func (v *validator) isResendIndicator(code string) error {
 
 // isTestFileIndicator ensures TestFileIndicator of a FileHeader is valid
 func (v *validator) isTestFileIndicator(code string) error {
-	switch code {
-	case
-		// Production File
-		"P",
-		// Test File
-		"T":
+	if _, ok := testFileIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -141,13 +513,7 @@ func (v *validator) isTestFileIndicator(code string) error {
 
 // isCompanionDocumentIndicatorUS ensures CompanionDocumentIndicatorUS of a FileHeader is valid
 func (v *validator) isCompanionDocumentIndicatorUS(code string) error {
-	switch code {
-	case
-		// Conditional value, blank/space indicates no CompanionDocumentIndicator
-		"",
-		// 0–9 Reserved for United States use
-		"0", "1", "2", "3", "4", "5", "6", "7":
-		// Other - as defined by clearing arrangements. - Not implemented
+	if _, ok := companionDocumentIndicatorUSCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -155,13 +521,7 @@ func (v *validator) isCompanionDocumentIndicatorUS(code string) error {
 
 // isCompanionDocumentIndicatorCA ensures CompanionDocumentIndicatorCA of a FileHeader is valid
 func (v *validator) isCompanionDocumentIndicatorCA(code string) error {
-	switch code {
-	case
-		// Conditional value, blank/space indicates no CompanionDocumentIndicator
-		"",
-		// A-J Reserved for Canadian use
-		"A", "B", "C", "D", "E", "F", "G", "H", "I", "J":
-		// Other - as defined by clearing arrangements. - Not implemented
+	if _, ok := companionDocumentIndicatorCACodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -171,41 +531,7 @@ func (v *validator) isCompanionDocumentIndicatorCA(code string) error {
 
 // isCollectionTypeIndicator ensures CollectionTypeIndicator of a CashLetterHeader is valid
 func (v *validator) isCollectionTypeIndicator(code string) error {
-	switch code {
-	case
-		// Preliminary Forward Information–Used when information may change and the
-		// information is treated as not final.
-		"00",
-		// Forward Presentment–For the collection and settlement of checks (demand
-		// instruments). Data are treated as final.
-		"01",
-		// Forward Presentment–Same-Day Settlement–For the collection and settlement of
-		// checks (demand instruments) presented under the Federal Reserve’s same day
-		// settlement amendments to Regulation CC (12CFR Part 229). Data are treated as
-		// final.
-		"02",
-		// 03 Return–For the return of check(s). Transaction carries value. Data are
-		// treated as final.
-		"03",
-		// 04 Return Notification–For the notification of return of check(s). Transaction
-		// carries no value. The Return Notification Indicator (Field 12) in the Return Record
-		// (Type 31) has to be interrogated to determine whether a notice is a preliminary or final
-		// notification.
-		"04",
-		// 05 Preliminary Return Notification–For the notification of return of check(s). Transaction
-		// carries no value. Used to indicate that an item may be returned. This field supersedes
-		// the Return Notification Indicator (Field 12) in the Return Record (Type 31).
-		"05",
-		// 06 Final Return Notification–For the notification of return of check(s). Transaction
-		// carries no value. Used to indicate that an item will be returned. This field
-		// supersedes the Return Notification Indicator (Field 12) in the Return Record (Type 31).
-		"06",
-		// No Detail–There are no detail records contained within the bundle or cash letter.
-		// Defined Value of the Cash Letter Record Type Indicator (Field 8) shall be set to ‘N’.
-		"20",
-		// Bundles not the same collection type. Use of the value is only allowed by clearing
-		// arrangement.
-		"99":
+	if _, ok := collectionTypeIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -213,17 +539,7 @@ func (v *validator) isCollectionTypeIndicator(code string) error {
 
 // isRecordTypeIndicator ensures CashLetterRecordTypeIndicator of a CashLetterHeader is valid
 func (v *validator) isRecordTypeIndicator(code string) error {
-	switch code {
-	case
-		// No electronic check records or image records (Type 2x’s, 3x’s, 5x’s); e.g., an empty cash letter.
-		"N",
-		// Cash letter contains electronic check records with no images (Type 2x’s and 3x’s only).
-		"E",
-		// Cash letter contains electronic check records (Type 2x’s, 3x’s) and image records (Type 5x’s).
-		"I",
-		// Cash letter contains electronic check records (Type 2x’s and 3x’s) and image records (Type 5x’s)
-		// that correspond to a previously sent cash letter (i.e., E file).
-		"F":
+	if _, ok := recordTypeIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -231,17 +547,7 @@ func (v *validator) isRecordTypeIndicator(code string) error {
 
 // isReturnsIndicator ensures ReturnsIndicator of a CashLetterHeader is valid
 func (v *validator) isReturnsIndicator(code string) error {
-	switch code {
-	case
-		// Blank for Forward Presentment
-		"",
-		// Administrative - items being returned that are handled by the bank and usually do not directly
-		// affect the customer or its account.
-		"E",
-		// Customer–items being returned that directly affect a customer’s account.
-		"R",
-		// Reject Return
-		"J":
+	if _, ok := returnsIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -251,40 +557,7 @@ func (v *validator) isReturnsIndicator(code string) error {
 
 // isReturnAcceptanceIndicator ensures ReturnAcceptanceIndicator of a CheckDetail is valid
 func (v *validator) isReturnAcceptanceIndicator(code string) error {
-	switch code {
-	case
-		// Will not accept any electronic information
-		"0",
-		// Will accept preliminary return notifications, returns, and final return notifications
-		"1",
-		// Will accept preliminary return notifications and returns
-		"2",
-		// Will accept preliminary return notifications and final return notifications
-		"3",
-		// Will accept returns and final return notifications
-		"4",
-		// Will accept preliminary return notifications only
-		"5",
-		// Will accept returns only
-		"6",
-		// Will accept final return notifications only
-		"7",
-		// Will accept preliminary return notifications, returns, final return notifications, and image returns
-		"8",
-		// Will accept preliminary return notifications, returns and image returns
-		"9",
-		// Will accept preliminary return notifications, final return notifications and image returns
-		"A",
-		// Will accept returns, final return notifications and image returns
-		"B",
-		// Will accept preliminary return notifications and image returns
-		"C",
-		// Will accept returns and image returns
-		"D",
-		// Will accept final return notifications and image returns
-		"E",
-		// Will accept image returns only
-		"F":
+	if _, ok := returnAcceptanceIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -292,16 +565,7 @@ func (v *validator) isReturnAcceptanceIndicator(code string) error {
 
 // isMICRValidIndicator ensures MICRValidIndicator of a CheckDetail is valid
 func (v *validator) isMICRValidIndicator(code int) error {
-	switch code {
-	case
-		// Good read
-		1,
-		// Good read, missing field
-		2,
-		// Read error encountered
-		3,
-		// Missing field and read error encountered
-		4:
+	if _, ok := micrValidIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -309,14 +573,7 @@ func (v *validator) isMICRValidIndicator(code int) error {
 
 // isBOFDIndicator ensures BOFDIndicator of a CheckDetail is valid
 func (v *validator) isBOFDIndicator(code string) error {
-	switch code {
-	case
-		// ECE institution is BOFD
-		"Y",
-		// ECE institution is not BOFD
-		"N",
-		// ECE institution relationship to BOFD is undetermined
-		"U":
+	if _, ok := bofdIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -324,18 +581,7 @@ func (v *validator) isBOFDIndicator(code string) error {
 
 // isCorrectionIndicator ensures CorrectionIndicator of a CheckDetail is valid
 func (v *validator) isCorrectionIndicator(code int) error {
-	switch code {
-	case
-		// No Repair
-		0,
-		// Repaired (form of repair unknown)
-		1,
-		// Repaired without Operator intervention
-		2,
-		// Repaired with Operator intervention
-		3,
-		// Undetermined if repair has been done or not
-		4:
+	if _, ok := correctionIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -343,26 +589,7 @@ func (v *validator) isCorrectionIndicator(code int) error {
 
 // isArchiveTypeIndicator ensures ArchiveTypeIndicator of a CheckDetail is valid
 func (v *validator) isArchiveTypeIndicator(code string) error {
-	switch code {
-	case
-		// Microfilm
-		"A",
-		// B: Image
-		"B",
-		// C: Paper
-		"C",
-		// D: Microfilm and image
-		"D",
-		// E: Microfilm and paper
-		"E",
-		// F: Image and paper
-		"F",
-		// Microfilm, image and paper
-		"G",
-		// Electronic Check Instrument
-		"H",
-		// None
-		"I":
+	if _, ok := archiveTypeIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -372,13 +599,7 @@ func (v *validator) isArchiveTypeIndicator(code string) error {
 
 // isTruncationIndicator ensures TruncationIndicator of a CheckDetailAddendumA is valid
 func (v *validator) isTruncationIndicator(code string) error {
-	switch code {
-	case
-		// This institution truncated this original check item and this is first endorsement for the institution.
-		"Y",
-		// This institution did not truncate the original check or, this is not the first endorsement for the
-		// institution or, this item is an IRD not an original check item (EPC equals 4).
-		"N":
+	if _, ok := truncationIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -387,26 +608,7 @@ func (v *validator) isTruncationIndicator(code string) error {
 // isConversionIndicator ensures BOFD and Endorsing Bank ConversionIndicator of a CheckDetailAddendumA and
 // CheckDetailAddendumC is valid
 func (v *validator) isConversionIndicator(code string) error {
-	switch code {
-	case
-		// Did not convert physical document
-		"0",
-		// Original paper converted to IRD
-		"1",
-		// Original paper converted to image
-		"2",
-		// IRD converted to another IRD
-		"3",
-		// IRD converted to image of IRD
-		"4",
-		// Image converted to an IRD
-		"5",
-		// Image converted to another image (e.g., transcoded)
-		"6",
-		// Did not convert image (e.g., same as source)
-		"7",
-		// Undetermined
-		"8":
+	if _, ok := conversionIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -414,15 +616,7 @@ func (v *validator) isConversionIndicator(code string) error {
 
 // isImageReferenceKeyIndicator ensures ImageReferenceKeyIndicator of a CheckDetailAddendumB is valid
 func (v *validator) isImageReferenceKeyIndicator(code int) error {
-	switch code {
-	case
-		// ImageReferenceKeyIndicator has Defined Value of 0034 and ImageReferenceKey contains the Image Reference Key.
-		0,
-		// ImageReferenceKeyIndicator contains a value other than Value 0034;
-		// or ImageReferenceKeyIndicator contains Value 0034, which is not a Defined Value, and the content of
-		// ImageReferenceKey has no special significance with regards to an Image Reference Key;
-		// or ImageReferenceKeyIndicator is 0000, meaning the ImageReferenceKey is not present.
-		1:
+	if _, ok := imageReferenceKeyIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -430,17 +624,7 @@ func (v *validator) isImageReferenceKeyIndicator(code int) error {
 
 // isEndorsingBankIdentifier ensures EndorsingBankIdentifier of a CheckDetailAddendumC is valid
 func (v *validator) isEndorsingBankIdentifier(code int) error {
-	switch code {
-	case
-		// Depository Bank (BOFD) - this value is used when the CheckDetailAddendumC Record reflects the Return
-		// Processing Bank in lieu of BOFD.
-		0,
-		// Other Collecting Bank
-		1,
-		// Other Returning Bank
-		2,
-		// Payor Bank
-		3:
+	if _, ok := endorsingBankIdentifierCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -450,16 +634,7 @@ func (v *validator) isEndorsingBankIdentifier(code int) error {
 
 // isImageIndicator ensures ImageIndicator of a ImageViewDetail is valid
 func (v *validator) isImageIndicator(code int) error {
-	switch code {
-	case
-		// Image view not present
-		0,
-		// Image view present, actual check
-		1,
-		// Image view present, not actual check
-		2,
-		// Image view present, unable to determine if value is 1 or 2
-		3:
+	if _, ok := imageIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -467,22 +642,7 @@ func (v *validator) isImageIndicator(code int) error {
 
 // isImageViewFormatIndicator ensures ImageViewFormatIndicator of a ImageViewDetail is valid
 func (v *validator) isImageViewFormatIndicator(code string) error {
-	switch code {
-	case
-		// Agreement not required:
-		// 00: TIFF 6; Extension: TIF
-		"00",
-		// Agreement required:
-		// 01: IOCA FS 11; Extension: ICA
-		"01",
-		// 20: PNG (Portable Network Graphics); Extension: PNG ‘21’	JFIF (JPEG File Interchange Format); Extension: JPG
-		"20",
-		// 22: SPIFF (Still Picture Interchange File Format) (ITU-T Rec. T.84 Annex F); Extension: SPF
-		"22",
-		// 23: JBIG data stream (ITU-T Rec. T.82/ISO/IEC 11544:1993);
-		// Extension: JBG ‘24’	JPEG 2000 (ISO/IEC 15444-1:2000);
-		// Extension: JP2
-		"23":
+	if _, ok := imageViewFormatIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -490,22 +650,7 @@ func (v *validator) isImageViewFormatIndicator(code string) error {
 
 // isImageViewCompressionAlgorithm ensures ImageViewCompressionAlgorithm of a ImageViewDetail is valid
 func (v *validator) isImageViewCompressionAlgorithm(code string) error {
-	switch code {
-	case
-		// Agreement not required:
-		// 00: Group 4 facsimile compression (ITU-T Rec. T.563/CCITT Rec. T.6)
-		"00",
-		// Agreement required:
-		// 01: JPEG Baseline (JPEG Interchange Format) (ITU-T Rec. T.81/ISO/IEC 10918)
-		"01",
-		// 02: ABIC
-		"02",
-		// 21: PNG (Portable Network Graphics)
-		"21",
-		// 22: JBIG (ITU-T Rec. T.82/ISO/IEC 11544:1993)
-		"22",
-		// 23: JPEG 2000 (ISO/IEC 15444–1:2000)
-		"23":
+	if _, ok := imageViewCompressionAlgorithmCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -513,12 +658,7 @@ func (v *validator) isImageViewCompressionAlgorithm(code string) error {
 
 // isViewSideIndicator ensures ViewSideIndicator of a ImageViewDetail is valid
 func (v *validator) isViewSideIndicator(code int) error {
-	switch code {
-	case
-		// Front image view
-		0,
-		// Rear image view
-		1:
+	if _, ok := viewSideIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -526,38 +666,7 @@ func (v *validator) isViewSideIndicator(code int) error {
 
 // isViewDescriptor ensures ViewDescriptor of a ImageViewDetail is valid
 func (v *validator) isViewDescriptor(code string) error {
-	switch code {
-	case
-		// Full view
-		"00",
-		// Partial view–unspecified Area of Interest
-		"01",
-		// Partial view–date Area of Interest
-		"02",
-		// Partial view–payee Area of Interest
-		"03",
-		// Partial view–convenience amount Area of Interest
-		"04",
-		// Partial view–amount in words (legal amount) Area of Interest
-		"05",
-		// Partial view–signature Area(s) of Interest
-		"06",
-		// Partial view–payor name and address Area of Interest
-		"07",
-		// Partial view–MICR line Area of Interest
-		"08",
-		// Partial view–memo line Area of Interest
-		"09",
-		// Partial view–payor bank name and address Area of Interest
-		"10",
-		// Partial view–payee endorsement Area of Interest
-		"11",
-		// Partial view–Bank Of First Deposit (BOFD) endorsement Area of Interest
-		"12",
-		// Partial view–transit endorsement Area of Interest
-		"13",
-		// 14 - 99 Reserved for X9
-		"14":
+	if _, ok := viewDescriptorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -565,12 +674,7 @@ func (v *validator) isViewDescriptor(code string) error {
 
 // isDigitalSignatureIndicator ensures DigitalSignatureIndicator of a ImageViewDetail is valid
 func (v *validator) isDigitalSignatureIndicator(code int) error {
-	switch code {
-	case
-		// Digital Signature is not present
-		0,
-		// Digital Signature is present
-		1:
+	if _, ok := digitalSignatureIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -578,20 +682,7 @@ func (v *validator) isDigitalSignatureIndicator(code int) error {
 
 // isDigitalSignatureMethod ensures DigitalSignatureMethod of a ImageViewDetail is valid
 func (v *validator) isDigitalSignatureMethod(code string) error {
-	switch code {
-	case
-		// 00: Digital Signature Algorithm (DSA) with SHA1 (ANSI X9.30)
-		"00",
-		// 01: RSA with MD5 (ANSI X9.31)
-		"01",
-		// 02: RSA with MDC2 (ANSI X9.31)
-		"02",
-		// 03: RSA with SHA1 (ANSI X9.31)
-		"03",
-		// 04: Elliptic Curve DSA (ECDSA) with SHA1 (ANSI X9.62)
-		"04",
-		// 05 - 99: Reserved for emerging cryptographic algorithms.
-		"05":
+	if _, ok := digitalSignatureMethodCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -599,12 +690,7 @@ func (v *validator) isDigitalSignatureMethod(code string) error {
 
 // isImageRecreateIndicator ensures ImageRecreateIndicator of a ImageViewDetail is valid
 func (v *validator) isImageRecreateIndicator(code int) error {
-	switch code {
-	case
-		// Sender can recreate the image view for the duration of the agreed upon retention time frames.
-		0,
-		// Sender cannot recreate image view.
-		1:
+	if _, ok := imageRecreateIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -612,62 +698,16 @@ func (v *validator) isImageRecreateIndicator(code int) error {
 
 // isOverrideIndicator ensures OverrideIndicator of a ImageViewDetail is valid
 func (v *validator) isOverrideIndicator(code string) error {
-	switch code {
-	case
-		// blank/space indicates no observed image test failure present
-		"",
-		// No override information for this view or not applicable
-		"0",
-		// Imperfect image
-		"1",
-		// IQA Fail–Image view reviewed and deemed usable—no alternate format
-		"A",
-		// B: IQA Fail–Image view reviewed and deemed usable—alternate format included in this file
-		"B",
-		// C: IQA Fail–Image view reviewed and deemed usable–alternate format included in this file and original
-		// document available
-		"C",
-		// D: IQA Fail–Image view reviewed and deemed usable–alternate format available
-		"D",
-		// E: IQA Fail–Image view reviewed and deemed usable–original document available
-		"E",
-		// F: IQA Fail–Image view reviewed and deemed usable–original document and alternate format available
-		"F",
-		// G: IQA Fail–Image view reviewed and deemed unusable–no alternate format
-		"G",
-		// H: IQA Fail–Image view reviewed and deemed unusable–alternate format included in this file
-		"H",
-		// I: IQA Fail–Image view reviewed and deemed unusable–alternate format included in this file and original
-		// document available
-		"I",
-		// J: IQA Fail–Image view reviewed and deemed unusable–alternate format available
-		"J",
-		// K: IQA Fail–Image view reviewed and deemed unusable–original document available
-		"K",
-		// L: IQA Fail–Image view reviewed and deemed unusable–original document and alternate format available
-		"L",
-		// M: IQA Fail–Image view not reviewed–no alternate format
-		"M",
-		// N: IQA Fail–Image view not reviewed–alternate format included in this file
-		"N",
-		// IQA Fail–Image view not reviewed–alternate format included in this file and original
-		"O":
-		return nil
-	}
-	return errors.New(msgInvalid)
-}
-
-// isImageViewAnalysisValid ensures generic properties of imageViewAnalysis are valid
-func (v *validator) isImageViewAnalysisValid(code string) error {
-	switch code {
-	case
-		"",
-		// Refer to ImageViewAnalysis property
-		"0",
-		// Refer to ImageViewAnalysis property
-		"1",
-		// Refer to ImageViewAnalysis property
-		"2":
+	if _, ok := overrideIndicatorCodes[code]; ok {
+		return nil
+	}
+	return errors.New(msgInvalid)
+}
+
+// isImageViewAnalysisIndicator ensures an enumerated indicator property of ImageViewAnalysis is valid. The value is
+// checked rather than its 1 digit field, which would hide values such as 10 or -1 that are truncated when written.
+func (v *validator) isImageViewAnalysisIndicator(code int) error {
+	if _, ok := imageViewAnalysisIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -677,29 +717,27 @@ func (v *validator) isImageViewAnalysisValid(code string) error {
 
 // isReturnNotificationIndicator ensures ReturnNotificationIndicator of ReturnDetail is valid
 func (v *validator) isReturnNotificationIndicator(code int) error {
-	switch code {
-	case
-		// Preliminary notification
-		1,
-		// Final notification
-		2:
+	if _, ok := returnNotificationIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
 }
 
+// isReturnReason ensures ReturnReason of a ReturnDetail, CheckDetailAddendumC and ReturnDetailAddendumD is one
+// of the codes in CustomerReturnCodeDict or AdministrativeReturnCodeDict
+func (v *validator) isReturnReason(code string) error {
+	if _, ok := CustomerReturnCodeDict[code]; ok {
+		return nil
+	}
+	if _, ok := AdministrativeReturnCodeDict[code]; ok {
+		return nil
+	}
+	return errors.New(msgReturnCode)
+}
+
 // isTimesReturned ensures TimeReturned of ReturnDetail is valid
 func (v *validator) isTimesReturned(code int) error {
-	switch code {
-	case
-		// The item has been returned an unknown number of times
-		0,
-		// The item has been returned once
-		1,
-		// The item has been returned twice
-		2,
-		// The item has been returned three times
-		3:
+	if _, ok := timesReturnedCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -707,21 +745,7 @@ func (v *validator) isTimesReturned(code int) error {
 
 // isAccountTypeCode ensures AccountTypeCode of CheckItem is valid
 func (v *validator) isAccountTypeCode(code string) error {
-	switch code {
-	case
-		// Unknown
-		"0",
-		// DDA account
-		"1",
-		// General Ledger account
-		"2",
-		// Savings account
-		"3",
-		// Money Market account
-		"4",
-		//Other account
-		"5",
-		"A", "B", "C", "D", "E", "F", "G", "H", "I", "J":
+	if _, ok := accountTypeCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -729,36 +753,7 @@ func (v *validator) isAccountTypeCode(code string) error {
 
 // isSourceWorkCode ensures SourceWorkCode of CheckItem is valid
 func (v *validator) isSourceWorkCode(code string) error {
-	switch code {
-	case
-		// Unknown
-		"00",
-		//Internal–ATM
-		"01",
-		// Internal–Branch
-		"02",
-		// Internal–Other
-		"03",
-		// External–Bank to Bank (Correspondent)
-		"04",
-		// External–Business to Bank (Customer)
-		"05",
-		// External–Business to Bank Remote Capture
-		"06",
-		// External–Processor to Bank
-		"07",
-		//External–Bank to Processor
-		"08",
-		// Lockbox
-		"09",
-		// International–Internal
-		"10",
-		//International–External
-		"11",
-		// User Defined
-		"21", "22", "23", "24", "25", "26", "27", "28", "29", "30",
-		"31", "32", "33", "34", "35", "36", "37", "38", "39", "40",
-		"41", "42", "43", "44", "45", "46", "47", "48", "49", "50":
+	if _, ok := sourceWorkCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -766,20 +761,7 @@ func (v *validator) isSourceWorkCode(code string) error {
 
 // isOwnerIdentifierIndicator ensures OwnerIdentifierIndicator of User* is valid
 func (v *validator) isOwnerIdentifierIndicator(code int) error {
-	switch code {
-	case
-		// Not Used
-		0,
-		// Routing Number
-		1,
-		// DUNS Number
-		2,
-		// Federal Tax Identification Number
-		3,
-		// X9 Assignment
-		4,
-		//Other
-		5:
+	if _, ok := ownerIdentifierIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -787,23 +769,7 @@ func (v *validator) isOwnerIdentifierIndicator(code int) error {
 
 // isEndorsementIndicator ensures EndorsementIndicator of UserPayeeEndorsement is valid
 func (v *validator) isEndorsementIndicator(code int) error {
-	switch code {
-	case
-		// Endorsed in Blank–Instrument becomes payable to bearer
-		0,
-		// For Deposit Only
-		1,
-		// For Collection Only
-		2,
-		// Anomalous Endorsement–Endorsement made by person who is not holder of instrument
-		3,
-		// Restrictive Endorsement–Limiting to a particular person or situation
-		4,
-		// Guaranteed Endorsement–Deposit to the account of within named payee absence of endorsement guaranteed by
-		// the bank whose Routing Number appears in BankRoutingNumber
-		5,
-		// Other
-		9:
+	if _, ok := endorsementIndicatorCodes[code]; ok {
 		return nil
 	}
 	return errors.New(msgInvalid)
@@ -835,11 +801,53 @@ func (v *validator) isAlphanumericSpecial(s string) error {
 	return nil
 }
 
-// isNumeric checks if a string only contains ASCII numeric (0-9) characters
+// isNumeric checks if a string only contains ASCII numeric (0-9) characters, which may be preceded or
+// followed by blanks. Blanks between digits are rejected as the value could not be parsed as a number.
 func (v *validator) isNumeric(s string) error {
-	if numericRegex.MatchString(s) {
+	if numericRegex.MatchString(s) || embeddedBlankRegex.MatchString(s) {
 		// [^ 0-9]
 		return errors.New(msgNumeric)
 	}
 	return nil
 }
+
+// isNBSM checks if a string only contains numeric-blank/special MICR characters, which are 0-9, blank, dash
+// and asterisk for a character which could not be read
+func (v *validator) isNBSM(s string) error {
+	if nbsmRegex.MatchString(s) {
+		return errors.New(msgNBSM)
+	}
+	return nil
+}
+
+// isNBSMOS checks if a string only contains numeric-blank/special MICR On-Us characters, which are the NBSM
+// characters and the On-Us symbol written as /
+func (v *validator) isNBSMOS(s string) error {
+	if nbsmosRegex.MatchString(s) {
+		return errors.New(msgNBSMOS)
+	}
+	return nil
+}
+
+// isYYYYMMDDDate ensures s is eight digits forming a calendar date, such as 20180905
+func (v *validator) isYYYYMMDDDate(s string) error {
+	if len(s) != 8 || numericRegex.MatchString(s) || strings.Contains(s, " ") {
+		return errors.New(msgDate)
+	}
+	if _, err := time.Parse("20060102", s); err != nil {
+		return errors.New(msgDate)
+	}
+	return nil
+}
+
+// isDateInRange ensures a date is not before 1990 or after 2099. The zero time is a date
+// which has not been set and is accepted.
+func (v *validator) isDateInRange(t time.Time) error {
+	if t.IsZero() {
+		return nil
+	}
+	if t.Before(earliestDate) || !t.Before(latestDate) {
+		return errors.New(msgDateRange)
+	}
+	return nil
+}
@@ -6,7 +6,12 @@ package imagecashletter
 
 import (
 	"bufio"
+	"encoding/binary"
+	"fmt"
 	"io"
+	"os"
+	"reflect"
+	"strconv"
 )
 
 // A Writer writes an imagecashletter.file to an encoded file.
@@ -18,26 +23,144 @@ import (
 type Writer struct {
 	w       *bufio.Writer
 	lineNum int //current line being written
+	// blockSize is the size of each block when writing blocked output, or 0 for one record per line
+	blockSize int
+	// blockOffset is the number of bytes written to the current block
+	blockOffset int
+	// strictFieldWidths returns an error rather than truncating values longer than their field
+	strictFieldWidths bool
+	// layout is the StandardLevel whose record layouts the current File is written with
+	layout string
+	// alphaFill and numericFill are the fill characters set by WriteFillOption, or 0 for the standard fill
+	alphaFill   byte
+	numericFill byte
+	// dest is the io.Writer w writes to, which Flush syncs when sync is set by WriteSyncOption
+	dest io.Writer
+	sync bool
+	// buf holds the record being written, and grows to the longest record written
+	buf []byte
 }
 
-// NewWriter returns a new Writer that writes to w.
-func NewWriter(w io.Writer) *Writer {
-	return &Writer{
-		w: bufio.NewWriter(w),
+// WriterOption can be used to change default behavior of Writer
+type WriterOption func(*Writer)
+
+// WriteBlockedOption writes records in the X9 blocked format rather than one record per line.
+// Each record is preceded by its length as a 4 byte big-endian binary value (field 0) and records
+// are packed into blocks of blockSize bytes, with the final short block padded with blockFill.
+// Records may span blocks. Blocked files are read with ReadBlockedOption.
+func WriteBlockedOption(blockSize int) WriterOption {
+	return func(w *Writer) {
+		w.blockSize = blockSize
+	}
+}
+
+// WriteStrictFieldWidthsOption makes Write return a FieldError when a value is longer than the
+// fixed width of its field, rather than truncating the value to fit.
+func WriteStrictFieldWidthsOption() WriterOption {
+	return func(w *Writer) {
+		w.strictFieldWidths = true
+	}
+}
+
+// WriteFillOption writes alphanumeric fields filled with alphaFill rather than blanks and numeric fields
+// filled with numericFill rather than zeros, for the rare receiver which expects a different fill. A fill
+// of 0 keeps the standard fill for that type of field. Numeric-blank/special MICR fields are always blank
+// filled. Reader only removes blank fill, so a File written with other fill may not read back the same.
+func WriteFillOption(alphaFill, numericFill byte) WriterOption {
+	return func(w *Writer) {
+		w.alphaFill = alphaFill
+		w.numericFill = numericFill
+	}
+}
+
+// WriteSyncOption makes Flush commit the written data to stable storage after flushing it, by calling
+// the Sync method of the io.Writer the Writer writes to, such as an *os.File. An io.Writer without a
+// Sync() error method is only flushed.
+func WriteSyncOption() WriterOption {
+	return func(w *Writer) {
+		w.sync = true
+	}
+}
+
+// NewWriter returns a new Writer that writes to w. Records are buffered and the buffer is flushed to w
+// before Write and WriteAll return, so w holds every byte of the written Files once they return
+// without error.
+func NewWriter(w io.Writer, opts ...WriterOption) *Writer {
+	writer := &Writer{
+		w:    bufio.NewWriter(w),
+		dest: w,
+	}
+	for _, opt := range opts {
+		opt(writer)
+	}
+	return writer
+}
+
+// WriteFile writes file to the file at path with a Writer created with the WriterOptions opts, creating
+// it or truncating an existing file, and closes it. An error is returned if the file can not be written
+// in full or closed.
+func WriteFile(path string, file *File, opts ...WriterOption) error {
+	fd, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := NewWriter(fd, opts...).Write(file); err != nil {
+		fd.Close()
+		return err
 	}
+	return fd.Close()
 }
 
 // Writer writes a single imagecashletter.file record to w
 func (w *Writer) Write(file *File) error {
+	return w.WriteAll([]*File{file})
+}
+
+// WriteAll writes each File to w in order as a distinct logical file, with its own FileHeader and
+// FileControl, so the stream can be read back with Reader.ReadAll. Every File is validated before
+// any records are written. Blocked output is padded once after the last File.
+func (w *Writer) WriteAll(files []*File) error {
+	for _, file := range files {
+		if err := w.validateFile(file); err != nil {
+			return err
+		}
+	}
+	if w.blockSize < 0 {
+		msg := fmt.Sprintf(msgBlockSize, w.blockSize)
+		return &FileError{FieldName: "BlockSize", Value: strconv.Itoa(w.blockSize), Msg: msg}
+	}
+	w.lineNum = 0
+	w.blockOffset = 0
+	for _, file := range files {
+		if err := w.writeFile(file); err != nil {
+			return err
+		}
+	}
+	if err := w.padBlock(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// validateFile checks a File can be written
+func (w *Writer) validateFile(file *File) error {
 	if file == nil {
 		return ErrNilFile
 	}
 	if err := file.Validate(); err != nil {
 		return err
 	}
-	w.lineNum = 0
+	if file.ImageDataSkipped() {
+		return &FileError{FieldName: "ImageData", Msg: msgFileImageDataSkipped}
+	}
+	return nil
+}
+
+// writeFile writes the records of a File
+func (w *Writer) writeFile(file *File) error {
+	w.layout = file.layout()
 	// Iterate over all records in the file
-	if _, err := w.w.WriteString(file.Header.String() + "\n"); err != nil {
+	if err := w.writeRecord(&file.Header); err != nil {
 		return err
 	}
 	w.lineNum++
@@ -45,29 +168,146 @@ func (w *Writer) Write(file *File) error {
 	if err := w.writeCashLetter(file); err != nil {
 		return err
 	}
-	if _, err := w.w.WriteString(file.Control.String() + "\n"); err != nil {
+	if err := w.writeRecord(&file.Control); err != nil {
 		return err
 	}
 	w.lineNum++
+	return nil
+}
+
+// RecordBytes returns the bytes Write emits for the record r with the WriterOptions of w, which is the
+// record followed by a newline, or preceded by its length when writing blocked output, so a corrected
+// record can be spliced into a file written by w. Blocked output is never padded, as the padding depends
+// on the records before r. Records are laid out for the StandardLevel of the File last written by w,
+// or as X9.100-187-2008 and later before a File is written.
+func (w *Writer) RecordBytes(r fmt.Stringer) ([]byte, error) {
+	return w.appendWireRecord(nil, r)
+}
+
+// appendWireRecord appends the record r to b as it is written, followed by a newline or preceded by its
+// length when writing blocked output
+func (w *Writer) appendWireRecord(b []byte, r fmt.Stringer) ([]byte, error) {
+	start := len(b)
+	if w.blockSize > 0 {
+		b = append(b, make([]byte, recordLengthFieldSize)...)
+	}
+	b, err := w.appendRecord(b, r)
+	if err != nil {
+		return nil, err
+	}
+	if w.blockSize == 0 {
+		return append(b, '\n'), nil
+	}
+	binary.BigEndian.PutUint32(b[start:], uint32(len(b)-start-recordLengthFieldSize))
+	return b, nil
+}
+
+// recordAppender is a record which appends itself to a buffer as String returns it
+type recordAppender interface {
+	AppendTo(b []byte) []byte
+}
+
+// appendRecord appends the record r to b as it is written with the options of the Writer
+func (w *Writer) appendRecord(b []byte, r fmt.Stringer) ([]byte, error) {
+	if w.strictFieldWidths {
+		if err := checkFieldWidths(r); err != nil {
+			return nil, err
+		}
+	}
+	if w.alphaFill != 0 || w.numericFill != 0 {
+		r = w.fillRecord(r)
+	}
+	// a layoutRecord is only laid out differently from String for the DSTU layout
+	if lr, ok := r.(layoutRecord); ok && w.layout == StandardLevelDSTU2003 {
+		return append(b, lr.layoutString(w.layout)...), nil
+	}
+	if ra, ok := r.(recordAppender); ok {
+		return ra.AppendTo(b), nil
+	}
+	return append(b, r.String()...), nil
+}
+
+// writeRecord writes a single record followed by a newline, or preceded by its length when
+// writing blocked output. Each record is formatted in buf, which is reused for the next record.
+func (w *Writer) writeRecord(r fmt.Stringer) error {
+	record, err := w.appendWireRecord(w.buf[:0], r)
+	if err != nil {
+		return err
+	}
+	w.buf = record
+	if _, err := w.w.Write(record); err != nil {
+		return err
+	}
+	if w.blockSize > 0 {
+		w.blockOffset = (w.blockOffset + len(record)) % w.blockSize
+	}
+	return nil
+}
+
+// filledRecord is a record whose fields can be written with other fill characters
+type filledRecord interface {
+	fmt.Stringer
+	setFill(alphaFill, numericFill byte)
+}
+
+// fillRecord returns a copy of the record r using the fill characters of the Writer, so records of the File
+// being written aren't changed
+func (w *Writer) fillRecord(r fmt.Stringer) fmt.Stringer {
+	rv := reflect.ValueOf(r)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return r
+	}
+	cp := reflect.New(rv.Elem().Type())
+	cp.Elem().Set(rv.Elem())
+	record, ok := cp.Interface().(filledRecord)
+	if !ok {
+		return r
+	}
+	record.setFill(w.alphaFill, w.numericFill)
+	return record
+}
 
-	return w.w.Flush()
+// padBlock fills the remainder of the current block when writing blocked output
+func (w *Writer) padBlock() error {
+	if w.blockSize == 0 || w.blockOffset == 0 {
+		return nil
+	}
+	for ; w.blockOffset < w.blockSize; w.blockOffset++ {
+		if err := w.w.WriteByte(blockFill); err != nil {
+			return err
+		}
+	}
+	w.blockOffset = 0
+	return nil
 }
 
-// Flush writes any buffered data to the underlying io.Writer.
-// To check if an error occurred during the Flush, call Error.
-func (w *Writer) Flush() {
-	w.w.Flush()
+// Flush writes any buffered data to the underlying io.Writer and, with WriteSyncOption, syncs it.
+// Write and WriteAll flush before returning, so calling Flush after them isn't required.
+func (w *Writer) Flush() error {
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	if s, ok := w.dest.(interface{ Sync() error }); ok && w.sync {
+		return s.Sync()
+	}
+	return nil
 }
 
 // writeCashLetter writes a CashLetter to a file
 func (w *Writer) writeCashLetter(file *File) error {
 	for _, cl := range file.CashLetters {
-		if _, err := w.w.WriteString(cl.GetHeader().String() + "\n"); err != nil {
+		if err := w.writeRecord(cl.GetHeader()); err != nil {
 			return err
 		}
 		w.lineNum++
 		for _, ci := range cl.GetCreditItems() {
-			if _, err := w.w.WriteString(ci.String() + "\n"); err != nil {
+			if err := w.writeRecord(ci); err != nil {
+				return err
+			}
+			w.lineNum++
+		}
+		for _, cr := range cl.GetCredits() {
+			if err := w.writeRecord(cr); err != nil {
 				return err
 			}
 			w.lineNum++
@@ -77,12 +317,12 @@ func (w *Writer) writeCashLetter(file *File) error {
 		}
 		w.lineNum++
 		for _, rns := range cl.GetRoutingNumberSummary() {
-			if _, err := w.w.WriteString(rns.String() + "\n"); err != nil {
+			if err := w.writeRecord(rns); err != nil {
 				return err
 			}
 			w.lineNum++
 		}
-		if _, err := w.w.WriteString(cl.GetControl().String() + "\n"); err != nil {
+		if err := w.writeRecord(cl.GetControl()); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -93,10 +333,22 @@ func (w *Writer) writeCashLetter(file *File) error {
 // writeBundle writes a Bundle to a CashLetter
 func (w *Writer) writeBundle(cl CashLetter) error {
 	for _, b := range cl.GetBundles() {
-		if _, err := w.w.WriteString(b.GetHeader().String() + "\n"); err != nil {
+		if err := w.writeRecord(b.GetHeader()); err != nil {
 			return err
 		}
 		w.lineNum++
+		for _, ci := range b.CreditItems {
+			if err := w.writeRecord(ci); err != nil {
+				return err
+			}
+			w.lineNum++
+		}
+		for _, cr := range b.Credits {
+			if err := w.writeRecord(cr); err != nil {
+				return err
+			}
+			w.lineNum++
+		}
 
 		if len(b.Checks) > 0 {
 			if err := w.writeCheckDetail(b); err != nil {
@@ -108,7 +360,7 @@ func (w *Writer) writeBundle(cl CashLetter) error {
 				return err
 			}
 		}
-		if _, err := w.w.WriteString(b.GetControl().String() + "\n"); err != nil {
+		if err := w.writeRecord(b.GetControl()); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -119,7 +371,7 @@ func (w *Writer) writeBundle(cl CashLetter) error {
 // writeCheckDetail writes a CheckDetail to a Bundle
 func (w *Writer) writeCheckDetail(b *Bundle) error {
 	for _, cd := range b.GetChecks() {
-		if _, err := w.w.WriteString(cd.String() + "\n"); err != nil {
+		if err := w.writeRecord(cd); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -138,19 +390,19 @@ func (w *Writer) writeCheckDetail(b *Bundle) error {
 // writeCheckDetailAddendum writes a CheckDetailAddendum (A, B, C) to a CheckDetail
 func (w *Writer) writeCheckDetailAddendum(cd *CheckDetail) error {
 	for _, cdAddendumA := range cd.GetCheckDetailAddendumA() {
-		if _, err := w.w.WriteString(cdAddendumA.String() + "\n"); err != nil {
+		if err := w.writeRecord(&cdAddendumA); err != nil {
 			return err
 		}
 		w.lineNum++
 	}
 	for _, cdAddendumB := range cd.GetCheckDetailAddendumB() {
-		if _, err := w.w.WriteString(cdAddendumB.String() + "\n"); err != nil {
+		if err := w.writeRecord(&cdAddendumB); err != nil {
 			return err
 		}
 		w.lineNum++
 	}
 	for _, cdAddendumC := range cd.GetCheckDetailAddendumC() {
-		if _, err := w.w.WriteString(cdAddendumC.String() + "\n"); err != nil {
+		if err := w.writeRecord(&cdAddendumC); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -160,28 +412,13 @@ func (w *Writer) writeCheckDetailAddendum(cd *CheckDetail) error {
 
 // writeCheckImageView writes ImageViews (Detail, Data, Analysis) to a CheckDetail
 func (w *Writer) writeCheckImageView(cd *CheckDetail) error {
-	for _, ivDetail := range cd.GetImageViewDetail() {
-		if _, err := w.w.WriteString(ivDetail.String() + "\n"); err != nil {
-			return err
-		}
-	}
-	for _, ivData := range cd.GetImageViewData() {
-		if _, err := w.w.WriteString(ivData.String() + "\n"); err != nil {
-			return err
-		}
-	}
-	for _, ivAnalysis := range cd.GetImageViewAnalysis() {
-		if _, err := w.w.WriteString(ivAnalysis.String() + "\n"); err != nil {
-			return err
-		}
-	}
-	return nil
+	return w.writeImageViews(cd.GetImageViewDetail(), cd.GetImageViewData(), cd.GetImageViewAnalysis())
 }
 
 // writeReturnDetail writes a ReturnDetail to a ReturnBundle
 func (w *Writer) writeReturnDetail(b *Bundle) error {
 	for _, rd := range b.GetReturns() {
-		if _, err := w.w.WriteString(rd.String() + "\n"); err != nil {
+		if err := w.writeRecord(rd); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -199,25 +436,25 @@ func (w *Writer) writeReturnDetail(b *Bundle) error {
 // writeReturnDetailAddendum writes a ReturnDetailAddendum (A, B, C, D) to a ReturnDetail
 func (w *Writer) writeReturnDetailAddendum(rd *ReturnDetail) error {
 	for _, rdAddendumA := range rd.GetReturnDetailAddendumA() {
-		if _, err := w.w.WriteString(rdAddendumA.String() + "\n"); err != nil {
+		if err := w.writeRecord(&rdAddendumA); err != nil {
 			return err
 		}
 		w.lineNum++
 	}
 	for _, rdAddendumB := range rd.GetReturnDetailAddendumB() {
-		if _, err := w.w.WriteString(rdAddendumB.String() + "\n"); err != nil {
+		if err := w.writeRecord(&rdAddendumB); err != nil {
 			return err
 		}
 		w.lineNum++
 	}
 	for _, rdAddendumC := range rd.GetReturnDetailAddendumC() {
-		if _, err := w.w.WriteString(rdAddendumC.String() + "\n"); err != nil {
+		if err := w.writeRecord(&rdAddendumC); err != nil {
 			return err
 		}
 		w.lineNum++
 	}
 	for _, rdAddendumD := range rd.GetReturnDetailAddendumD() {
-		if _, err := w.w.WriteString(rdAddendumD.String() + "\n"); err != nil {
+		if err := w.writeRecord(&rdAddendumD); err != nil {
 			return err
 		}
 		w.lineNum++
@@ -227,23 +464,68 @@ func (w *Writer) writeReturnDetailAddendum(rd *ReturnDetail) error {
 
 // writeReturnImageView writes ImageViews (Detail, Data, Analysis) to a ReturnDetail
 func (w *Writer) writeReturnImageView(rd *ReturnDetail) error {
-	for _, ivDetail := range rd.GetImageViewDetail() {
-		if _, err := w.w.WriteString(ivDetail.String() + "\n"); err != nil {
-			return err
+	return w.writeImageViews(rd.GetImageViewDetail(), rd.GetImageViewData(), rd.GetImageViewAnalysis())
+}
+
+// writeImageViews writes each image view as its ImageViewDetail followed by the ImageViewData and
+// ImageViewAnalysis at the same index, if any.
+func (w *Writer) writeImageViews(details []ImageViewDetail, data []ImageViewData, analysis []ImageViewAnalysis) error {
+	for i := 0; i < len(details) || i < len(data) || i < len(analysis); i++ {
+		if i < len(details) {
+			if err := w.writeRecord(&details[i]); err != nil {
+				return err
+			}
+			w.lineNum++
 		}
-		w.lineNum++
-	}
-	for _, ivData := range rd.GetImageViewData() {
-		if _, err := w.w.WriteString(ivData.String() + "\n"); err != nil {
-			return err
+		if i < len(data) {
+			if err := w.writeRecord(&data[i]); err != nil {
+				return err
+			}
+			w.lineNum++
+		}
+		if i < len(analysis) {
+			if err := w.writeRecord(&analysis[i]); err != nil {
+				return err
+			}
+			w.lineNum++
 		}
-		w.lineNum++
 	}
-	for _, ivAnalysis := range rd.GetImageViewAnalysis() {
-		if _, err := w.w.WriteString(ivAnalysis.String() + "\n"); err != nil {
-			return err
+	return nil
+}
+
+// checkFieldWidths returns a FieldError for the first exported string or int field of record which is
+// longer than the width written by its corresponding <Name>Field method.
+func checkFieldWidths(record interface{}) error {
+	rv := reflect.ValueOf(record)
+	v := reflect.Indirect(rv)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		method := rv.MethodByName(sf.Name + "Field")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 ||
+			method.Type().Out(0).Kind() != reflect.String {
+			continue
+		}
+		var value string
+		switch fv := v.Field(i); fv.Kind() {
+		case reflect.String:
+			value = fv.String()
+		case reflect.Int:
+			value = strconv.Itoa(int(fv.Int()))
+		default:
+			continue
+		}
+		width := len(method.Call(nil)[0].String())
+		if len(value) > width {
+			msg := fmt.Sprintf(msgFieldWidth, len(value), t.Name(), width)
+			return &FieldError{FieldName: sf.Name, Value: value, Msg: msg}
 		}
-		w.lineNum++
 	}
 	return nil
 }
@@ -6,7 +6,11 @@ package imagecashletter
 
 import (
 	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -236,3 +240,468 @@ func TestICLWriteRoutingNumber(t *testing.T) {
 	}
 
 }
+
+// TestICLWriteBlocked writes an ICL file in the blocked format and reads it back
+func TestICLWriteBlocked(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("Can not open local file: %s: \n", err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var lines bytes.Buffer
+	if err := NewWriter(&lines).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// a block size which is not a multiple of the record length
+	blockSize := 7 * 80 / 2
+	var blocked bytes.Buffer
+	if err := NewWriter(&blocked, WriteBlockedOption(blockSize)).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if blocked.Len()%blockSize != 0 {
+		t.Errorf("blocked output of %d bytes is not a multiple of %d", blocked.Len(), blockSize)
+	}
+	if !bytes.HasPrefix(blocked.Bytes(), []byte{0, 0, 0, 80, '0', '1'}) {
+		t.Errorf("unexpected first record: %q", blocked.Bytes()[:6])
+	}
+	if blocked.Bytes()[blocked.Len()-1] != blockFill {
+		t.Errorf("expected final block to be padded with fill")
+	}
+
+	read, err := NewReader(bytes.NewReader(blocked.Bytes()), ReadBlockedOption()).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	var roundTrip bytes.Buffer
+	if err := NewWriter(&roundTrip).Write(&read); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(lines.Bytes(), roundTrip.Bytes()) {
+		t.Error("blocked round trip does not match the original file")
+	}
+
+	// a record cut short by the end of input
+	_, err = NewReader(bytes.NewReader(blocked.Bytes()[:200]), ReadBlockedOption()).Read()
+	if err == nil || !strings.Contains(err.Error(), "RecordLength") {
+		t.Errorf("expected RecordLength error, got %v", err)
+	}
+
+	// data following the block fill
+	trailing := append(append([]byte(nil), blocked.Bytes()...), 0, 0, 0, 80)
+	trailing = append(trailing, lines.Bytes()[:80]...)
+	trailing = append(trailing, 'X')
+	_, err = NewReader(bytes.NewReader(trailing), ReadBlockedOption()).Read()
+	if err == nil {
+		t.Error("expected error reading data after block fill")
+	}
+
+	if err := NewWriter(&blocked, WriteBlockedOption(-1)).Write(&file); err == nil {
+		t.Error("expected error writing with a negative block size")
+	}
+}
+
+// TestICLWriteStrictFieldWidths validates overlong values are errors with WriteStrictFieldWidthsOption
+func TestICLWriteStrictFieldWidths(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatalf("Can not open local file: %s: \n", err)
+	}
+	defer fd.Close()
+	file, err := NewReader(fd).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := NewWriter(&bytes.Buffer{}, WriteStrictFieldWidthsOption()).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	cd := file.CashLetters[0].Bundles[0].Checks[0]
+	cd.ItemAmount = 12345678901 // $123,456,789.01 does not fit in 10 digits
+
+	// silently truncated by default
+	if err := NewWriter(&bytes.Buffer{}).Write(&file); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	err = NewWriter(&bytes.Buffer{}, WriteStrictFieldWidthsOption()).Write(&file)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ItemAmount" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	cd.ItemAmount = 100
+	cd.CheckDetailAddendumA[0].BOFDAccountNumber = strings.Repeat("1", 19)
+	err = NewWriter(&bytes.Buffer{}, WriteStrictFieldWidthsOption()).Write(&file)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "BOFDAccountNumber" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestICLWriteStrictFieldWidthsMock validates the mock records fit their fields
+func TestICLWriteStrictFieldWidthsMock(t *testing.T) {
+	file := mockManyCashLetterFile(2)
+	file.CashLetters[0].AddCreditItem(mockCreditItem())
+	file.CashLetters[0].AddCredit(mockCredit())
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := NewWriter(&bytes.Buffer{}, WriteStrictFieldWidthsOption()).Write(file); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestICLWriteAll writes two files to one stream and reads them back
+func TestICLWriteAll(t *testing.T) {
+	first := mockMergeFile("A1")
+	second := mockManyCashLetterFile(2)
+
+	for _, opts := range [][]WriterOption{nil, {WriteBlockedOption(400)}} {
+		var buf bytes.Buffer
+		if err := NewWriter(&buf, opts...).WriteAll([]*File{first, second}); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+
+		var readOpts []ReaderOption
+		if opts != nil {
+			readOpts = append(readOpts, ReadBlockedOption())
+		}
+		files, err := NewReader(bytes.NewReader(buf.Bytes()), readOpts...).ReadAll()
+		if err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if len(files) != 2 {
+			t.Fatalf("expected 2 files, got %d", len(files))
+		}
+		for i, want := range []*File{first, second} {
+			var wantBuf, gotBuf bytes.Buffer
+			if err := NewWriter(&wantBuf).Write(want); err != nil {
+				t.Fatal(err)
+			}
+			if err := NewWriter(&gotBuf).Write(files[i]); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(wantBuf.Bytes(), gotBuf.Bytes()) {
+				t.Errorf("file %d does not match after round trip", i)
+			}
+		}
+	}
+
+	// nothing is written when a File is invalid
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).WriteAll([]*File{first, nil}); err != ErrNilFile {
+		t.Errorf("%T: %s", err, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %d bytes", buf.Len())
+	}
+}
+
+// TestICLWriteImageViewOrder validates each ImageViewDetail is written followed by its ImageViewData and
+// ImageViewAnalysis so files with several image views can be read back
+func TestICLWriteImageViewOrder(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	cd := mockCheckDetail()
+	cd.AddCheckDetailAddendumA(mockCheckDetailAddendumA())
+	cd.AddCheckDetailAddendumB(mockCheckDetailAddendumB())
+	cd.AddCheckDetailAddendumC(mockCheckDetailAddendumC())
+	for side := 0; side < 2; side++ {
+		ivDetail := mockImageViewDetail()
+		ivDetail.ViewSideIndicator = side
+		cd.AddImageViewDetail(ivDetail)
+		cd.AddImageViewData(mockImageViewData())
+		cd.AddImageViewAnalysis(mockImageViewAnalysis())
+	}
+	bundle := NewBundle(mockBundleHeader())
+	bundle.AddCheckDetail(cd)
+	cl := NewCashLetter(mockCashLetterHeader())
+	cl.AddBundle(bundle)
+	if err := cl.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file.AddCashLetter(cl)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	var recordTypes []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		recordTypes = append(recordTypes, line[:2])
+	}
+	if got := strings.Join(recordTypes[7:13], " "); got != "50 52 54 50 52 54" {
+		t.Errorf("unexpected image view records %s", got)
+	}
+	read, err := NewReader(&buf).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := len(read.CashLetters[0].Bundles[0].Checks[0].ImageViewDetail); n != 2 {
+		t.Errorf("expected 2 ImageViewDetail, got %d", n)
+	}
+}
+
+// TestICLWriteFill validates WriteFillOption changes the fill of alphanumeric and numeric fields without
+// changing the File
+func TestICLWriteFill(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf, WriteFillOption('_', ' ')).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if fh := lines[0]; len(fh) != 80 || !strings.Contains(fh, "Citadel___________Wells Fargo________US") {
+		t.Errorf("FileHeader %q", fh)
+	}
+	if cd := lines[3]; len(cd) != 80 || cd[47:57] != "    100000" {
+		t.Errorf("CheckDetail %q", cd)
+	}
+
+	// the File is written with the standard fill afterwards
+	buf.Reset()
+	if err := NewWriter(&buf, WriteFillOption(0, 0)).Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(buf.Bytes()), bytes.TrimSpace(bs)) {
+		t.Error("written file differs from input")
+	}
+}
+
+// readWriterTestFile reads a sample File to be written
+func readWriterTestFile(t *testing.T) *File {
+	t.Helper()
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return &file
+}
+
+// TestICLWritePipe writes a File to a pipe, which receives every byte before Write returns
+func TestICLWritePipe(t *testing.T) {
+	file := readWriterTestFile(t)
+	var expected bytes.Buffer
+	if err := NewWriter(&expected).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	pr, pw := io.Pipe()
+	received := make(chan []byte)
+	go func() {
+		bs, _ := ioutil.ReadAll(pr)
+		received <- bs
+	}()
+	w := NewWriter(pw)
+	if err := w.Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	pw.Close()
+	if got := <-received; !bytes.Equal(got, expected.Bytes()) {
+		t.Errorf("received %d bytes, expected %d", len(got), expected.Len())
+	}
+
+	// a failed write to the pipe is returned by Flush
+	pr, pw = io.Pipe()
+	pr.CloseWithError(io.ErrClosedPipe)
+	w = NewWriter(pw)
+	if err := w.Write(file); err != io.ErrClosedPipe {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := w.Flush(); err != io.ErrClosedPipe {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// syncBuffer is a bytes.Buffer which counts calls to Sync
+type syncBuffer struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return nil
+}
+
+// TestICLWriteSync syncs the underlying io.Writer after writing with WriteSyncOption
+func TestICLWriteSync(t *testing.T) {
+	file := readWriterTestFile(t)
+
+	var buf syncBuffer
+	if err := NewWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if buf.syncs != 0 {
+		t.Errorf("unexpected %d syncs", buf.syncs)
+	}
+	if err := NewWriter(&buf, WriteSyncOption()).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if buf.syncs != 1 {
+		t.Errorf("unexpected %d syncs", buf.syncs)
+	}
+
+	f, err := ioutil.TempFile("", "icl-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := NewWriter(f, WriteSyncOption()).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if info, err := f.Stat(); err != nil || info.Size() == 0 {
+		t.Errorf("unexpected size: %v", err)
+	}
+}
+
+// TestICLWriteRecordBytes validates RecordBytes matches the records written by a Writer with the same options
+func TestICLWriteRecordBytes(t *testing.T) {
+	file := readWriterTestFile(t)
+	cl := file.CashLetters[0]
+	b := cl.GetBundles()[0]
+	cd := b.GetChecks()[0]
+
+	tests := map[string][]WriterOption{
+		"lines":   nil,
+		"blocked": {WriteBlockedOption(7 * 80 / 2)},
+		"fill":    {WriteFillOption('*', '#')},
+	}
+	for name, opts := range tests {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, opts...)
+		if err := w.Write(file); err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+
+		// the leading records of the file, in the order they're written
+		var leading []byte
+		records := []fmt.Stringer{&file.Header, cl.CashLetterHeader, b.BundleHeader, cd,
+			&cd.CheckDetailAddendumA[0], &cd.CheckDetailAddendumB[0], &cd.CheckDetailAddendumC[0],
+			&cd.ImageViewDetail[0], &cd.ImageViewData[0]}
+		for _, r := range records {
+			bs, err := w.RecordBytes(r)
+			if err != nil {
+				t.Fatalf("%s: %T: %s", name, err, err)
+			}
+			leading = append(leading, bs...)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), leading) {
+			t.Errorf("%s: RecordBytes doesn't match the written records", name)
+		}
+
+		bs, err := w.RecordBytes(&file.Control)
+		if err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+		if written := bytes.TrimRight(buf.Bytes(), string(blockFill)); !bytes.HasSuffix(written, bs) {
+			t.Errorf("%s: RecordBytes %q doesn't match the written FileControl", name, bs)
+		}
+	}
+
+	if _, err := NewWriter(ioutil.Discard, WriteStrictFieldWidthsOption()).RecordBytes(&CheckDetail{OnUs: strings.Repeat("1", 21)}); err == nil {
+		t.Error("expected error for an overlong field")
+	}
+}
+
+// TestWriteFile validates WriteFile writes a file by name which ReadFile reads back
+func TestWriteFile(t *testing.T) {
+	file := readWriterTestFile(t)
+	dir, err := ioutil.TempDir("", "icl-writefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file.icl")
+	if err := WriteFile(path, file, WriteBlockedOption(800)); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	read, err := ReadFile(path, ReadBlockedOption())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	var want, got bytes.Buffer
+	if err := NewWriter(&want).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := NewWriter(&got).Write(read); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !bytes.Equal(want.Bytes(), got.Bytes()) {
+		t.Error("file written by WriteFile does not read identically")
+	}
+
+	if err := WriteFile(filepath.Join(dir, "missing", "file.icl"), file); err == nil {
+		t.Error("expected error creating a file in a missing directory")
+	}
+	if err := WriteFile(path, nil); err == nil {
+		t.Error("expected error writing a nil File")
+	}
+}
+
+// benchmarkWriterFile reads the file written by the writer benchmarks
+func benchmarkWriterFile(b *testing.B) *File {
+	b.Helper()
+	bs, err := ioutil.ReadFile(filepath.Join("test", "testdata", "BNK20180905121042882-A.icl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		b.Fatalf("%T: %s", err, err)
+	}
+	return &file
+}
+
+// BenchmarkICLWrite benchmarks writing a File, without reading it
+func BenchmarkICLWrite(b *testing.B) {
+	file := benchmarkWriterFile(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewWriter(ioutil.Discard).Write(file); err != nil {
+			b.Fatalf("%T: %s", err, err)
+		}
+	}
+}
+
+// BenchmarkCheckDetailString benchmarks formatting the CheckDetail and its addenda
+func BenchmarkCheckDetailString(b *testing.B) {
+	cd := benchmarkWriterFile(b).CashLetters[0].Bundles[0].Checks[0]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = cd.String()
+		for j := range cd.CheckDetailAddendumA {
+			_ = cd.CheckDetailAddendumA[j].String()
+		}
+		for j := range cd.CheckDetailAddendumB {
+			_ = cd.CheckDetailAddendumB[j].String()
+		}
+		for j := range cd.CheckDetailAddendumC {
+			_ = cd.CheckDetailAddendumC[j].String()
+		}
+	}
+}